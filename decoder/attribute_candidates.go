@@ -9,18 +9,25 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/hcl-lang/internal/syntaxhelper"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 )
 
 func attributeSchemaToCandidate(ctx context.Context, name string, attr *schema.AttributeSchema, rng hcl.Range) lang.Candidate {
 	var snippet string
 	var triggerSuggest bool
-	cData := attr.Constraint.EmptyCompletionData(ctx, 1, 0)
+	cData := schema.EmptyCompletionDataOf(ctx, attr.Constraint, 1, 0)
 	snippet = fmt.Sprintf("%s = %s", name, cData.Snippet)
 	triggerSuggest = cData.TriggerSuggest
+	if cData.Snippet == "" {
+		// no value is inserted, so we follow up with value
+		// completion right after "name = " is inserted
+		triggerSuggest = true
+	}
 
 	return lang.Candidate{
 		Label:        name,
@@ -59,6 +66,32 @@ func detailForAttribute(attr *schema.AttributeSchema) string {
 	return strings.Join(details[:], ", ")
 }
 
+// attributeRelations returns names of attributes declared in the body
+// which require the named attribute (via RequiredWith) and names
+// of declared attributes which conflict with it (via ConflictsWith
+// in either direction).
+func attributeRelations(body *hclsyntax.Body, bodySchema *schema.BodySchema, name string) (requiredBy, conflictsWith []string) {
+	attr, ok := bodySchema.Attributes[name]
+	if !ok {
+		return nil, nil
+	}
+
+	for _, declared := range syntaxhelper.SortedBodyAttributes(body) {
+		declaredAttr, ok := bodySchema.Attributes[declared.Name]
+		if !ok {
+			continue
+		}
+		if stringsContain(declaredAttr.RequiredWith, name) {
+			requiredBy = append(requiredBy, declared.Name)
+		}
+		if stringsContain(declaredAttr.ConflictsWith, name) || stringsContain(attr.ConflictsWith, declared.Name) {
+			conflictsWith = append(conflictsWith, declared.Name)
+		}
+	}
+
+	return requiredBy, conflictsWith
+}
+
 func sortedObjectAttrNames(obj cty.Type) []string {
 	if !obj.IsObjectType() {
 		return []string{}
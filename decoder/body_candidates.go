@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 
@@ -21,10 +22,14 @@ func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.
 
 	candidates := lang.NewCandidates()
 	count := 0
+	// isRanked indicates whether any candidate is prioritized
+	// or deprioritized per IsRequired, RequiredWith or ConflictsWith
+	isRanked := false
 
 	if schema.Extensions != nil {
 		// check if count attribute "extension" is enabled here
-		if schema.Extensions.Count {
+		// and not shadowed by an explicitly declared attribute
+		if _, declared := schema.Attributes["count"]; schema.Extensions.Count && !declared {
 			// check if count attribute is already declared, so we don't
 			// suggest a duplicate
 			if _, ok := body.Attributes["count"]; !ok {
@@ -32,13 +37,29 @@ func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.
 			}
 		}
 
-		if schema.Extensions.ForEach {
+		if _, declared := schema.Attributes["for_each"]; schema.Extensions.ForEach && !declared {
 			// check if for_each attribute is already declared, so we don't
 			// suggest a duplicate
 			if _, present := body.Attributes["for_each"]; !present {
 				candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "for_each", schemahelper.ForEachAttributeSchema(), editRng))
 			}
 		}
+
+		if _, declared := schema.Attributes["provider"]; schema.Extensions.Provider && !declared {
+			// check if provider attribute is already declared, so we don't
+			// suggest a duplicate
+			if _, present := body.Attributes["provider"]; !present {
+				candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "provider", schemahelper.ProviderAttributeSchema(), editRng))
+			}
+		}
+
+		if _, declared := schema.Attributes["depends_on"]; schema.Extensions.DependsOn && !declared {
+			// check if depends_on attribute is already declared, so we don't
+			// suggest a duplicate
+			if _, present := body.Attributes["depends_on"]; !present {
+				candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, "depends_on", schemahelper.DependsOnAttributeSchema(), editRng))
+			}
+		}
 	}
 
 	if len(schema.Attributes) > 0 {
@@ -56,7 +77,22 @@ func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.
 				return candidates
 			}
 
-			candidates.List = append(candidates.List, attributeSchemaToCandidate(ctx, name, attr, editRng))
+			candidate := attributeSchemaToCandidate(ctx, name, attr, editRng)
+			requiredBy, conflictsWith := attributeRelations(body, schema, name)
+			if len(requiredBy) > 0 {
+				candidate.Detail += fmt.Sprintf(", required by %s", strings.Join(requiredBy, ", "))
+				candidate.SortText = "0" + name
+				isRanked = true
+			} else if len(conflictsWith) > 0 {
+				candidate.Detail += fmt.Sprintf(", conflicts with %s", strings.Join(conflictsWith, ", "))
+				candidate.SortText = "2" + name
+				isRanked = true
+			} else if attr.IsRequired {
+				candidate.SortText = "0" + name
+				isRanked = true
+			}
+
+			candidates.List = append(candidates.List, candidate)
 			count++
 		}
 	} else if attr := schema.AnyAttribute; attr != nil && len(prefix) == 0 {
@@ -104,9 +140,28 @@ func (d *PathDecoder) bodySchemaCandidates(ctx context.Context, body *hclsyntax.
 
 	sort.Sort(candidates)
 
+	if isRanked {
+		sortCandidatesByRank(candidates.List)
+	}
+
 	return candidates
 }
 
+// sortCandidatesByRank sorts candidates such that required attributes
+// and attributes required by declared attributes come first
+// and attributes conflicting with declared attributes come last,
+// while preserving the alphabetical order otherwise.
+func sortCandidatesByRank(candidates []lang.Candidate) {
+	for i, c := range candidates {
+		if c.SortText == "" {
+			candidates[i].SortText = "1" + c.Label
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].SortText < candidates[j].SortText
+	})
+}
+
 func sortedAttributeNames(attrs map[string]*schema.AttributeSchema) []string {
 	names := make([]string, len(attrs))
 	i := 0
@@ -150,7 +205,7 @@ func isBlockDeclarable(body *hclsyntax.Body, blockType string, bSchema *schema.B
 
 	itemCount := uint64(0)
 	for _, block := range body.Blocks {
-		if block.Type == blockType {
+		if block.Type == blockType || isBlockAlias(bSchema, block.Type) {
 			itemCount++
 			if itemCount >= bSchema.MaxItems {
 				return false
@@ -159,3 +214,12 @@ func isBlockDeclarable(body *hclsyntax.Body, blockType string, bSchema *schema.B
 	}
 	return true
 }
+
+func isBlockAlias(bSchema *schema.BlockSchema, blockType string) bool {
+	for _, alias := range bSchema.Aliases {
+		if alias == blockType {
+			return true
+		}
+	}
+	return false
+}
@@ -223,7 +223,8 @@ func TestDecoder_CandidateAtPos_incompleteBlocks(t *testing.T) {
 					NewText: "block2",
 					Snippet: "block2 {\n  ${1}\n}",
 				},
-				Kind: lang.BlockCandidateKind,
+				Kind:             lang.BlockCandidateKind,
+				CommitCharacters: []string{" "},
 			},
 		},
 		IsComplete: false,
@@ -299,3 +300,126 @@ func TestDecoder_CandidateAtPos_duplicateNames(t *testing.T) {
 		t.Fatalf("unexpected candidates: %s", diff)
 	}
 }
+
+func TestDecoder_CandidateAtPos_defaultSnippet(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"ami": {
+				IsOptional: true,
+				Constraint: schema.LiteralType{
+					Type:    cty.String,
+					Snippet: `"ami-${1:00000000}"`,
+				},
+			},
+			"name": {
+				IsOptional: true,
+				Constraint: schema.LiteralType{Type: cty.String},
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("\n"), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.InitialPos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "ami",
+			Detail: "optional, string",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.InitialPos,
+					End:      hcl.InitialPos,
+				},
+				NewText: "ami",
+				Snippet: `ami = "ami-${1:00000000}"`,
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+		{
+			Label:  "name",
+			Detail: "optional, string",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.InitialPos,
+					End:      hcl.InitialPos,
+				},
+				NewText: "name",
+				Snippet: `name = "${1:value}"`,
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CandidateAtPos_defaultSnippetNested(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"instance": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"settings": {
+							IsOptional: true,
+							Constraint: schema.Object{
+								Attributes: schema.ObjectAttributes{
+									"image": {
+										IsRequired: true,
+										Constraint: schema.LiteralType{
+											Type:    cty.String,
+											Snippet: `"ami-${1:00000000}"`,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte(`instance {
+
+}
+`)
+	f, _ := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.PrefillRequiredFields = true
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 2, Column: 1, Byte: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var settings lang.Candidate
+	for _, c := range candidates.List {
+		if c.Label == "settings" {
+			settings = c
+		}
+	}
+	expectedSnippet := "settings = {\n  image = \"ami-${1:00000000}\"\n}"
+	if settings.TextEdit.Snippet != expectedSnippet {
+		t.Fatalf("unexpected snippet: %q, expected %q", settings.TextEdit.Snippet, expectedSnippet)
+	}
+}
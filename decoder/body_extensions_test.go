@@ -346,7 +346,9 @@ func TestCompletionAtPos_BodySchema_Extensions_Count(t *testing.T) {
 						NewText: "count.index",
 						Snippet: "count.index",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0count.index",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -518,7 +520,9 @@ func TestCompletionAtPos_BodySchema_Extensions_Count(t *testing.T) {
 						NewText: "count.index",
 						Snippet: "count.index",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0count.index",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -599,7 +603,72 @@ variable "test" {
 						NewText: "var.test",
 						Snippet: "var.test",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.test",
+					CommitCharacters: []string{"."},
+				},
+			}),
+		},
+		{
+			"explicitly declared count attribute takes precedence",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{
+								Name: "type",
+							},
+							{
+								Name: "name",
+							},
+						},
+						Body: &schema.BodySchema{
+							Extensions: &schema.BodyExtensions{
+								Count: true,
+							},
+							Attributes: map[string]*schema.AttributeSchema{
+								"count": {
+									IsOptional:  true,
+									Constraint:  schema.LiteralType{Type: cty.String},
+									Description: lang.PlainText("Explicit count"),
+								},
+							},
+						},
+					},
+				},
+			},
+			reference.Targets{},
+			`resource "aws_instance" "foo" {
+
+}`,
+			hcl.Pos{
+				Line:   2,
+				Column: 1,
+				Byte:   32,
+			},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       "count",
+					Description: lang.PlainText("Explicit count"),
+					Detail:      "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start: hcl.Pos{
+								Line:   2,
+								Column: 1,
+								Byte:   32,
+							},
+							End: hcl.Pos{
+								Line:   2,
+								Column: 1,
+								Byte:   32,
+							},
+						},
+						NewText: "count",
+						Snippet: `count = "${1:value}"`,
+					},
+					Kind: lang.AttributeCandidateKind,
 				},
 			}),
 		},
@@ -777,9 +846,11 @@ thing =
 			hcl.Pos{Line: 6, Column: 9, Byte: 102},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "each.key",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "each.key",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0each.key",
+					CommitCharacters: []string{"."},
 					Description: lang.MarkupContent{
 						Value: "The map key (or set member) corresponding to this instance",
 						Kind:  lang.MarkdownKind,
@@ -795,9 +866,11 @@ thing =
 					},
 				},
 				{
-					Label:  "each.value",
-					Detail: "dynamic",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "each.value",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1each.value",
+					CommitCharacters: []string{"."},
 					Description: lang.MarkupContent{
 						Value: "The map value corresponding to this instance. (If a set was provided, this is the same as `each.key`.)",
 						Kind:  lang.MarkdownKind,
@@ -1007,11 +1080,13 @@ foo {
 			hcl.Pos{Line: 7, Column: 13, Byte: 109},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "each.key",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "each.value",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0each.value",
+					CommitCharacters: []string{"."},
 					Description: lang.MarkupContent{
-						Value: "The map key (or set member) corresponding to this instance",
+						Value: "The map value corresponding to this instance. (If a set was provided, this is the same as `each.key`.)",
 						Kind:  lang.MarkdownKind,
 					},
 					TextEdit: lang.TextEdit{
@@ -1020,16 +1095,18 @@ foo {
 							Start:    hcl.Pos{Line: 7, Column: 13, Byte: 109},
 							End:      hcl.Pos{Line: 7, Column: 13, Byte: 109},
 						},
-						NewText: "each.key",
-						Snippet: "each.key",
+						NewText: "each.value",
+						Snippet: "each.value",
 					},
 				},
 				{
-					Label:  "each.value",
-					Detail: "dynamic",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "each.key",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2each.key",
+					CommitCharacters: []string{"."},
 					Description: lang.MarkupContent{
-						Value: "The map value corresponding to this instance. (If a set was provided, this is the same as `each.key`.)",
+						Value: "The map key (or set member) corresponding to this instance",
 						Kind:  lang.MarkdownKind,
 					},
 					TextEdit: lang.TextEdit{
@@ -1038,8 +1115,8 @@ foo {
 							Start:    hcl.Pos{Line: 7, Column: 13, Byte: 109},
 							End:      hcl.Pos{Line: 7, Column: 13, Byte: 109},
 						},
-						NewText: "each.value",
-						Snippet: "each.value",
+						NewText: "each.key",
+						Snippet: "each.key",
 					},
 				},
 			}),
@@ -1144,6 +1221,644 @@ for_each =
 	}
 }
 
+func TestCompletionAtPos_BodySchema_Extensions_Provider(t *testing.T) {
+	ctx := context.Background()
+
+	resourceSchema := func(enabled bool) *schema.BodySchema {
+		return &schema.BodySchema{
+			Blocks: map[string]*schema.BlockSchema{
+				"resource": {
+					Labels: []*schema.LabelSchema{
+						{Name: "type"}, {Name: "name"},
+					},
+					Body: &schema.BodySchema{
+						Extensions: &schema.BodyExtensions{
+							Provider: enabled,
+						},
+					},
+				},
+			},
+		}
+	}
+	providerTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws"},
+				lang.AttrStep{Name: "west"},
+			},
+			ScopeId: schema.ProviderScopeId,
+			RangePtr: &hcl.Range{
+				Filename: "providers.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 3, Column: 2, Byte: 38},
+			},
+			DefRangePtr: &hcl.Range{
+				Filename: "providers.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "region"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+			RangePtr: &hcl.Range{
+				Filename: "variables.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 21, Byte: 20},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		bodySchema         *schema.BodySchema
+		referenceTargets   reference.Targets
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"provider attribute completion",
+			resourceSchema(true),
+			reference.Targets{},
+			`resource "aws_instance" "foo" {
+
+}`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "provider",
+					Description: lang.MarkupContent{
+						Value: "Reference to a `provider` configuration block, in the form `<PROVIDER NAME>.<ALIAS>`, overriding the default provider selection.",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "optional, reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 32},
+							End:      hcl.Pos{Line: 2, Column: 1, Byte: 32},
+						},
+						NewText: "provider",
+						Snippet: "provider = ",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"provider attribute completion when extension not enabled",
+			resourceSchema(false),
+			reference.Targets{},
+			`resource "aws_instance" "foo" {
+
+}`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"provider attribute completion when already declared",
+			resourceSchema(true),
+			reference.Targets{},
+			`resource "aws_instance" "foo" {
+  provider = aws.west
+
+}`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 54},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"provider alias value completion",
+			resourceSchema(true),
+			providerTargets,
+			`resource "aws_instance" "foo" {
+  provider = 
+}`,
+			hcl.Pos{Line: 2, Column: 14, Byte: 45},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "aws.west",
+					Detail:           "reference",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2aws.west",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 14, Byte: 45},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 45},
+						},
+						NewText: "aws.west",
+						Snippet: "aws.west",
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: tc.bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: tc.referenceTargets,
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_BodySchema_Extensions_DependsOn(t *testing.T) {
+	ctx := context.Background()
+
+	resourceSchema := func(enabled bool) *schema.BodySchema {
+		return &schema.BodySchema{
+			Blocks: map[string]*schema.BlockSchema{
+				"resource": {
+					Labels: []*schema.LabelSchema{
+						{Name: "type"}, {Name: "name"},
+					},
+					Body: &schema.BodySchema{
+						Extensions: &schema.BodyExtensions{
+							DependsOn: enabled,
+						},
+					},
+				},
+			},
+		}
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "bar"},
+			},
+			ScopeId: schema.ResourceScopeId,
+			RangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 2, Column: 2, Byte: 33},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "module"},
+				lang.AttrStep{Name: "baz"},
+			},
+			ScopeId: schema.ModuleScopeId,
+			RangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 4, Column: 1, Byte: 35},
+				End:      hcl.Pos{Line: 5, Column: 2, Byte: 52},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "region"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+			RangePtr: &hcl.Range{
+				Filename: "variables.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 21, Byte: 20},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		bodySchema         *schema.BodySchema
+		referenceTargets   reference.Targets
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"depends_on attribute completion",
+			resourceSchema(true),
+			reference.Targets{},
+			`resource "aws_instance" "foo" {
+
+}`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "depends_on",
+					Description: lang.MarkupContent{
+						Value: "Set of references to hidden dependencies, i.e. resources or modules this block depends on, which cannot be inferred from its configuration.",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "optional, set of reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 32},
+							End:      hcl.Pos{Line: 2, Column: 1, Byte: 32},
+						},
+						NewText: "depends_on",
+						Snippet: "depends_on = [ ${1} ]",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"depends_on attribute completion when extension not enabled",
+			resourceSchema(false),
+			reference.Targets{},
+			`resource "aws_instance" "foo" {
+
+}`,
+			hcl.Pos{Line: 2, Column: 1, Byte: 32},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"depends_on element completion",
+			resourceSchema(true),
+			targets,
+			`resource "aws_instance" "foo" {
+  depends_on = []
+}`,
+			hcl.Pos{Line: 2, Column: 17, Byte: 48},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_instance.bar",
+					Detail: "reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 17, Byte: 48},
+							End:      hcl.Pos{Line: 2, Column: 17, Byte: 48},
+						},
+						NewText: "aws_instance.bar",
+						Snippet: "aws_instance.bar",
+					},
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2aws_instance.bar",
+					CommitCharacters: []string{"."},
+				},
+				{
+					Label:  "module.baz",
+					Detail: "reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 17, Byte: 48},
+							End:      hcl.Pos{Line: 2, Column: 17, Byte: 48},
+						},
+						NewText: "module.baz",
+						Snippet: "module.baz",
+					},
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2module.baz",
+					CommitCharacters: []string{"."},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: tc.bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: tc.referenceTargets,
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_BodySchema_Extensions_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	resourceSchema := func(enabled bool) *schema.BodySchema {
+		return &schema.BodySchema{
+			Blocks: map[string]*schema.BlockSchema{
+				"resource": {
+					Labels: []*schema.LabelSchema{
+						{Name: "type"}, {Name: "name"},
+					},
+					Body: &schema.BodySchema{
+						Extensions: &schema.BodyExtensions{
+							Lifecycle: enabled,
+						},
+						Attributes: map[string]*schema.AttributeSchema{
+							"ami": {
+								IsOptional: true,
+								Constraint: schema.LiteralType{Type: cty.String},
+							},
+							"tags": {
+								IsOptional: true,
+								Constraint: schema.Map{Elem: schema.LiteralType{Type: cty.String}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		testName           string
+		bodySchema         *schema.BodySchema
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"lifecycle block completion",
+			resourceSchema(true),
+			`resource "aws_instance" "foo" {
+  lif
+}`,
+			hcl.Pos{Line: 2, Column: 6, Byte: 37},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "lifecycle",
+					Description: lang.MarkupContent{
+						Value: "Lifecycle customizations to change default behaviour of the enclosing block",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "Block, max: 1",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 37},
+						},
+						NewText: "lifecycle",
+						Snippet: "lifecycle {\n  ${1}\n}",
+					},
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+				},
+			}),
+		},
+		{
+			"lifecycle block completion when extension not enabled",
+			resourceSchema(false),
+			`resource "aws_instance" "foo" {
+  lif
+}`,
+			hcl.Pos{Line: 2, Column: 6, Byte: 37},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+		{
+			"lifecycle nested attribute completion",
+			resourceSchema(true),
+			`resource "aws_instance" "foo" {
+  lifecycle {
+
+  }
+}`,
+			hcl.Pos{Line: 3, Column: 1, Byte: 46},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "create_before_destroy",
+					Description: lang.MarkupContent{
+						Value: "Whether the replacement object should be created before the existing object is destroyed, instead of after.",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "optional, bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 46},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 46},
+						},
+						NewText: "create_before_destroy",
+						Snippet: "create_before_destroy = ${1:false}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+				{
+					Label: "ignore_changes",
+					Description: lang.MarkupContent{
+						Value: "Set of attributes of the enclosing block whose changes should be ignored when planning updates, or `all` to ignore all of them.",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "optional, keyword or set of attribute reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 46},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 46},
+						},
+						NewText: "ignore_changes",
+						Snippet: "ignore_changes = ",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+				{
+					Label: "prevent_destroy",
+					Description: lang.MarkupContent{
+						Value: "Whether any plan which would destroy the object should be rejected with an error.",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "optional, bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 46},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 46},
+						},
+						NewText: "prevent_destroy",
+						Snippet: "prevent_destroy = ${1:false}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+				{
+					Label: "replace_triggered_by",
+					Description: lang.MarkupContent{
+						Value: "List of references to resources whose changes should trigger replacement of the enclosing block.",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "optional, list of reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 46},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 46},
+						},
+						NewText: "replace_triggered_by",
+						Snippet: "replace_triggered_by = [ ${1} ]",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"ignore_changes element completion",
+			resourceSchema(true),
+			`resource "aws_instance" "foo" {
+  lifecycle {
+    ignore_changes = []
+  }
+}`,
+			hcl.Pos{Line: 3, Column: 23, Byte: 68},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "ami",
+					Detail: "attribute reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 23, Byte: 68},
+							End:      hcl.Pos{Line: 3, Column: 23, Byte: 68},
+						},
+						NewText: "ami",
+						Snippet: "ami",
+					},
+					Kind:             lang.ReferenceCandidateKind,
+					CommitCharacters: []string{"."},
+				},
+				{
+					Label:  "tags",
+					Detail: "attribute reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 23, Byte: 68},
+							End:      hcl.Pos{Line: 3, Column: 23, Byte: 68},
+						},
+						NewText: "tags",
+						Snippet: "tags",
+					},
+					Kind:             lang.ReferenceCandidateKind,
+					CommitCharacters: []string{"."},
+				},
+			}),
+		},
+		{
+			"ignore_changes nested path completion",
+			resourceSchema(true),
+			`resource "aws_instance" "foo" {
+  lifecycle {
+    ignore_changes = [ta["Name"]]
+  }
+}`,
+			hcl.Pos{Line: 3, Column: 25, Byte: 70},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "tags",
+					Detail: "attribute reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 23, Byte: 68},
+							End:      hcl.Pos{Line: 3, Column: 25, Byte: 70},
+						},
+						NewText: "tags",
+						Snippet: "tags",
+					},
+					Kind:             lang.ReferenceCandidateKind,
+					CommitCharacters: []string{"."},
+				},
+			}),
+		},
+		{
+			"ignore_changes value completion",
+			resourceSchema(true),
+			`resource "aws_instance" "foo" {
+  lifecycle {
+    ignore_changes = 
+  }
+}`,
+			hcl.Pos{Line: 3, Column: 22, Byte: 67},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "all",
+					Description: lang.MarkupContent{
+						Value: "Ignore changes to all attributes of the enclosing block",
+						Kind:  lang.MarkdownKind,
+					},
+					Detail: "keyword",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 22, Byte: 67},
+							End:      hcl.Pos{Line: 3, Column: 22, Byte: 67},
+						},
+						NewText: "all",
+						Snippet: "all",
+					},
+					Kind: lang.KeywordCandidateKind,
+				},
+				{
+					Label:  "[ attribute reference ]",
+					Detail: "set of attribute reference",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 22, Byte: 67},
+							End:      hcl.Pos{Line: 3, Column: 22, Byte: 67},
+						},
+						NewText: "[ ]",
+						Snippet: "[ ${1} ]",
+					},
+					Kind:           lang.SetCandidateKind,
+					TriggerSuggest: true,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: tc.bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 	ctx := context.Background()
 
@@ -1307,7 +2022,9 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self",
 						Snippet: "self",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2self",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -1525,7 +2242,9 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self.cpu_count",
 						Snippet: "self.cpu_count",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0self.cpu_count",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -1623,7 +2342,9 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self",
 						Snippet: "self",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2self",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -1694,7 +2415,9 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self.static",
 						Snippet: "self.static",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0self.static",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -1765,7 +2488,9 @@ func TestCompletionAtPos_BodySchema_Extensions_SelfRef(t *testing.T) {
 						NewText: "self.static",
 						Snippet: "self.static",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0self.static",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -1958,9 +2683,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -1972,9 +2698,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 					},
 				},
 				{
-					Label:  "foo",
-					Detail: "Block",
-					Kind:   lang.BlockCandidateKind,
+					Label:            "foo",
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2051,40 +2778,43 @@ resource "aws_elastic_beanstalk_environment" "example" {
 			hcl.Pos{Line: 4, Column: 5, Byte: 73},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "content",
+					Label: "for_each",
 					Description: lang.MarkupContent{
-						Value: "The body of each generated block",
-						Kind:  lang.PlainTextKind,
+						Value: "A meta-argument that accepts a list, map or a set of strings, and creates an instance for each item in that list, map or set.",
+						Kind:  lang.MarkdownKind,
 					},
-					Detail: "Block, min: 1, max: 1",
-					Kind:   lang.BlockCandidateKind,
+					Detail:         "required, map of any single type or list of any single type or set of string",
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+					SortText:       "0for_each",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 73},
 							End:      hcl.Pos{Line: 4, Column: 5, Byte: 73},
 						},
-						NewText: "content",
-						Snippet: "content {\n  ${1}\n}",
+						NewText: "for_each",
+						Snippet: "for_each = ",
 					},
 				},
 				{
-					Label: "for_each",
+					Label: "content",
 					Description: lang.MarkupContent{
-						Value: "A meta-argument that accepts a list, map or a set of strings, and creates an instance for each item in that list, map or set.",
-						Kind:  lang.MarkdownKind,
+						Value: "The body of each generated block",
+						Kind:  lang.PlainTextKind,
 					},
-					Detail:         "required, map of any single type or list of any single type or set of string",
-					Kind:           lang.AttributeCandidateKind,
-					TriggerSuggest: true,
+					Detail:           "Block, min: 1, max: 1",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					SortText:         "1content",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 73},
 							End:      hcl.Pos{Line: 4, Column: 5, Byte: 73},
 						},
-						NewText: "for_each",
-						Snippet: "for_each = ",
+						NewText: "content",
+						Snippet: "content {\n  ${1}\n}",
 					},
 				},
 				{
@@ -2093,8 +2823,9 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "The name of a temporary variable that represents the current element of the complex value. Defaults to the label of the dynamic block.",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail: "optional, string",
-					Kind:   lang.AttributeCandidateKind,
+					Detail:   "optional, string",
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1iterator",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2111,8 +2842,9 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A list of strings that specifies the block labels, in order, to use for each generated block.",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail: "optional, list of string",
-					Kind:   lang.AttributeCandidateKind,
+					Detail:   "optional, list of string",
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1labels",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2370,9 +3102,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 			hcl.Pos{Line: 5, Column: 7, Byte: 86},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "bar",
-					Detail: "Block",
-					Kind:   lang.BlockCandidateKind,
+					Label:            "bar",
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2389,9 +3122,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2554,9 +3288,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2568,9 +3303,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 					},
 				},
 				{
-					Label:  "foo",
-					Detail: "Block",
-					Kind:   lang.BlockCandidateKind,
+					Label:            "foo",
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2637,9 +3373,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 			hcl.Pos{Line: 4, Column: 5, Byte: 63},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "bar",
-					Detail: "Block",
-					Kind:   lang.BlockCandidateKind,
+					Label:            "bar",
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2656,9 +3393,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2795,9 +3533,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 			hcl.Pos{Line: 4, Column: 7, Byte: 60},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "baz",
-					Detail: "Block",
-					Kind:   lang.BlockCandidateKind,
+					Label:            "baz",
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -2814,9 +3553,10 @@ resource "aws_elastic_beanstalk_environment" "example" {
 						Value: "A dynamic block to produce blocks dynamically by iterating over a given complex value",
 						Kind:  lang.MarkdownKind,
 					},
-					Detail:         "Block",
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Detail:           "Block",
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -4,21 +4,68 @@
 package decoder
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
 
+	"github.com/hashicorp/hcl-lang/decoder/internal/ast"
 	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+// CompletionAtPosInPath returns completion candidates for a given position
+// in a file within the given path.
+//
+// Unlike PathDecoder.CompletionAtPos, the path reader is made available
+// via context, which allows resolution of references into other paths.
+func (d *Decoder) CompletionAtPosInPath(ctx context.Context, path lang.Path, filename string, pos hcl.Pos) (lang.Candidates, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return lang.ZeroCandidates(), err
+	}
+
+	return pd.CompletionAtPos(d.pathContext(ctx, pd), filename, pos)
+}
+
 // CompletionAtPos returns completion candidates for a given position in a file
 //
 // Schema is required in order to return any candidates and method will return
 // error if there isn't one.
 func (d *PathDecoder) CompletionAtPos(ctx context.Context, filename string, pos hcl.Pos) (lang.Candidates, error) {
+	ctx, cc := withCompletionContext(ctx)
+
+	candidates, err := d.candidatesAtPos(ctx, filename, pos)
+	if err != nil {
+		return candidates, err
+	}
+
+	if d.decoderCtx.DisableSnippets {
+		candidates = candidatesWithoutSnippets(candidates)
+	}
+	candidates = candidatesWithCommitCharacters(candidates)
+
+	if d.pathCtx.CandidateHook != nil {
+		ctx = WithPath(ctx, d.path)
+		ctx = WithFilename(ctx, filename)
+		ctx = WithPos(ctx, pos)
+		if cc.schemaPath != nil {
+			ctx = schemacontext.WithSchemaPath(ctx, *cc.schemaPath)
+		}
+		candidates.List = d.pathCtx.CandidateHook(ctx, candidates.List)
+	}
+
+	return candidates, nil
+}
+
+func (d *PathDecoder) candidatesAtPos(ctx context.Context, filename string, pos hcl.Pos) (lang.Candidates, error) {
 	f, err := d.fileByName(filename)
 	if err != nil {
 		return lang.ZeroCandidates(), err
@@ -26,6 +73,11 @@ func (d *PathDecoder) CompletionAtPos(ctx context.Context, filename string, pos
 
 	rootBody, err := d.bodyForFileAndPos(filename, f, pos)
 	if err != nil {
+		var unknownFormatErr *UnknownFileFormatError
+		if errors.As(err, &unknownFormatErr) && d.pathCtx.Schema != nil {
+			// JSON body can only be decoded with schema
+			return d.completionAtPosJSON(ctx, filename, f, d.pathCtx.Schema, pos), nil
+		}
 		return lang.ZeroCandidates(), err
 	}
 
@@ -36,17 +88,54 @@ func (d *PathDecoder) CompletionAtPos(ctx context.Context, filename string, pos
 	outerBodyRng := rootBody.Range()
 	// Find outer block body range to allow filtering
 	// of references pointing back to the same block
-	outerBlock := rootBody.OutermostBlockAtPos(pos)
+	outerBlock := outermostBlockAtPos(rootBody, pos)
 	if outerBlock != nil {
-		ob := outerBlock.Body.(*hclsyntax.Body)
-		outerBodyRng = ob.Range()
+		outerBodyRng = outerBlock.Body.Range()
 	}
 
 	ctx = schema.WithPrefillRequiredFields(ctx, d.PrefillRequiredFields)
+	ctx = withMaxReferenceStepDepth(ctx, d.decoderCtx.MaxReferenceStepDepth)
+	ctx = schema.WithFunctionSignatures(ctx, d.functionSignatures())
 
 	return d.completionAtPos(ctx, rootBody, outerBodyRng, d.pathCtx.Schema, pos)
 }
 
+// candidatesWithoutSnippets returns candidates with snippets removed
+// from all text edits, leaving only the plain NewText.
+func candidatesWithoutSnippets(candidates lang.Candidates) lang.Candidates {
+	for i, candidate := range candidates.List {
+		candidates.List[i].TextEdit.Snippet = ""
+		for j := range candidate.AdditionalTextEdits {
+			candidates.List[i].AdditionalTextEdits[j].Snippet = ""
+		}
+	}
+	return candidates
+}
+
+// candidatesWithCommitCharacters populates default commit characters
+// of candidates which do not declare any, based on their kind.
+func candidatesWithCommitCharacters(candidates lang.Candidates) lang.Candidates {
+	for i, candidate := range candidates.List {
+		if candidate.CommitCharacters != nil {
+			continue
+		}
+		candidates.List[i].CommitCharacters = defaultCommitCharacters(candidate.Kind)
+	}
+	return candidates
+}
+
+// defaultCommitCharacters returns commit characters
+// for a candidate of the given kind, or nil if there are none
+func defaultCommitCharacters(kind lang.CandidateKind) []string {
+	switch kind {
+	case lang.ReferenceCandidateKind:
+		return []string{"."}
+	case lang.BlockCandidateKind:
+		return []string{" "}
+	}
+	return nil
+}
+
 func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body, outerBodyRng hcl.Range, bodySchema *schema.BodySchema, pos hcl.Pos) (lang.Candidates, error) {
 	if bodySchema == nil {
 		return lang.ZeroCandidates(), nil
@@ -54,23 +143,17 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 
 	filename := body.Range().Filename
 
+	schemaPath, _ := schemacontext.FromContext(ctx)
+
 	for _, attr := range body.Attributes {
 		if d.isPosInsideAttrExpr(attr, pos) {
+			ctx = schemacontext.WithSchemaPath(ctx, schemaPath.WithAttributeName(attr.Name))
 			if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
 				ctx = schema.WithActiveSelfRefs(ctx)
 			}
-			if bodySchema.Extensions != nil && bodySchema.Extensions.Count && attr.Name == "count" {
-				return d.attrValueCompletionAtPos(ctx, attr, schemahelper.CountAttributeSchema(), outerBodyRng, pos)
-			}
-			if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && attr.Name == "for_each" {
-				return d.attrValueCompletionAtPos(ctx, attr, schemahelper.ForEachAttributeSchema(), outerBodyRng, pos)
-			}
-			if aSchema, ok := bodySchema.Attributes[attr.Name]; ok {
+			if aSchema, ok := schemahelper.AttributeSchema(bodySchema, attr.Name); ok {
 				return d.attrValueCompletionAtPos(ctx, attr, aSchema, outerBodyRng, pos)
 			}
-			if bodySchema.AnyAttribute != nil {
-				return d.attrValueCompletionAtPos(ctx, attr, bodySchema.AnyAttribute, outerBodyRng, pos)
-			}
 
 			return lang.ZeroCandidates(), nil
 		}
@@ -91,8 +174,8 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 	}
 
 	for _, block := range body.Blocks {
-		if block.Range().ContainsPos(pos) {
-			blockSchema, ok := bodySchema.Blocks[block.Type]
+		if blockContainsPos(block, pos) {
+			blockSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 			if !ok {
 				return lang.ZeroCandidates(), &PositionalError{
 					Filename: filename,
@@ -107,8 +190,8 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 				return d.bodySchemaCandidates(ctx, body, bodySchema, prefixRng, block.Range()), nil
 			}
 
-			for i, labelRange := range block.LabelRanges {
-				if labelRange.ContainsPos(pos) {
+			if i, insideLabel, ok := labelIndexAtPos(block, pos); ok {
+				if insideLabel {
 					if i+1 > len(blockSchema.Labels) {
 						return lang.ZeroCandidates(), &PositionalError{
 							Filename: filename,
@@ -118,19 +201,26 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 					}
 
 					prefixRng := rng
-					tokenRange, err := d.labelTokenRangeAtPos(labelRange.Filename, pos)
+					tokenRange, err := d.labelTokenRangeAtPos(filename, pos)
 					if err == nil {
 						rng, prefixRng = tokenRange, tokenRange
 					}
 					prefixRng.End = pos
 
-					labelSchema := blockSchema.Labels[i]
+					return d.labelCandidates(i, body, bodySchema, blockSchema, prefixRng, rng, block)
+				}
 
-					if !labelSchema.Completable {
-						return lang.ZeroCandidates(), nil
+				if i < len(block.LabelRanges) {
+					// there is nothing to complete between labels
+					return lang.ZeroCandidates(), nil
+				}
+				if i < len(blockSchema.Labels) {
+					// completing a new label after the last declared one
+					candidates, err := d.labelCandidates(i, body, bodySchema, blockSchema, rng, rng, block)
+					if err != nil {
+						return candidates, err
 					}
-
-					return d.labelCandidatesFromDependentSchema(i, blockSchema.DependentBody, prefixRng, rng, block, blockSchema.Labels)
+					return quotedLabelCandidates(candidates, rng), nil
 				}
 			}
 
@@ -142,19 +232,161 @@ func (d *PathDecoder) completionAtPos(ctx context.Context, body *hclsyntax.Body,
 				}
 			}
 
-			if block.Body != nil && block.Body.Range().ContainsPos(pos) {
+			if block.Body != nil && (block.Body.Range().ContainsPos(pos) || isPosAtUnclosedBlockEnd(block, pos)) {
 				mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+				ctx = schemacontext.WithSchemaPath(ctx, schemaPath.WithBlockType(block.Type))
 				return d.completionAtPos(ctx, block.Body, outerBodyRng, mergedSchema, pos)
 			}
 		}
 	}
 
-	tokenRng, err := d.nameTokenRangeAtPos(body.Range().Filename, pos)
+	prefixRng := rng
+	f, err := d.fileByName(filename)
 	if err == nil {
-		rng = tokenRng
+		_, rng = tokenRangeAtPos(f.Bytes, filename, pos)
+		prefixRng = rng
+		prefixRng.End = pos
+	}
+
+	return d.bodySchemaCandidates(ctx, body, bodySchema, prefixRng, rng), nil
+}
+
+// completionAtPosJSON returns attribute names as candidates for completion
+// of object keys in a JSON body, either at a fresh key position
+// (after '{' or ',') or when editing a partially typed key.
+func (d *PathDecoder) completionAtPosJSON(ctx context.Context, filename string, f *hcl.File, rootSchema *schema.BodySchema, pos hcl.Pos) lang.Candidates {
+	bodySchema, content, ok := jsonBodyAtPos(f.Body, rootSchema, pos)
+	if !ok || bodySchema == nil {
+		return lang.ZeroCandidates()
+	}
+
+	prefix, editRng, ok := jsonKeyRangeAtPos(f.Bytes, filename, pos)
+	if !ok {
+		return lang.ZeroCandidates()
+	}
+
+	candidates := lang.NewCandidates()
+	count := 0
+	for _, name := range sortedAttributeNames(bodySchema.Attributes) {
+		attr := bodySchema.Attributes[name]
+
+		if attr.IsComputed && !attr.IsOptional {
+			continue
+		}
+		if declaredAttr, ok := content.Attributes[name]; ok && !declaredAttr.NameRange.Overlaps(editRng) {
+			continue
+		}
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if uint(count) >= d.maxCandidates {
+			return candidates
+		}
+
+		candidates.List = append(candidates.List, lang.Candidate{
+			Label:        name,
+			Detail:       detailForAttribute(attr),
+			Description:  attr.Description,
+			IsDeprecated: attr.IsDeprecated,
+			Kind:         lang.AttributeCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: fmt.Sprintf("%q: ", name),
+				Snippet: fmt.Sprintf("%q: ${1}", name),
+				Range:   editRng,
+			},
+		})
+		count++
+	}
+
+	candidates.IsComplete = true
+	sort.Sort(candidates)
+
+	return candidates
+}
+
+// jsonBodyAtPos finds the innermost JSON body (and its schema)
+// containing the given position. It returns false if the position
+// is inside of an attribute value.
+func jsonBodyAtPos(body hcl.Body, bodySchema *schema.BodySchema, pos hcl.Pos) (*schema.BodySchema, ast.BodyContent, bool) {
+	content := ast.DecodeBody(body, bodySchema)
+
+	for _, attr := range content.Attributes {
+		if attr.Expr.Range().ContainsPos(pos) {
+			return nil, content, false
+		}
 	}
 
-	return d.bodySchemaCandidates(ctx, body, bodySchema, rng, rng), nil
+	for _, block := range content.Blocks {
+		bSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
+		if !ok {
+			continue
+		}
+		if block.Range.ContainsPos(pos) {
+			mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.Block, bSchema)
+			return jsonBodyAtPos(block.Body, mergedSchema, pos)
+		}
+	}
+
+	return bodySchema, content, true
+}
+
+// jsonKeyRangeAtPos returns prefix of the (partially typed) key
+// at the given position and range which is to be replaced by the key,
+// including any quotes.
+func jsonKeyRangeAtPos(src []byte, filename string, pos hcl.Pos) (string, hcl.Range, bool) {
+	if pos.Byte > len(src) {
+		return "", hcl.Range{}, false
+	}
+
+	start := pos.Byte
+	for start > 0 && isJSONKeyByte(src[start-1]) {
+		start--
+	}
+	prefix := string(src[start:pos.Byte])
+
+	end := pos.Byte
+	if start > 0 && src[start-1] == '"' {
+		// partial key, e.g. "na|" or "na|me"
+		start--
+		for end < len(src) && isJSONKeyByte(src[end]) {
+			end++
+		}
+		if end < len(src) && src[end] == '"' {
+			end++
+		}
+	}
+
+	// key is only expected at the beginning of an object or after a comma
+	before := bytes.TrimRightFunc(src[:start], unicode.IsSpace)
+	if len(before) == 0 {
+		return "", hcl.Range{}, false
+	}
+	if last := before[len(before)-1]; last != '{' && last != ',' {
+		return "", hcl.Range{}, false
+	}
+
+	// key characters are always single-byte, so columns
+	// can be derived from the byte offsets
+	return prefix, hcl.Range{
+		Filename: filename,
+		Start: hcl.Pos{
+			Line:   pos.Line,
+			Column: pos.Column - (pos.Byte - start),
+			Byte:   start,
+		},
+		End: hcl.Pos{
+			Line:   pos.Line,
+			Column: pos.Column + (end - pos.Byte),
+			Byte:   end,
+		},
+	}, true
+}
+
+func isJSONKeyByte(b byte) bool {
+	return b == '_' || b == '-' ||
+		(b >= 'a' && b <= 'z') ||
+		(b >= 'A' && b <= 'Z') ||
+		(b >= '0' && b <= '9')
 }
 
 func (d *PathDecoder) isPosInsideAttrExpr(attr *hclsyntax.Attribute, pos hcl.Pos) bool {
@@ -189,53 +421,6 @@ func (d *PathDecoder) isPosInsideAttrExpr(attr *hclsyntax.Attribute, pos hcl.Pos
 	return false
 }
 
-func (d *PathDecoder) nameTokenRangeAtPos(filename string, pos hcl.Pos) (hcl.Range, error) {
-	rng := hcl.Range{
-		Filename: filename,
-		Start:    pos,
-		End:      pos,
-	}
-
-	f, err := d.fileByName(filename)
-	if err != nil {
-		return rng, err
-	}
-	tokens, diags := hclsyntax.LexConfig(f.Bytes, filename, hcl.InitialPos)
-	if diags.HasErrors() {
-		return rng, diags
-	}
-
-	return nameTokenRangeAtPos(tokens, pos)
-}
-
-func nameTokenRangeAtPos(tokens hclsyntax.Tokens, pos hcl.Pos) (hcl.Range, error) {
-	for i, t := range tokens {
-		if t.Range.ContainsPos(pos) {
-			if t.Type == hclsyntax.TokenIdent {
-				return t.Range, nil
-			}
-			if t.Type == hclsyntax.TokenNewline && i > 0 {
-				// end of line
-				previousToken := tokens[i-1]
-				if previousToken.Type == hclsyntax.TokenIdent {
-					return previousToken.Range, nil
-				}
-			}
-			return hcl.Range{}, fmt.Errorf("token is %s, not Ident", t.Type.String())
-		}
-
-		// EOF token has zero length
-		// so we just compare start/end position
-		if t.Type == hclsyntax.TokenEOF && t.Range.Start == pos && t.Range.End == pos && i > 0 {
-			previousToken := tokens[i-1]
-			if previousToken.Type == hclsyntax.TokenIdent {
-				return previousToken.Range, nil
-			}
-		}
-	}
-	return hcl.Range{}, fmt.Errorf("no token found at %s", stringPos(pos))
-}
-
 func (d *PathDecoder) labelTokenRangeAtPos(filename string, pos hcl.Pos) (hcl.Range, error) {
 	rng := hcl.Range{
 		Filename: filename,
@@ -277,6 +462,31 @@ func labelTokenRangeAtPos(tokens hclsyntax.Tokens, pos hcl.Pos) (hcl.Range, erro
 	return hcl.Range{}, fmt.Errorf("no valid token found at %s", stringPos(pos))
 }
 
+// blockContainsPos reports whether the given block contains pos,
+// including the end of a block which is missing its closing brace,
+// such as when the cursor is at the end of a file.
+func blockContainsPos(block *hclsyntax.Block, pos hcl.Pos) bool {
+	return block.Range().ContainsPos(pos) || isPosAtUnclosedBlockEnd(block, pos)
+}
+
+// isPosAtUnclosedBlockEnd reports whether pos is at the very end
+// of a block which the parser recovered without a closing brace.
+func isPosAtUnclosedBlockEnd(block *hclsyntax.Block, pos hcl.Pos) bool {
+	return block.CloseBraceRange.Start.Byte == block.CloseBraceRange.End.Byte &&
+		block.Range().End.Byte == pos.Byte
+}
+
+// outermostBlockAtPos returns the outermost block of the body
+// which contains pos (see blockContainsPos), or nil if there is none.
+func outermostBlockAtPos(body *hclsyntax.Body, pos hcl.Pos) *hclsyntax.Block {
+	for _, block := range body.Blocks {
+		if blockContainsPos(block, pos) {
+			return block
+		}
+	}
+	return nil
+}
+
 func isPosOutsideBody(block *hclsyntax.Block, pos hcl.Pos) bool {
 	if block.OpenBraceRange.ContainsPos(pos) {
 		return true
@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/json"
@@ -83,6 +84,159 @@ func TestDecoder_CompletionAtPos_json(t *testing.T) {
 	}
 }
 
+func TestDecoder_CompletionAtPos_jsonWithSchema(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				Constraint:  schema.LiteralType{Type: cty.String},
+				IsOptional:  true,
+				Description: lang.PlainText("name of the thing"),
+			},
+			"number": {
+				Constraint: schema.LiteralType{Type: cty.Number},
+				IsRequired: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"fresh key in empty object",
+			"{\n  \n}\n",
+			hcl.Pos{Line: 2, Column: 3, Byte: 4},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       "name",
+					Detail:      "optional, string",
+					Description: lang.PlainText("name of the thing"),
+					Kind:        lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `"name": `,
+						Snippet: `"name": ${1}`,
+						Range: hcl.Range{
+							Filename: "test.tf.json",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+							End:      hcl.Pos{Line: 2, Column: 3, Byte: 4},
+						},
+					},
+				},
+				{
+					Label:  "number",
+					Detail: "required, number",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `"number": `,
+						Snippet: `"number": ${1}`,
+						Range: hcl.Range{
+							Filename: "test.tf.json",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+							End:      hcl.Pos{Line: 2, Column: 3, Byte: 4},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"partial key next to declared attribute",
+			"{\n  \"n\": 42,\n  \"name\": \"foo\"\n}\n",
+			hcl.Pos{Line: 2, Column: 5, Byte: 6},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "number",
+					Detail: "required, number",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `"number": `,
+						Snippet: `"number": ${1}`,
+						Range: hcl.Range{
+							Filename: "test.tf.json",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 7},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"partial key",
+			"{\n  \"na\n}\n",
+			hcl.Pos{Line: 2, Column: 6, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       "name",
+					Detail:      "optional, string",
+					Description: lang.PlainText("name of the thing"),
+					Kind:        lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `"name": `,
+						Snippet: `"name": ${1}`,
+						Range: hcl.Range{
+							Filename: "test.tf.json",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 7},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"existing partial key with closing quote",
+			"{\n  \"nu\": 42\n}\n",
+			hcl.Pos{Line: 2, Column: 6, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "number",
+					Detail: "required, number",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: `"number": `,
+						Snippet: `"number": ${1}`,
+						Range: hcl.Range{
+							Filename: "test.tf.json",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+							End:      hcl.Pos{Line: 2, Column: 7, Byte: 8},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"attribute value",
+			"{\n  \"number\": 42\n}\n",
+			hcl.Pos{Line: 2, Column: 14, Byte: 15},
+			lang.ZeroCandidates(),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := json.Parse([]byte(tc.cfg), "test.tf.json")
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf.json": f,
+				},
+			})
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf.json", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_CompletionAtPos_unknownBlock(t *testing.T) {
 	ctx := context.Background()
 	resourceLabelSchema := []*schema.LabelSchema{
@@ -356,7 +510,8 @@ func TestDecoder_CompletionAtPos_prefixNearEOF(t *testing.T) {
 				NewText: "resource",
 				Snippet: "resource \"${1:type}\" \"${2:name}\" {\n  ${3}\n}",
 			},
-			Kind: lang.BlockCandidateKind,
+			Kind:             lang.BlockCandidateKind,
+			CommitCharacters: []string{" "},
 		},
 	})
 	if diff := cmp.Diff(expectedCandidates, candidates, ctydebug.CmpOptions); diff != "" {
@@ -593,7 +748,8 @@ func TestDecoder_CompletionAtPos_endOfLabel(t *testing.T) {
 	}
 	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
 		{
-			Label: "myfirst",
+			Label:  "myfirst",
+			Detail: "3 attributes",
 			TextEdit: lang.TextEdit{
 				Range: hcl.Range{
 					Filename: "test.tf",
@@ -614,7 +770,8 @@ func TestDecoder_CompletionAtPos_endOfLabel(t *testing.T) {
 			Kind: lang.LabelCandidateKind,
 		},
 		{
-			Label: "mysecond",
+			Label:  "mysecond",
+			Detail: "2 attributes",
 			TextEdit: lang.TextEdit{
 				Range: hcl.Range{
 					Filename: "test.tf",
@@ -740,7 +897,94 @@ func TestDecoder_CompletionAtPos_zeroByteContent(t *testing.T) {
 				NewText: "resource",
 				Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 			},
-			Kind:           lang.BlockCandidateKind,
+			Kind:             lang.BlockCandidateKind,
+			CommitCharacters: []string{" "},
+			TriggerSuggest:   true,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_attributeNameValueSuggest(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"any":  {Constraint: schema.LiteralType{Type: cty.DynamicPseudoType}, IsOptional: true},
+			"name": {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+			"ref":  {Constraint: schema.Reference{OfScopeId: lang.ScopeId("foo")}, IsOptional: true},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"block": {
+				Body: &schema.BodySchema{},
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig([]byte{}, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.InitialPos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.InitialPos,
+		End:      hcl.InitialPos,
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "any",
+			Detail: "optional, any type",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "any",
+				Snippet: "any = ",
+			},
+			Kind:           lang.AttributeCandidateKind,
+			TriggerSuggest: true,
+		},
+		{
+			Label:  "block",
+			Detail: "Block",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "block",
+				Snippet: "block {\n  ${1}\n}",
+			},
+			Kind:             lang.BlockCandidateKind,
+			CommitCharacters: []string{" "},
+		},
+		{
+			Label:  "name",
+			Detail: "optional, string",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "name",
+				Snippet: `name = "${1:value}"`,
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+		{
+			Label:  "ref",
+			Detail: "optional, reference",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "ref",
+				Snippet: "ref = ",
+			},
+			Kind:           lang.AttributeCandidateKind,
 			TriggerSuggest: true,
 		},
 	})
@@ -804,8 +1048,9 @@ func TestDecoder_CompletionAtPos_endOfFilePos(t *testing.T) {
 					NewText: "resource",
 					Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 				},
-				Kind:           lang.BlockCandidateKind,
-				TriggerSuggest: true,
+				Kind:             lang.BlockCandidateKind,
+				CommitCharacters: []string{" "},
+				TriggerSuggest:   true,
 			},
 		},
 		IsComplete: true,
@@ -880,7 +1125,8 @@ func TestDecoder_CompletionAtPos_emptyLabel(t *testing.T) {
 	}
 	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
 		{
-			Label: "azurerm_subnet",
+			Label:  "azurerm_subnet",
+			Detail: "3 attributes",
 			TextEdit: lang.TextEdit{
 				Range: hcl.Range{
 					Filename: "test.tf",
@@ -893,7 +1139,8 @@ func TestDecoder_CompletionAtPos_emptyLabel(t *testing.T) {
 			Kind: lang.LabelCandidateKind,
 		},
 		{
-			Label: "random_resource",
+			Label:  "random_resource",
+			Detail: "2 attributes",
 			TextEdit: lang.TextEdit{
 				Range: hcl.Range{
 					Filename: "test.tf",
@@ -987,7 +1234,8 @@ func TestDecoder_CompletionAtPos_emptyLabel_duplicateDepKeys(t *testing.T) {
 	}
 	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
 		{
-			Label: "azurerm_subnet",
+			Label:  "azurerm_subnet",
+			Detail: "3 attributes",
 			TextEdit: lang.TextEdit{
 				Range: hcl.Range{
 					Filename: "test.tf",
@@ -1106,8 +1354,9 @@ resource "random_resource" "test" {
 						NewText: "resource",
 						Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 					},
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 				},
 			}),
 		},
@@ -1127,8 +1376,9 @@ resource "random_resource" "test" {
 						NewText: "resource",
 						Snippet: "resource \"${1}\" \"${2:name}\" {\n  ${3}\n}",
 					},
-					Kind:           lang.BlockCandidateKind,
-					TriggerSuggest: true,
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+					TriggerSuggest:   true,
 				},
 			}),
 		},
@@ -1137,7 +1387,8 @@ resource "random_resource" "test" {
 			hcl.Pos{Line: 1, Column: 11, Byte: 10},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "azurerm_subnet",
+					Label:  "azurerm_subnet",
+					Detail: "3 attributes",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -1150,7 +1401,8 @@ resource "random_resource" "test" {
 					Kind: lang.LabelCandidateKind,
 				},
 				{
-					Label: "random_resource",
+					Label:  "random_resource",
+					Detail: "2 attributes",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -1163,7 +1415,8 @@ resource "random_resource" "test" {
 					Kind: lang.LabelCandidateKind,
 				},
 				{
-					Label: "sensitive_resource",
+					Label:  "sensitive_resource",
+					Detail: "2 attributes",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -1193,7 +1446,8 @@ resource "random_resource" "test" {
 						NewText: "one",
 						Snippet: `one = "${1:value}"`,
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "0one",
 				},
 				{
 					Label:  "three",
@@ -1207,7 +1461,8 @@ resource "random_resource" "test" {
 						NewText: "three",
 						Snippet: "three = ${1:false}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1three",
 				},
 				{
 					Label:  "two",
@@ -1221,7 +1476,8 @@ resource "random_resource" "test" {
 						NewText: "two",
 						Snippet: "two = ${1:0}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1two",
 				},
 			}),
 		},
@@ -1241,7 +1497,8 @@ resource "random_resource" "test" {
 						NewText: "one",
 						Snippet: `one = "${1:value}"`,
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "0one",
 				},
 				{
 					Label:  "three",
@@ -1255,7 +1512,8 @@ resource "random_resource" "test" {
 						NewText: "three",
 						Snippet: "three = ${1:false}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1three",
 				},
 				{
 					Label:  "two",
@@ -1269,7 +1527,8 @@ resource "random_resource" "test" {
 						NewText: "two",
 						Snippet: "two = ${1:0}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1two",
 				},
 			}),
 		},
@@ -1289,7 +1548,8 @@ resource "random_resource" "test" {
 						NewText: "seven",
 						Snippet: "seven = ${1:0}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "0seven",
 				},
 				{
 					Label:  "six",
@@ -1303,7 +1563,8 @@ resource "random_resource" "test" {
 						NewText: "six",
 						Snippet: "six = ${1:0}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:     lang.AttributeCandidateKind,
+					SortText: "1six",
 				},
 			}),
 		},
@@ -1392,37 +1653,28 @@ func TestDecoder_CompletionAtPos_AnyAttribute(t *testing.T) {
 	}
 }
 
-func TestDecoder_CompletionAtPos_multipleTypes(t *testing.T) {
+func TestDecoder_CompletionAtPos_blockAlias(t *testing.T) {
 	ctx := context.Background()
-	resourceLabelSchema := []*schema.LabelSchema{
-		{Name: "type", IsDepKey: true, Completable: true},
-		{Name: "name"},
-	}
-
-	resourceSchema := &schema.BlockSchema{
-		Labels: resourceLabelSchema,
-		Body: &schema.BodySchema{
-			Attributes: map[string]*schema.AttributeSchema{
-				"for_each": {
-					Constraint: schema.OneOf{
-						schema.LiteralType{Type: cty.Set(cty.DynamicPseudoType)},
-						schema.LiteralType{Type: cty.Map(cty.DynamicPseudoType)},
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Aliases: []string{"legacyblock"},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"num_attr": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
 					},
-					IsOptional: true,
 				},
 			},
 		},
 	}
 
-	bodySchema := &schema.BodySchema{
-		Blocks: map[string]*schema.BlockSchema{
-			"resource": resourceSchema,
-		},
-	}
-
-	cfg := []byte(`resource "azurerm_subnet" "example" {
+	cfg := []byte(`legacyblock {
 
 }
+
 `)
 
 	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
@@ -1437,107 +1689,49 @@ func TestDecoder_CompletionAtPos_multipleTypes(t *testing.T) {
 		},
 	})
 
-	pos := hcl.Pos{Line: 2, Column: 1, Byte: 38}
-	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
-	if err != nil {
-		t.Fatal(err)
-	}
-	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
-		{
-			Label:  "for_each",
-			Detail: "optional, set of any single type or map of any single type",
-			TextEdit: lang.TextEdit{
-				Range: hcl.Range{
-					Filename: "test.tf",
-					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 38},
-					End:      hcl.Pos{Line: 2, Column: 1, Byte: 38},
-				},
-				NewText: "for_each",
-				Snippet: "for_each = [ ${1} ]",
-			},
-			Kind: lang.AttributeCandidateKind,
-		},
-	})
-
-	diff := cmp.Diff(expectedCandidates, candidates, ctydebug.CmpOptions)
-	if diff != "" {
-		t.Fatalf("unexpected schema for %s: %s", stringPos(pos), diff)
-	}
-}
-
-func TestDecoder_CompletionAtPos_incompleteAttrOrBlock(t *testing.T) {
-	ctx := context.Background()
-	resourceLabelSchema := []*schema.LabelSchema{
-		{Name: "type"},
-		{Name: "name"},
-	}
-
-	resourceSchema := &schema.BlockSchema{
-		Labels: resourceLabelSchema,
-		Body: &schema.BodySchema{
-			Attributes: map[string]*schema.AttributeSchema{
-				"count": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
-			},
-		},
-	}
-
-	bodySchema := &schema.BodySchema{
-		Blocks: map[string]*schema.BlockSchema{
-			"resource": resourceSchema,
-		},
-	}
-
 	testCases := []struct {
 		name               string
-		src                string
 		pos                hcl.Pos
 		expectedCandidates lang.Candidates
 	}{
 		{
-			"new root block or attribute",
-			`
-res
-`,
-			hcl.Pos{Line: 2, Column: 4, Byte: 4},
+			"within aliased block",
+			hcl.Pos{Line: 2, Column: 1, Byte: 14},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "resource",
-					Detail: "Block",
+					Label:  "num_attr",
+					Detail: "optional, number",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
-							End:      hcl.Pos{Line: 2, Column: 4, Byte: 4},
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 14},
+							End:      hcl.Pos{Line: 2, Column: 1, Byte: 14},
 						},
-						NewText: "resource",
-						Snippet: "resource \"${1:type}\" \"${2:name}\" {\n  ${3}\n}",
+						NewText: "num_attr",
+						Snippet: "num_attr = ${1:0}",
 					},
-					Kind: lang.BlockCandidateKind,
+					Kind: lang.AttributeCandidateKind,
 				},
 			}),
 		},
 		{
-			"new block or attribute inside a block",
-			`
-resource "any" "ref" {
-  co
-}
-`,
-			hcl.Pos{Line: 3, Column: 5, Byte: 28},
+			"canonical block type only",
+			hcl.Pos{Line: 4, Column: 1, Byte: 17},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "count",
-					Detail: "optional, number",
+					Label:  "myblock",
+					Detail: "Block",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 26},
-							End:      hcl.Pos{Line: 3, Column: 5, Byte: 28},
+							Start:    hcl.Pos{Line: 4, Column: 1, Byte: 17},
+							End:      hcl.Pos{Line: 4, Column: 1, Byte: 17},
 						},
-						NewText: "count",
-						Snippet: "count = ${1:0}",
+						NewText: "myblock",
+						Snippet: "myblock {\n  ${1}\n}",
 					},
-					Kind: lang.AttributeCandidateKind,
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 				},
 			}),
 		},
@@ -1545,14 +1739,622 @@ resource "any" "ref" {
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
-			f, _ := hclsyntax.ParseConfig([]byte(tc.src), "test.tf", hcl.InitialPos)
-
-			d := testPathDecoder(t, &PathContext{
-				Schema: bodySchema,
-				Files: map[string]*hcl.File{
-					"test.tf": f,
-				},
-			})
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_whitespaceLine(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"num_attr": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
+						"str_attr": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+					Blocks: map[string]*schema.BlockSchema{
+						"nested": {
+							Body: &schema.BodySchema{},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := []byte("myblock {\n  num_attr = 1\n  \n\n}\n")
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	testCases := []struct {
+		name               string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"end of indentation on whitespace-only line",
+			hcl.Pos{Line: 3, Column: 3, Byte: 27},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "nested",
+					Detail: "Block",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 27},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 27},
+						},
+						NewText: "nested",
+						Snippet: "nested {\n  ${1}\n}",
+					},
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+				},
+				{
+					Label:  "str_attr",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 27},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 27},
+						},
+						NewText: "str_attr",
+						Snippet: "str_attr = \"${1:value}\"",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"start of whitespace-only line",
+			hcl.Pos{Line: 3, Column: 1, Byte: 25},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "nested",
+					Detail: "Block",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 25},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 25},
+						},
+						NewText: "nested",
+						Snippet: "nested {\n  ${1}\n}",
+					},
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+				},
+				{
+					Label:  "str_attr",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 1, Byte: 25},
+							End:      hcl.Pos{Line: 3, Column: 1, Byte: 25},
+						},
+						NewText: "str_attr",
+						Snippet: "str_attr = \"${1:value}\"",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"empty line",
+			hcl.Pos{Line: 4, Column: 1, Byte: 28},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "nested",
+					Detail: "Block",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 1, Byte: 28},
+							End:      hcl.Pos{Line: 4, Column: 1, Byte: 28},
+						},
+						NewText: "nested",
+						Snippet: "nested {\n  ${1}\n}",
+					},
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+				},
+				{
+					Label:  "str_attr",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 1, Byte: 28},
+							End:      hcl.Pos{Line: 4, Column: 1, Byte: 28},
+						},
+						NewText: "str_attr",
+						Snippet: "str_attr = \"${1:value}\"",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_noTrailingNewline(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"num_attr": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
+						"str_attr": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"block type prefix",
+			`myb`,
+			hcl.Pos{Line: 1, Column: 4, Byte: 3},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "myblock",
+					Detail: "Block",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+						},
+						NewText: "myblock",
+						Snippet: "myblock {\n  ${1}\n}",
+					},
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+				},
+			}),
+		},
+		{
+			"unterminated block body",
+			"myblock {\n  ",
+			hcl.Pos{Line: 2, Column: 3, Byte: 12},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "num_attr",
+					Detail: "optional, number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 12},
+							End:      hcl.Pos{Line: 2, Column: 3, Byte: 12},
+						},
+						NewText: "num_attr",
+						Snippet: "num_attr = ${1:0}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+				{
+					Label:  "str_attr",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 12},
+							End:      hcl.Pos{Line: 2, Column: 3, Byte: 12},
+						},
+						NewText: "str_attr",
+						Snippet: "str_attr = \"${1:value}\"",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"attribute prefix in unterminated block",
+			"myblock {\n  str",
+			hcl.Pos{Line: 2, Column: 6, Byte: 15},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "str_attr",
+					Detail: "optional, string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 12},
+							End:      hcl.Pos{Line: 2, Column: 6, Byte: 15},
+						},
+						NewText: "str_attr",
+						Snippet: "str_attr = \"${1:value}\"",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_uniqueLabels(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name", AllowedValues: []string{"db", "web"}},
+				},
+				LabelsUnique: true,
+			},
+		},
+	}
+
+	cfg := []byte(`resource "aws_instance" "web" {
+}
+resource "aws_instance" "web" {
+}
+resource "aws_db" "web" {
+}
+resource "aws_instance" {
+}
+`)
+
+	f, _ := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	testCases := []struct {
+		name               string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"name in use by sibling",
+			hcl.Pos{Line: 3, Column: 29, Byte: 62},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "web_1",
+					Detail: "unique name",
+					Kind:   lang.LabelCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 26, Byte: 59},
+							End:      hcl.Pos{Line: 3, Column: 29, Byte: 62},
+						},
+						NewText: "web_1",
+						Snippet: "web_1",
+					},
+				},
+			}),
+		},
+		{
+			"name in use by sibling of different type label",
+			hcl.Pos{Line: 5, Column: 23, Byte: 90},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "web",
+					Kind:  lang.LabelCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 20, Byte: 87},
+							End:      hcl.Pos{Line: 5, Column: 23, Byte: 90},
+						},
+						NewText: "web",
+						Snippet: "web",
+					},
+				},
+			}),
+		},
+		{
+			"new name label",
+			hcl.Pos{Line: 7, Column: 25, Byte: 120},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "db",
+					Kind:  lang.LabelCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 7, Column: 25, Byte: 120},
+							End:      hcl.Pos{Line: 7, Column: 25, Byte: 120},
+						},
+						NewText: `"db"`,
+						Snippet: `"db"`,
+					},
+				},
+				{
+					Label:  "name",
+					Detail: "unique name",
+					Kind:   lang.LabelCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 7, Column: 25, Byte: 120},
+							End:      hcl.Pos{Line: 7, Column: 25, Byte: 120},
+						},
+						NewText: `"name"`,
+						Snippet: `"name"`,
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_multipleTypes(t *testing.T) {
+	ctx := context.Background()
+	resourceLabelSchema := []*schema.LabelSchema{
+		{Name: "type", IsDepKey: true, Completable: true},
+		{Name: "name"},
+	}
+
+	resourceSchema := &schema.BlockSchema{
+		Labels: resourceLabelSchema,
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"for_each": {
+					Constraint: schema.OneOf{
+						schema.LiteralType{Type: cty.Set(cty.DynamicPseudoType)},
+						schema.LiteralType{Type: cty.Map(cty.DynamicPseudoType)},
+					},
+					IsOptional: true,
+				},
+			},
+		},
+	}
+
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": resourceSchema,
+		},
+	}
+
+	cfg := []byte(`resource "azurerm_subnet" "example" {
+
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(cfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	pos := hcl.Pos{Line: 2, Column: 1, Byte: 38}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "for_each",
+			Detail: "optional, set of any single type or map of any single type",
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 38},
+					End:      hcl.Pos{Line: 2, Column: 1, Byte: 38},
+				},
+				NewText: "for_each",
+				Snippet: "for_each = [ ${1} ]",
+			},
+			Kind: lang.AttributeCandidateKind,
+		},
+	})
+
+	diff := cmp.Diff(expectedCandidates, candidates, ctydebug.CmpOptions)
+	if diff != "" {
+		t.Fatalf("unexpected schema for %s: %s", stringPos(pos), diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_incompleteAttrOrBlock(t *testing.T) {
+	ctx := context.Background()
+	resourceLabelSchema := []*schema.LabelSchema{
+		{Name: "type"},
+		{Name: "name"},
+	}
+
+	resourceSchema := &schema.BlockSchema{
+		Labels: resourceLabelSchema,
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"count": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+			},
+		},
+	}
+
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": resourceSchema,
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		src                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"new root block or attribute",
+			`
+res
+`,
+			hcl.Pos{Line: 2, Column: 4, Byte: 4},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "resource",
+					Detail: "Block",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 4, Byte: 4},
+						},
+						NewText: "resource",
+						Snippet: "resource \"${1:type}\" \"${2:name}\" {\n  ${3}\n}",
+					},
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
+				},
+			}),
+		},
+		{
+			"new block or attribute inside a block",
+			`
+resource "any" "ref" {
+  co
+}
+`,
+			hcl.Pos{Line: 3, Column: 5, Byte: 28},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "count",
+					Detail: "optional, number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 26},
+							End:      hcl.Pos{Line: 3, Column: 5, Byte: 28},
+						},
+						NewText: "count",
+						Snippet: "count = ${1:0}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"cursor in the middle of block or attribute name",
+			`
+resource "any" "ref" {
+  coxyz
+}
+`,
+			hcl.Pos{Line: 3, Column: 5, Byte: 28},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "count",
+					Detail: "optional, number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 26},
+							End:      hcl.Pos{Line: 3, Column: 8, Byte: 31},
+						},
+						NewText: "count",
+						Snippet: "count = ${1:0}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.src), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
 
 			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
 			if err != nil {
@@ -1630,7 +2432,8 @@ res
 						NewText: "resource",
 						Snippet: "resource \"${1:type}\" \"${2:name}\" {\n  ${3}\n}",
 					},
-					Kind: lang.BlockCandidateKind,
+					Kind:             lang.BlockCandidateKind,
+					CommitCharacters: []string{" "},
 				},
 			}),
 		},
@@ -1694,3 +2497,221 @@ resource "random_resource" "test" {
   arg = ""
 }
 `)
+
+func TestDecoder_CompletionAtPos_disableSnippets(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+				},
+				Body: schema.NewBodySchema(),
+			},
+		},
+		Attributes: map[string]*schema.AttributeSchema{
+			"tags": {
+				Constraint: schema.Set{
+					Elem: schema.LiteralType{Type: cty.String},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name string
+		cfg  string
+		pos  hcl.Pos
+	}{
+		{
+			"block and attribute",
+			"\n",
+			hcl.InitialPos,
+		},
+		{
+			"empty set expression",
+			"tags = \n",
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			d.decoderCtx.DisableSnippets = true
+
+			candidates, err := d.CompletionAtPos(context.Background(), "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(candidates.List) == 0 {
+				t.Fatal("expected candidates")
+			}
+			for _, c := range candidates.List {
+				if c.TextEdit.Snippet != "" {
+					t.Fatalf("unexpected snippet for %q: %q", c.Label, c.TextEdit.Snippet)
+				}
+				if c.TextEdit.NewText == "" {
+					t.Fatalf("expected NewText for %q", c.Label)
+				}
+			}
+		})
+	}
+}
+
+func TestDecoder_CompletionAtPos_candidateHook(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.OneOf{
+					schema.Keyword{Keyword: "bar"},
+					schema.Keyword{Keyword: "baz"},
+					schema.Keyword{Keyword: "foo"},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte("attr = \n"), "test.tf", hcl.InitialPos)
+	pos := hcl.Pos{Line: 1, Column: 8, Byte: 7}
+
+	var hookCons schema.Constraint
+	var hookPos hcl.Pos
+	var hookSchemaPath schemacontext.SchemaPath
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		CandidateHook: func(ctx context.Context, candidates []lang.Candidate) []lang.Candidate {
+			hookCons, _ = CompletionConstraintFromContext(ctx)
+			hookPos, _ = PosFromContext(ctx)
+			hookSchemaPath, _ = schemacontext.FromContext(ctx)
+
+			// reverse order and drop the first candidate
+			filtered := make([]lang.Candidate, 0)
+			for i := len(candidates) - 1; i > 0; i-- {
+				filtered = append(filtered, candidates[i])
+			}
+			return filtered
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(context.Background(), "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := make([]string, 0)
+	for _, c := range candidates.List {
+		labels = append(labels, c.Label)
+	}
+	expectedLabels := []string{"foo", "baz"}
+	if diff := cmp.Diff(expectedLabels, labels); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+
+	if diff := cmp.Diff(bodySchema.Attributes["attr"].Constraint, hookCons); diff != "" {
+		t.Fatalf("unexpected constraint passed to hook: %s", diff)
+	}
+	if diff := cmp.Diff(pos, hookPos); diff != "" {
+		t.Fatalf("unexpected position passed to hook: %s", diff)
+	}
+	expectedSchemaPath := schemacontext.SchemaPath{
+		BlockTypes:    []string{},
+		AttributeName: "attr",
+	}
+	if diff := cmp.Diff(expectedSchemaPath, hookSchemaPath); diff != "" {
+		t.Fatalf("unexpected schema path passed to hook: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_attributeRelations(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"username": {
+				Constraint:   schema.LiteralType{Type: cty.String},
+				IsOptional:   true,
+				RequiredWith: []string{"password"},
+			},
+			"password": {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+			"region":   {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+			"token": {
+				Constraint:    schema.LiteralType{Type: cty.String},
+				IsOptional:    true,
+				ConflictsWith: []string{"username"},
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig([]byte(`username = "u"
+
+`), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	pos := hcl.Pos{Line: 2, Column: 1, Byte: 15}
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    pos,
+		End:      pos,
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "password",
+			Detail: "optional, string, required by username",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "password",
+				Snippet: `password = "${1:value}"`,
+			},
+			Kind:     lang.AttributeCandidateKind,
+			SortText: "0password",
+		},
+		{
+			Label:  "region",
+			Detail: "optional, string",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "region",
+				Snippet: `region = "${1:value}"`,
+			},
+			Kind:     lang.AttributeCandidateKind,
+			SortText: "1region",
+		},
+		{
+			Label:  "token",
+			Detail: "optional, string, conflicts with username",
+			TextEdit: lang.TextEdit{
+				Range:   rng,
+				NewText: "token",
+				Snippet: `token = "${1:value}"`,
+			},
+			Kind:     lang.AttributeCandidateKind,
+			SortText: "2token",
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// CodeActionsInRange returns code actions for attributes within
+// the given range of the file, such as normalization of formatting
+// of attribute values, or removal of deprecated attributes.
+//
+// Each transformation is returned as a separate code action,
+// such that the client can choose which one to apply.
+func (d *PathDecoder) CodeActionsInRange(filename string, rng hcl.Range) ([]lang.CodeAction, error) {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := d.bodyForFileAndPos(filename, f, hcl.InitialPos)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]lang.CodeAction, 0)
+	for _, attr := range attributesInRange(body, d.pathCtx.Schema, rng) {
+		if attr.schema != nil && attr.schema.IsDeprecated {
+			actions = append(actions, codeActionsForDeprecatedAttribute(f.Bytes, attr.body, attr.Attribute, attr.schema)...)
+		}
+
+		hclsyntax.VisitAll(attr.Expr, func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(hclsyntax.Expression)
+			if !ok {
+				return nil
+			}
+			actions = append(actions, codeActionsForExpression(f.Bytes, expr)...)
+			return nil
+		})
+	}
+
+	return actions, nil
+}
+
+type attributeInRange struct {
+	*hclsyntax.Attribute
+	body *hclsyntax.Body
+	// schema of the attribute, if known
+	schema *schema.AttributeSchema
+}
+
+// attributesInRange returns attributes of the body (including nested
+// bodies) overlapping with the given range, in the order in which
+// they appear in the configuration.
+func attributesInRange(body *hclsyntax.Body, bodySchema *schema.BodySchema, rng hcl.Range) []attributeInRange {
+	attrs := make([]attributeInRange, 0)
+
+	for name, attr := range body.Attributes {
+		if attr.SrcRange.Overlaps(rng) || attr.SrcRange.ContainsPos(rng.Start) {
+			aSchema, _ := schemahelper.AttributeSchema(bodySchema, name)
+			attrs = append(attrs, attributeInRange{
+				Attribute: attr,
+				body:      body,
+				schema:    aSchema,
+			})
+		}
+	}
+	for _, block := range body.Blocks {
+		if block.Body == nil {
+			continue
+		}
+		var blockBodySchema *schema.BodySchema
+		if bodySchema != nil {
+			if blockSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type); ok {
+				blockBodySchema, _ = schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
+			}
+		}
+		attrs = append(attrs, attributesInRange(block.Body, blockBodySchema, rng)...)
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+
+	return attrs
+}
+
+// codeActionsForDeprecatedAttribute returns code actions removing
+// the deprecated attribute and (if the schema declares a replacement
+// which is not declared yet) renaming it to its replacement.
+func codeActionsForDeprecatedAttribute(src []byte, body *hclsyntax.Body, attr *hclsyntax.Attribute, aSchema *schema.AttributeSchema) []lang.CodeAction {
+	actions := []lang.CodeAction{
+		{
+			Title: fmt.Sprintf("Remove deprecated attribute %q", attr.Name),
+			Kind:  lang.QuickFixCodeActionKind,
+			Edits: []lang.TextEdit{
+				{
+					Range: attributeRemovalRange(src, attr.SrcRange),
+				},
+			},
+		},
+	}
+
+	if aSchema.ReplacedBy == "" {
+		return actions
+	}
+	if _, declared := body.Attributes[aSchema.ReplacedBy]; declared {
+		return actions
+	}
+
+	return append(actions, lang.CodeAction{
+		Title: fmt.Sprintf("Rename %q to %q", attr.Name, aSchema.ReplacedBy),
+		Kind:  lang.QuickFixCodeActionKind,
+		Edits: []lang.TextEdit{
+			{
+				Range:   attr.NameRange,
+				NewText: aSchema.ReplacedBy,
+			},
+		},
+	})
+}
+
+// attributeRemovalRange returns the range to remove when removing
+// an attribute of the given range. The range is expanded to the full
+// lines only if the attribute is the only thing on its lines,
+// e.g. not within a single-line block such as block { attr = 1 }.
+func attributeRemovalRange(src []byte, rng hcl.Range) hcl.Range {
+	lineStart := bytes.LastIndexByte(src[:rng.Start.Byte], '\n') + 1
+	if len(bytes.TrimSpace(src[lineStart:rng.Start.Byte])) > 0 {
+		return rng
+	}
+
+	lineEnd := len(src)
+	if idx := bytes.IndexByte(src[rng.End.Byte:], '\n'); idx >= 0 {
+		lineEnd = rng.End.Byte + idx
+	}
+	if len(bytes.TrimSpace(src[rng.End.Byte:lineEnd])) > 0 {
+		return rng
+	}
+
+	return fullLineRange(src, rng)
+}
+
+// fullLineRange expands the given range to the full lines it spans,
+// including the trailing newline (if any).
+func fullLineRange(src []byte, rng hcl.Range) hcl.Range {
+	start := hcl.Pos{
+		Line:   rng.Start.Line,
+		Column: 1,
+		Byte:   bytes.LastIndexByte(src[:rng.Start.Byte], '\n') + 1,
+	}
+
+	idx := bytes.IndexByte(src[rng.End.Byte:], '\n')
+	if idx < 0 {
+		return hcl.Range{
+			Filename: rng.Filename,
+			Start:    start,
+			End:      hcl.Pos{Line: rng.End.Line, Column: rng.End.Column + utf8.RuneCount(src[rng.End.Byte:]), Byte: len(src)},
+		}
+	}
+
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start:    start,
+		End:      hcl.Pos{Line: rng.End.Line + 1, Column: 1, Byte: rng.End.Byte + idx + 1},
+	}
+}
+
+func codeActionsForExpression(src []byte, expr hclsyntax.Expression) []lang.CodeAction {
+	actions := make([]lang.CodeAction, 0)
+
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateWrapExpr:
+		// "${var.x}" is equivalent to var.x only for plain references,
+		// as other expressions may change type when wrapped
+		wrapped, ok := e.Wrapped.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			break
+		}
+		actions = append(actions, lang.CodeAction{
+			Title: "Remove redundant interpolation",
+			Kind:  lang.RefactorRewriteCodeActionKind,
+			Edits: []lang.TextEdit{
+				{
+					Range:   e.SrcRange,
+					NewText: string(wrapped.SrcRange.SliceBytes(src)),
+				},
+			},
+		})
+	case *hclsyntax.TemplateExpr:
+		if !isHeredoc(src, e) {
+			break
+		}
+
+		if text, ok := singleLineHeredocValue(e); ok {
+			actions = append(actions, lang.CodeAction{
+				Title: "Convert heredoc to string",
+				Kind:  lang.RefactorRewriteCodeActionKind,
+				Edits: []lang.TextEdit{
+					{
+						Range:   e.SrcRange,
+						NewText: string(hclwrite.TokensForValue(cty.StringVal(text)).Bytes()),
+					},
+				},
+			})
+		}
+
+		// trailing whitespace is part of the heredoc value,
+		// so removing it is not a meaning-preserving rewrite
+		if edits := trailingWhitespaceEdits(src, e.SrcRange); len(edits) > 0 {
+			actions = append(actions, lang.CodeAction{
+				Title: "Trim trailing whitespace in heredoc",
+				Kind:  lang.RefactorCodeActionKind,
+				Edits: edits,
+			})
+		}
+	}
+
+	return actions
+}
+
+func isHeredoc(src []byte, expr *hclsyntax.TemplateExpr) bool {
+	return bytes.HasPrefix(expr.SrcRange.SliceBytes(src), []byte("<<"))
+}
+
+// singleLineHeredocValue returns the value of a heredoc
+// which consists of a single line of literal text.
+func singleLineHeredocValue(expr *hclsyntax.TemplateExpr) (string, bool) {
+	if !expr.IsStringLiteral() {
+		return "", false
+	}
+	lit, ok := expr.Parts[0].(*hclsyntax.LiteralValueExpr)
+	if !ok || lit.Val.Type() != cty.String || !lit.Val.IsKnown() || lit.Val.IsNull() {
+		return "", false
+	}
+
+	value := lit.Val.AsString()
+	if !strings.HasSuffix(value, "\n") {
+		return "", false
+	}
+	value = strings.TrimSuffix(value, "\n")
+	if strings.Contains(value, "\n") {
+		return "", false
+	}
+
+	return value, true
+}
+
+// trailingWhitespaceEdits returns edits removing trailing spaces
+// and tabs from lines within the given (heredoc) range,
+// skipping the opening line.
+func trailingWhitespaceEdits(src []byte, rng hcl.Range) []lang.TextEdit {
+	edits := make([]lang.TextEdit, 0)
+	b := rng.SliceBytes(src)
+
+	pos := rng.Start
+	var wsStart *hcl.Pos
+	isFirstLine := true
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		switch {
+		case r == '\n':
+			if wsStart != nil && !isFirstLine {
+				edits = append(edits, lang.TextEdit{
+					Range: hcl.Range{
+						Filename: rng.Filename,
+						Start:    *wsStart,
+						End:      pos,
+					},
+				})
+			}
+			wsStart = nil
+			isFirstLine = false
+			pos = hcl.Pos{Line: pos.Line + 1, Column: 1, Byte: pos.Byte + size}
+			i += size
+			continue
+		case r == ' ' || r == '\t':
+			if wsStart == nil {
+				startPos := pos
+				wsStart = &startPos
+			}
+		default:
+			wsStart = nil
+		}
+		pos = hcl.Pos{Line: pos.Line, Column: pos.Column + 1, Byte: pos.Byte + size}
+		i += size
+	}
+
+	return edits
+}
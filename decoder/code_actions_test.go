@@ -0,0 +1,315 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestCodeActionsInRange(t *testing.T) {
+	testCases := []struct {
+		name            string
+		cfg             string
+		rng             hcl.Range
+		expectedActions []lang.CodeAction
+	}{
+		{
+			"no actions",
+			`attr = "foo"
+`,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 2, Column: 1, Byte: 13},
+			},
+			[]lang.CodeAction{},
+		},
+		{
+			"redundant interpolation",
+			`attr = "${var.foo}"
+`,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			},
+			[]lang.CodeAction{
+				{
+					Title: "Remove redundant interpolation",
+					Kind:  lang.RefactorRewriteCodeActionKind,
+					Edits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+								End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+							},
+							NewText: "var.foo",
+						},
+					},
+				},
+			},
+		},
+		{
+			"interpolation with surrounding text",
+			`attr = "prefix-${var.foo}"
+`,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 2, Column: 1, Byte: 27},
+			},
+			[]lang.CodeAction{},
+		},
+		{
+			"interpolation of non-reference",
+			`attr = "${5}"
+`,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 2, Column: 1, Byte: 14},
+			},
+			[]lang.CodeAction{},
+		},
+		{
+			"single line heredoc",
+			`attr = <<EOT
+say "hi"
+EOT
+`,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 1, Byte: 0},
+			},
+			[]lang.CodeAction{
+				{
+					Title: "Convert heredoc to string",
+					Kind:  lang.RefactorRewriteCodeActionKind,
+					Edits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+								End:      hcl.Pos{Line: 3, Column: 4, Byte: 25},
+							},
+							NewText: `"say \"hi\""`,
+						},
+					},
+				},
+			},
+		},
+		{
+			"multi-line heredoc with trailing whitespace",
+			"attr = <<EOT\nfoo  \nbar\t\nbaz\nEOT\n",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 1, Byte: 0},
+			},
+			[]lang.CodeAction{
+				{
+					Title: "Trim trailing whitespace in heredoc",
+					Kind:  lang.RefactorCodeActionKind,
+					Edits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 2, Column: 4, Byte: 16},
+								End:      hcl.Pos{Line: 2, Column: 6, Byte: 18},
+							},
+						},
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 3, Column: 4, Byte: 22},
+								End:      hcl.Pos{Line: 3, Column: 5, Byte: 23},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"nested block outside of range",
+			`block {
+  attr = "${var.foo}"
+}
+`,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 6, Byte: 5},
+			},
+			[]lang.CodeAction{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			actions, err := d.CodeActionsInRange("test.tf", tc.rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedActions, actions); diff != "" {
+				t.Fatalf("unexpected code actions: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCodeActionsInRange_deprecatedAttribute(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"block": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"old_name": {
+							Constraint:   schema.LiteralType{Type: cty.String},
+							IsOptional:   true,
+							IsDeprecated: true,
+							ReplacedBy:   "new_name",
+						},
+						"new_name": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsOptional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	rng := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+		End:      hcl.Pos{Line: 2, Column: 3, Byte: 10},
+	}
+	removeAction := lang.CodeAction{
+		Title: `Remove deprecated attribute "old_name"`,
+		Kind:  lang.QuickFixCodeActionKind,
+		Edits: []lang.TextEdit{
+			{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 8},
+					End:      hcl.Pos{Line: 3, Column: 1, Byte: 27},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		cfg             string
+		expectedActions []lang.CodeAction
+	}{
+		{
+			"rename to replacement",
+			`block {
+  old_name = "foo"
+}
+`,
+			[]lang.CodeAction{
+				removeAction,
+				{
+					Title: `Rename "old_name" to "new_name"`,
+					Kind:  lang.QuickFixCodeActionKind,
+					Edits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 2, Column: 3, Byte: 10},
+								End:      hcl.Pos{Line: 2, Column: 11, Byte: 18},
+							},
+							NewText: "new_name",
+						},
+					},
+				},
+			},
+		},
+		{
+			"replacement already declared",
+			`block {
+  old_name = "foo"
+  new_name = "bar"
+}
+`,
+			[]lang.CodeAction{
+				removeAction,
+			},
+		},
+		{
+			"single-line block",
+			`block { old_name = "foo" }
+`,
+			[]lang.CodeAction{
+				{
+					Title: `Remove deprecated attribute "old_name"`,
+					Kind:  lang.QuickFixCodeActionKind,
+					Edits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+								End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+							},
+						},
+					},
+				},
+				{
+					Title: `Rename "old_name" to "new_name"`,
+					Kind:  lang.QuickFixCodeActionKind,
+					Edits: []lang.TextEdit{
+						{
+							Range: hcl.Range{
+								Filename: "test.tf",
+								Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+								End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+							},
+							NewText: "new_name",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			actions, err := d.CodeActionsInRange("test.tf", rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedActions, actions); diff != "" {
+				t.Fatalf("unexpected code actions: %s", diff)
+			}
+		})
+	}
+}
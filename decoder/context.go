@@ -39,6 +39,45 @@ type DecoderContext struct {
 	// a resolve hook, ResolveCandidate will execute the hook and return
 	// additional (resolved) data for the completion item.
 	CompletionResolveHooks CompletionResolveFuncMap
+
+	// DisableSnippets instructs the decoder to return completion
+	// candidates without snippets (and their placeholders),
+	// for clients which cannot render them.
+	// Only the plain NewText is then returned in candidate text edits.
+	DisableSnippets bool
+
+	// ReportUnknownFunctions enables validation of function calls
+	// against known functions (see validator.UnknownFunction).
+	// It is off by default, as some deployments intentionally
+	// provide only a partial set of functions.
+	ReportUnknownFunctions bool
+
+	// HoverIncludeSourceComments instructs the decoder to include
+	// line comments (# or //) placed directly above an attribute
+	// or a block in the hover content for its name or type,
+	// such that configuration can document itself.
+	HoverIncludeSourceComments bool
+
+	// HoverUnknownAttributes instructs the decoder to return hover data
+	// for attributes not declared in the schema, instead of an error.
+	// The hover content states that the attribute is unknown
+	// and suggests the closest known attribute name, if any,
+	// to help catch typos.
+	HoverUnknownAttributes bool
+
+	// MaxReferenceStepDepth (if non-zero) limits how many attribute steps
+	// reference completion derives from the (object) type of a target,
+	// e.g. a limit of 1 offers var.foo.bar but not var.foo.bar.baz.
+	// This prevents expensive completion for very deep or wide types.
+	// Zero (default) means unlimited depth.
+	MaxReferenceStepDepth int
+
+	// ReferenceTargetValues instructs the decoder to record statically
+	// known (literal) values of collected reference targets
+	// (see reference.Target.Value) and to render them in hover data
+	// for references, e.g. "us-east-1" for var.region.
+	// It is off by default to avoid exposing sensitive values.
+	ReferenceTargetValues bool
 }
 
 func NewDecoderContext() DecoderContext {
@@ -50,6 +89,7 @@ func NewDecoderContext() DecoderContext {
 
 func (d *Decoder) SetContext(ctx DecoderContext) {
 	d.ctx = ctx
+	d.resetTokensCache()
 }
 
 // CompletionFunc is the function signature for completion hooks.
@@ -100,6 +140,10 @@ type Candidate struct {
 	// SortText is an optional string that will be used when comparing this
 	// candidate with other candidates
 	SortText string
+
+	// FilterText is an optional string that will be used when filtering
+	// candidates, if it differs from the label
+	FilterText string
 }
 
 // ExpressionCandidate is a simplified version of Candidate and the preferred
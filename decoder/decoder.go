@@ -5,13 +5,21 @@ package decoder
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl/v2"
 )
 
 type Decoder struct {
 	ctx        DecoderContext
 	pathReader PathReader
+
+	tokensCache   map[tokensCacheKey]tokensCacheEntry
+	tokensCacheMu sync.Mutex
+
+	functionSigsCache   map[lang.Path]functionSignaturesCacheEntry
+	functionSigsCacheMu sync.Mutex
 }
 
 // NewDecoder creates a new Decoder
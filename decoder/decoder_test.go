@@ -5,10 +5,17 @@ package decoder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type testPathReader struct {
@@ -53,3 +60,378 @@ func testPathDecoder(t *testing.T, pathCtx *PathContext) *PathDecoder {
 
 	return pathDecoder
 }
+
+func TestDecoder_InPath_unknownPath(t *testing.T) {
+	ctx := context.Background()
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{},
+	})
+	path := lang.Path{Path: "unknown"}
+
+	_, err := d.CompletionAtPosInPath(ctx, path, "test.tf", hcl.InitialPos)
+	if err == nil {
+		t.Fatal("expected error for unknown path (completion)")
+	}
+	_, err = d.HoverAtPosInPath(ctx, path, "test.tf", hcl.InitialPos)
+	if err == nil {
+		t.Fatal("expected error for unknown path (hover)")
+	}
+	_, err = d.SemanticTokensInFileInPath(ctx, path, "test.tf")
+	if err == nil {
+		t.Fatal("expected error for unknown path (semantic tokens)")
+	}
+}
+
+func TestDecoder_InPath_basic(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				Constraint:  schema.LiteralType{Type: cty.String},
+				IsOptional:  true,
+				Description: lang.PlainText("name of the thing"),
+			},
+		},
+	}
+	f, pDiags := hclsyntax.ParseConfig([]byte("name = \"foo\"\n"), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			"first": {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	})
+	d.SetContext(NewDecoderContext())
+	path := lang.Path{Path: "first"}
+
+	candidates, err := d.CompletionAtPosInPath(ctx, path, "test.tf", hcl.Pos{Line: 2, Column: 1, Byte: 13})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if candidates.Len() != 0 {
+		t.Fatalf("expected no candidates for already declared attribute, %d given", candidates.Len())
+	}
+
+	hoverData, err := d.HoverAtPosInPath(ctx, path, "test.tf", hcl.Pos{Line: 1, Column: 2, Byte: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedContent := lang.Markdown("**name** _optional, string_\n\nname of the thing")
+	if diff := cmp.Diff(expectedContent, hoverData.Content); diff != "" {
+		t.Fatalf("unexpected hover content: %s", diff)
+	}
+
+	tokens, err := d.SemanticTokensInFileInPath(ctx, path, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, %d given: %#v", len(tokens), tokens)
+	}
+}
+
+func TestDecoder_SemanticTokensInFileInPath_version(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			"type": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+	parseFile := func(t *testing.T, src string) *hcl.File {
+		f, pDiags := hclsyntax.ParseConfig([]byte(src), "test.tf", hcl.InitialPos)
+		if len(pDiags) > 0 {
+			t.Fatal(pDiags)
+		}
+		return f
+	}
+
+	pathCtx := &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": parseFile(t, "name = \"foo\"\n"),
+		},
+		Version: 1,
+	}
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			"first": pathCtx,
+		},
+	})
+	d.SetContext(NewDecoderContext())
+	path := lang.Path{Path: "first"}
+
+	assertTokenCount := func(t *testing.T, expectedCount int) {
+		t.Helper()
+		tokens, err := d.SemanticTokensInFileInPath(ctx, path, "test.tf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tokens) != expectedCount {
+			t.Fatalf("expected %d tokens, %d given: %#v", expectedCount, len(tokens), tokens)
+		}
+	}
+
+	assertTokenCount(t, 2)
+
+	// file changed without bumping the version returns stale (cached) tokens
+	pathCtx.Files["test.tf"] = parseFile(t, "name = \"foo\"\ntype = \"bar\"\n")
+	assertTokenCount(t, 2)
+
+	// bumping the version invalidates the cache
+	pathCtx.Version++
+	assertTokenCount(t, 4)
+
+	// zero version disables caching
+	pathCtx.Version = 0
+	pathCtx.Files["test.tf"] = parseFile(t, "type = \"bar\"\n")
+	assertTokenCount(t, 2)
+}
+
+func TestDecoder_SemanticTokensInFileInPath_cachedModifiers(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				Constraint:             schema.LiteralType{Type: cty.String},
+				IsOptional:             true,
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
+			},
+		},
+	}
+	f, pDiags := hclsyntax.ParseConfig([]byte("name = \"foo\"\n"), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			"first": {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Version: 1,
+			},
+		},
+	})
+	d.SetContext(NewDecoderContext())
+	path := lang.Path{Path: "first"}
+
+	tokens, err := d.SemanticTokensInFileInPath(ctx, path, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedTokens := []lang.SemanticToken{
+		{
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+			},
+		},
+		{
+			Type:      lang.TokenString,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+
+	// modifying returned tokens must not affect the cache
+	for _, token := range tokens {
+		for i := range token.Modifiers {
+			token.Modifiers[i] = lang.TokenModifierSynthetic
+		}
+	}
+
+	cachedTokens, err := d.SemanticTokensInFileInPath(ctx, path, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expectedTokens, cachedTokens); diff != "" {
+		t.Fatalf("unexpected cached tokens: %s", diff)
+	}
+}
+
+func TestDecoder_functionSignaturesVersion(t *testing.T) {
+	pathCtx := &PathContext{
+		Functions: map[string]schema.FunctionSignature{
+			"foo": {ReturnType: cty.String},
+		},
+		Version: 1,
+	}
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			"first": pathCtx,
+		},
+	})
+	path := lang.Path{Path: "first"}
+
+	assertSignatureCount := func(t *testing.T, expectedCount int) {
+		t.Helper()
+		pd, err := d.Path(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatures := pd.functionSignatures()
+		if len(signatures) != expectedCount {
+			t.Fatalf("expected %d signatures, %d given: %#v", expectedCount, len(signatures), signatures)
+		}
+	}
+
+	assertSignatureCount(t, 1)
+
+	// functions changed without bumping the version returns cached signatures
+	pathCtx.Functions["bar"] = schema.FunctionSignature{ReturnType: cty.Number}
+	assertSignatureCount(t, 1)
+
+	// bumping the version invalidates the cache
+	pathCtx.Version++
+	assertSignatureCount(t, 2)
+
+	// zero version disables caching
+	pathCtx.Version = 0
+	delete(pathCtx.Functions, "foo")
+	assertSignatureCount(t, 1)
+}
+
+func TestDecoder_DecodableFiles(t *testing.T) {
+	hclFile, pDiags := hclsyntax.ParseConfig([]byte("name = \"foo\"\n"), "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	secondHclFile, pDiags := hclsyntax.ParseConfig([]byte("name = \"bar\"\n"), "another.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	jsonFile, pDiags := json.Parse([]byte(`{"name": "foo"}`), "test.tf.json")
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			"first": {
+				Schema: &schema.BodySchema{},
+				Files: map[string]*hcl.File{
+					"test.tf":      hclFile,
+					"another.tf":   secondHclFile,
+					"test.tf.json": jsonFile,
+				},
+			},
+			"noschema": {
+				Files: map[string]*hcl.File{
+					"test.tf": hclFile,
+				},
+			},
+		},
+	})
+
+	files, err := d.DecodableFiles(lang.Path{Path: "first"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedFiles := []string{"another.tf", "test.tf"}
+	if diff := cmp.Diff(expectedFiles, files); diff != "" {
+		t.Fatalf("unexpected files: %s", diff)
+	}
+
+	_, err = d.DecodableFiles(lang.Path{Path: "noschema"})
+	noSchemaErr := &NoSchemaError{}
+	if !errors.As(err, &noSchemaErr) {
+		t.Fatal("expected NoSchemaError for no schema")
+	}
+}
+
+func TestPathDecoder_nilFileBody(t *testing.T) {
+	files := map[string]*hcl.File{
+		"nil-body.tf": {
+			Bytes: []byte("name = \"foo\"\n"),
+		},
+		"typed-nil-body.tf": {
+			Body:  (*hclsyntax.Body)(nil),
+			Bytes: []byte("name = \"foo\"\n"),
+		},
+		"nil.tf": nil,
+	}
+	d := testPathDecoder(t, &PathContext{
+		Schema: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"name": {Constraint: schema.LiteralType{Type: cty.String}},
+			},
+		},
+		Files: files,
+	})
+
+	ctx := context.Background()
+	pos := hcl.Pos{Line: 1, Column: 2, Byte: 1}
+	rng := hcl.Range{Start: hcl.InitialPos, End: pos}
+
+	for filename := range files {
+		entryPoints := map[string]func() error{
+			"SemanticTokensInFile": func() error {
+				_, err := d.SemanticTokensInFile(ctx, filename)
+				return err
+			},
+			"HoverAtPos": func() error {
+				_, err := d.HoverAtPos(ctx, filename, pos)
+				return err
+			},
+			"CompletionAtPos": func() error {
+				_, err := d.CompletionAtPos(ctx, filename, pos)
+				return err
+			},
+			"SymbolsInFile": func() error {
+				_, err := d.SymbolsInFile(filename)
+				return err
+			},
+			"CodeActionsInRange": func() error {
+				rng.Filename = filename
+				_, err := d.CodeActionsInRange(filename, rng)
+				return err
+			},
+		}
+		for name, entryPoint := range entryPoints {
+			t.Run(fmt.Sprintf("%s-%s", filename, name), func(t *testing.T) {
+				err := entryPoint()
+				unknownFormatErr := &UnknownFileFormatError{}
+				if !errors.As(err, &unknownFormatErr) {
+					t.Fatalf("expected UnknownFileFormatError, given: %#v", err)
+				}
+			})
+		}
+	}
+
+	// path-wide operations skip such files
+	if _, err := d.CollectReferenceTargets(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.CollectReferenceOrigins(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Validate(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
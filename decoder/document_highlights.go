@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// DocumentHighlightsAtPos returns all occurrences of the reference
+// under the cursor within the given file, i.e. the definition
+// of the target (if declared in the same file) and all origins
+// targeting it.
+//
+// The cursor may be placed either on a reference origin, or on
+// the definition of a reference target. Empty slice is returned
+// if there is no reference at the given position.
+func (d *Decoder) DocumentHighlightsAtPos(path lang.Path, file string, pos hcl.Pos) ([]lang.DocumentHighlight, error) {
+	highlights := make([]lang.DocumentHighlight, 0)
+
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return highlights, err
+	}
+
+	targets := make(reference.Targets, 0)
+	if origins, ok := pathCtx.ReferenceOrigins.AtPos(file, pos); ok {
+		for _, origin := range origins {
+			if _, ok := origin.(reference.LocalOrigin); !ok {
+				// only local origins can target the same file
+				continue
+			}
+			matchableOrigin := origin.(reference.MatchableOrigin)
+			matchingTargets, ok := pathCtx.ReferenceTargets.Match(matchableOrigin)
+			if !ok {
+				continue
+			}
+			targets = append(targets, matchingTargets...)
+		}
+	} else if matchingTargets, ok := pathCtx.ReferenceTargets.InnermostAtPos(file, pos); ok {
+		for _, target := range matchingTargets {
+			if target.DefRangePtr != nil && target.DefRangePtr.ContainsPos(pos) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	seen := make(map[hcl.Range]bool, 0)
+	addHighlight := func(rng hcl.Range, kind lang.DocumentHighlightKind) {
+		if rng.Filename != file || seen[rng] {
+			return
+		}
+		seen[rng] = true
+		highlights = append(highlights, lang.DocumentHighlight{
+			Range: rng,
+			Kind:  kind,
+		})
+	}
+
+	fileOrigins := pathCtx.ReferenceOrigins.ForFile(file)
+	for _, target := range targets {
+		if target.DefRangePtr != nil {
+			addHighlight(*target.DefRangePtr, lang.WriteHighlightKind)
+		} else if target.RangePtr != nil {
+			addHighlight(*target.RangePtr, lang.WriteHighlightKind)
+		}
+
+		for _, origin := range fileOrigins.Match(path, target, path) {
+			addHighlight(origin.OriginRange(), lang.ReadHighlightKind)
+		}
+	}
+
+	sort.SliceStable(highlights, func(i, j int) bool {
+		return highlights[i].Range.Start.Byte < highlights[j].Range.Start.Byte
+	})
+
+	return highlights, nil
+}
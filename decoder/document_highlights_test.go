@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDocumentHighlightsAtPos(t *testing.T) {
+	dirPath := t.TempDir()
+	path := lang.Path{Path: dirPath}
+
+	targetAddr := lang.Address{
+		lang.RootStep{Name: "var"},
+		lang.AttrStep{Name: "foo"},
+	}
+	defRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+	}
+	firstOriginRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 4, Column: 7, Byte: 30},
+		End:      hcl.Pos{Line: 4, Column: 14, Byte: 37},
+	}
+	secondOriginRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 5, Column: 7, Byte: 44},
+		End:      hcl.Pos{Line: 5, Column: 14, Byte: 51},
+	}
+	otherFileOriginRange := hcl.Range{
+		Filename: "other.tf",
+		Start:    hcl.Pos{Line: 1, Column: 7, Byte: 6},
+		End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+	}
+	originConstraints := reference.OriginConstraints{
+		{OfType: cty.String},
+	}
+
+	pathCtx := &PathContext{
+		ReferenceTargets: reference.Targets{
+			{
+				Addr: targetAddr,
+				Type: cty.String,
+				RangePtr: &hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 2, Column: 2, Byte: 17},
+				},
+				DefRangePtr: defRange.Ptr(),
+			},
+		},
+		ReferenceOrigins: reference.Origins{
+			reference.LocalOrigin{
+				Addr:        targetAddr,
+				Range:       firstOriginRange,
+				Constraints: originConstraints,
+			},
+			reference.LocalOrigin{
+				Addr:        targetAddr,
+				Range:       secondOriginRange,
+				Constraints: originConstraints,
+			},
+			reference.LocalOrigin{
+				Addr:        targetAddr,
+				Range:       otherFileOriginRange,
+				Constraints: originConstraints,
+			},
+		},
+	}
+
+	expectedHighlights := []lang.DocumentHighlight{
+		{Range: defRange, Kind: lang.WriteHighlightKind},
+		{Range: firstOriginRange, Kind: lang.ReadHighlightKind},
+		{Range: secondOriginRange, Kind: lang.ReadHighlightKind},
+	}
+
+	testCases := []struct {
+		name               string
+		pos                hcl.Pos
+		expectedHighlights []lang.DocumentHighlight
+	}{
+		{
+			"on origin",
+			hcl.Pos{Line: 5, Column: 9, Byte: 46},
+			expectedHighlights,
+		},
+		{
+			"on target definition",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			expectedHighlights,
+		},
+		{
+			"inside target body",
+			hcl.Pos{Line: 2, Column: 1, Byte: 15},
+			[]lang.DocumentHighlight{},
+		},
+		{
+			"outside of any reference",
+			hcl.Pos{Line: 6, Column: 1, Byte: 60},
+			[]lang.DocumentHighlight{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.name), func(t *testing.T) {
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: pathCtx,
+				},
+			})
+
+			highlights, err := d.DocumentHighlightsAtPos(path, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedHighlights, highlights); diff != "" {
+				t.Fatalf("unexpected highlights: %s", diff)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package decoder
 import (
 	"fmt"
 
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl/v2"
 )
 
@@ -61,3 +62,13 @@ type PositionalError struct {
 func (e *PositionalError) Error() string {
 	return fmt.Sprintf("%s (%s): %s", e.Filename, stringPos(e.Pos), e.Msg)
 }
+
+// RenameConflictError indicates that a target cannot be renamed
+// because another target is already declared under the new address.
+type RenameConflictError struct {
+	Address lang.Address
+}
+
+func (e *RenameConflictError) Error() string {
+	return fmt.Sprintf("%s is already declared", e.Address)
+}
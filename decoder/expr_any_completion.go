@@ -87,7 +87,7 @@ func (a Any) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 		}
 
 		cons := schema.Object{
-			Attributes:            ctyObjectToObjectAttributes(typ),
+			Attributes:            schema.ObjectAttributesForType(typ, nil),
 			AllowInterpolatedKeys: true,
 		}
 		return newExpression(a.pathCtx, expr, cons).CompletionAtPos(ctx, pos)
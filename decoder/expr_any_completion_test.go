@@ -283,9 +283,11 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 15},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "foo.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "foo.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2foo.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "foo.bar",
 						Snippet: "foo.bar",
@@ -431,9 +433,11 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 16, Byte: 15},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.lst",
-					Detail: "list of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.lst",
+					Detail:           "list of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.lst",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -489,9 +493,11 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 18, Byte: 17},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.obj",
-					Detail: "list of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.obj",
+					Detail:           "list of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.obj",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -547,9 +553,11 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 22, Byte: 21},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  `var.map["foo"]`,
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            `var.map["foo"]`,
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.map[\"foo\"]",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -561,9 +569,11 @@ func TestCompletionAtPos_exprAny_functions(t *testing.T) {
 					},
 				},
 				{
-					Label:  `var.map`,
-					Detail: "map of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            `var.map`,
+					Detail:           "map of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.map",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
@@ -980,9 +990,11 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 8, Byte: 7},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "bool",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "bool",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -1150,9 +1162,11 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "toot.noot",
-					Detail: "bool",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "toot.noot",
+					Detail:           "bool",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0toot.noot",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "toot.noot",
 						Snippet: "toot.noot",
@@ -1224,6 +1238,134 @@ func TestCompletionAtPos_exprAny_combinedExpressions(t *testing.T) {
 	}
 }
 
+func TestCompletionAtPos_exprAny_functionsUndeclaredReturnType(t *testing.T) {
+	attrSchema := map[string]*schema.AttributeSchema{
+		"attr": {
+			Constraint: schema.AnyExpression{
+				OfType:                  cty.List(cty.String),
+				SkipLiteralComplexTypes: true,
+			},
+		},
+	}
+	functions := map[string]schema.FunctionSignature{
+		"tomap": {
+			Params: []function.Parameter{
+				{
+					Name: "v",
+					Type: cty.DynamicPseudoType,
+				},
+			},
+			ReturnType: cty.Map(cty.DynamicPseudoType),
+		},
+		"tolist": {
+			Params: []function.Parameter{
+				{
+					Name: "v",
+					Type: cty.DynamicPseudoType,
+				},
+			},
+			ReturnType: cty.List(cty.DynamicPseudoType),
+		},
+		"untyped": {
+			Description: "`untyped` has no declared return type.",
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"no prefix",
+			`attr = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       "tolist",
+					Detail:      "tolist(v dynamic) list of dynamic",
+					Description: lang.Markdown(""),
+					Kind:        lang.FunctionCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "tolist()",
+						Snippet: "tolist(${0})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+				{
+					Label:       "untyped",
+					Detail:      "untyped() dynamic",
+					Description: lang.Markdown("`untyped` has no declared return type."),
+					Kind:        lang.FunctionCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "untyped()",
+						Snippet: "untyped(${0})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"prefix",
+			`attr = u
+`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       "untyped",
+					Detail:      "untyped() dynamic",
+					Description: lang.Markdown("`untyped` has no declared return type."),
+					Kind:        lang.FunctionCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "untyped()",
+						Snippet: "untyped(${0})",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						},
+					},
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: &schema.BodySchema{
+					Attributes: attrSchema,
+				},
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Functions: functions,
+			})
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func testFunctionSignatures() map[string]schema.FunctionSignature {
 	return map[string]schema.FunctionSignature{
 		"element": {
@@ -2542,9 +2684,11 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 8, Byte: 7},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -2556,9 +2700,11 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 					},
 				},
 				{
-					Label:  "local.baz",
-					Detail: "number",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.baz",
+					Detail:           "number",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2local.baz",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.baz",
 						Snippet: "local.baz",
@@ -2607,9 +2753,11 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 13, Byte: 12},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.bar",
-					Detail: "number",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.bar",
+					Detail:           "number",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.bar",
 						Snippet: "local.bar",
@@ -2658,9 +2806,11 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 11, Byte: 10},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -2709,9 +2859,11 @@ func TestCompletionAtPos_exprAny_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 13},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -3052,12 +3204,14 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 13},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "number",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
-						NewText: "var.bar",
-						Snippet: "var.bar",
+						NewText: "var.foo",
+						Snippet: "var.foo",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
@@ -3066,12 +3220,14 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 					},
 				},
 				{
-					Label:  "var.foo",
-					Detail: "number",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
-						NewText: "var.foo",
-						Snippet: "var.foo",
+						NewText: "var.bar",
+						Snippet: "var.bar",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
@@ -3243,9 +3399,11 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 13, Byte: 12},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "bool",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "bool",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -3394,9 +3552,11 @@ func TestCompletionAtPos_exprAny_operators(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 15, Byte: 14},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -3628,9 +3788,11 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "number",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "number",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -3713,9 +3875,11 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 13, Byte: 12},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "bool",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "bool",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -3753,9 +3917,11 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 4, Byte: 12},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -3793,9 +3959,11 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 7, Byte: 15},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -3885,9 +4053,11 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 4, Byte: 12},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -3927,9 +4097,11 @@ func TestCompletionAtPos_exprAny_parentheses(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 7, Byte: 15},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4070,9 +4242,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "list of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "list of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4115,9 +4289,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 27, Byte: 26},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -4128,6 +4304,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1v",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "v",
+						Snippet: "v",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4192,9 +4384,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "set of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "set of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4237,9 +4431,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 27, Byte: 26},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -4250,6 +4446,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1v",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "v",
+						Snippet: "v",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4329,9 +4541,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "tuple",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "tuple",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4374,12 +4588,14 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 27, Byte: 26},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0v",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
-						NewText: "var.foo",
-						Snippet: "var.foo",
+						NewText: "v",
+						Snippet: "v",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
@@ -4388,9 +4604,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 					},
 				},
 				{
-					Label:  "var.bar",
-					Detail: "tuple",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "tuple",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -4401,6 +4619,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.foo",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "var.foo",
+						Snippet: "var.foo",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4480,9 +4714,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "map of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "map of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4525,9 +4761,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 27, Byte: 26},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4538,6 +4776,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1v",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "v",
+						Snippet: "v",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4570,9 +4824,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 32, Byte: 31},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4583,6 +4839,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1v",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "v",
+						Snippet: "v",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
+							End:      hcl.Pos{Line: 1, Column: 32, Byte: 31},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4647,9 +4919,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "object",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "object",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4692,9 +4966,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 27, Byte: 26},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.foo",
 						Snippet: "var.foo",
@@ -4705,6 +4981,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1v",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "v",
+						Snippet: "v",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4737,12 +5029,14 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 32, Byte: 31},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "v",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0v",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
-						NewText: "var.foo",
-						Snippet: "var.foo",
+						NewText: "v",
+						Snippet: "v",
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
@@ -4751,9 +5045,11 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 					},
 				},
 				{
-					Label:  "var.bar",
-					Detail: "list of string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "list of string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -4764,6 +5060,22 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 						},
 					},
 				},
+				{
+					Label:            "var.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.foo",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "var.foo",
+						Snippet: "var.foo",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
+							End:      hcl.Pos{Line: 1, Column: 32, Byte: 31},
+						},
+					},
+				},
 			}),
 		},
 		{
@@ -4796,6 +5108,55 @@ func TestCompletionAtPos_exprAny_forExpr(t *testing.T) {
 				},
 			}),
 		},
+
+		// iterator variables
+		{
+			"iterator variables on condition",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.String),
+					},
+				},
+			},
+			reference.Targets{},
+			`attr = [for i, v in var: v if i]
+`,
+			hcl.Pos{Line: 1, Column: 32, Byte: 31},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "i",
+					Detail:           "dynamic",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "1i",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "i",
+						Snippet: "i",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
+							End:      hcl.Pos{Line: 1, Column: 32, Byte: 31},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"no iterator variables on collection",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.String),
+					},
+				},
+			},
+			reference.Targets{},
+			`attr = [for i, v in v: v]
+`,
+			hcl.Pos{Line: 1, Column: 22, Byte: 21},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -4863,9 +5224,11 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 11, Byte: 10},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -4981,9 +5344,11 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 12, Byte: 11},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5024,9 +5389,11 @@ func TestCompletionAtPos_exprAny_template(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 15, Byte: 14},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5070,9 +5437,11 @@ EOT
 			hcl.Pos{Line: 3, Column: 10, Byte: 38},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5249,6 +5618,79 @@ EOT
 			hcl.Pos{Line: 1, Column: 21, Byte: 20},
 			lang.CompleteCandidates([]lang.Candidate{}),
 		},
+		{
+			"literal prefix with trailing dot",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.String,
+				},
+			},
+			`attr = "prefix-${var.}"
+`,
+			hcl.Pos{Line: 1, Column: 22, Byte: 21},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "var.bar",
+						Snippet: "var.bar",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 18, Byte: 17},
+							End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"no completion within literal text",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.String,
+				},
+			},
+			`attr = "prefix-${var.bar}-suffix"
+`,
+			hcl.Pos{Line: 1, Column: 30, Byte: 29},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
 		{
 			"completion within function within expression",
 			map[string]*schema.AttributeSchema{
@@ -5277,9 +5719,11 @@ EOT
 			hcl.Pos{Line: 1, Column: 28, Byte: 27},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5395,9 +5839,11 @@ EOT
 			hcl.Pos{Line: 1, Column: 21, Byte: 20},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5438,9 +5884,11 @@ EOT
 			hcl.Pos{Line: 1, Column: 20, Byte: 19},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5481,9 +5929,11 @@ EOT
 			hcl.Pos{Line: 1, Column: 23, Byte: 22},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5599,9 +6049,11 @@ EOT
 			hcl.Pos{Line: 1, Column: 23, Byte: 22},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5720,9 +6172,11 @@ EOT
 			hcl.Pos{Line: 1, Column: 23, Byte: 22},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -5901,7 +6355,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.foo",
 						Snippet: "var.foo",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -5955,7 +6411,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.bar",
 						Snippet: "var.bar",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 				},
 				{
 					Label:  "var.foo",
@@ -5969,7 +6427,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.foo",
 						Snippet: "var.foo",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.foo",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -6023,7 +6483,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.bar",
 						Snippet: "var.bar",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 				},
 				{
 					Label:  "var.foo",
@@ -6037,7 +6499,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.foo",
 						Snippet: "var.foo",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.foo",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -6091,7 +6555,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.bar",
 						Snippet: "var.bar",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 				},
 				{
 					Label:  "var.foo",
@@ -6105,7 +6571,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.foo",
 						Snippet: "var.foo",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.foo",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -6159,7 +6627,9 @@ func TestCompletionAtPos_exprAny_conditional(t *testing.T) {
 						NewText: "var.foo",
 						Snippet: "var.foo",
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0var.foo",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -27,6 +27,10 @@ func (a Any) completeForExprAtPos(ctx context.Context, pos hcl.Pos) ([]lang.Cand
 			return newExpression(a.pathCtx, eType.CollExpr, a.cons).CompletionAtPos(ctx, pos), true
 		}
 
+		// iterator variables are only in scope within the result
+		// and condition expressions, not within the collection
+		pathCtx := pathCtxWithForIterators(a.pathCtx, eType)
+
 		if eType.KeyExpr != nil && (eType.KeyExpr.Range().ContainsPos(pos) || eType.KeyExpr.Range().End.Byte == pos.Byte) {
 			typ, ok := iterableKeyType(a.cons.OfType)
 			if !ok {
@@ -36,7 +40,7 @@ func (a Any) completeForExprAtPos(ctx context.Context, pos hcl.Pos) ([]lang.Cand
 				OfType: typ,
 			}
 
-			return newExpression(a.pathCtx, eType.KeyExpr, cons).CompletionAtPos(ctx, pos), true
+			return newExpression(pathCtx, eType.KeyExpr, cons).CompletionAtPos(ctx, pos), true
 		}
 
 		if eType.ValExpr.Range().ContainsPos(pos) || eType.ValExpr.Range().End.Byte == pos.Byte {
@@ -48,14 +52,14 @@ func (a Any) completeForExprAtPos(ctx context.Context, pos hcl.Pos) ([]lang.Cand
 				OfType: typ,
 			}
 
-			return newExpression(a.pathCtx, eType.ValExpr, cons).CompletionAtPos(ctx, pos), true
+			return newExpression(pathCtx, eType.ValExpr, cons).CompletionAtPos(ctx, pos), true
 		}
 
 		if eType.CondExpr != nil && (eType.CondExpr.Range().ContainsPos(pos) || eType.CondExpr.Range().End.Byte == pos.Byte) {
 			cons := schema.AnyExpression{
 				OfType: cty.Bool,
 			}
-			return newExpression(a.pathCtx, eType.CondExpr, cons).CompletionAtPos(ctx, pos), true
+			return newExpression(pathCtx, eType.CondExpr, cons).CompletionAtPos(ctx, pos), true
 		}
 		return candidates, false
 	}
@@ -70,9 +74,9 @@ func (a Any) hoverForExprAtPos(ctx context.Context, pos hcl.Pos) (*lang.HoverDat
 			return nil, false
 		}
 
-		// TODO: eType.KeyVarExpr.Range() to display key type
-
-		// TODO: eType.ValVarExpr.Range() to display value type
+		if hoverData, ok := a.hoverForIteratorAtPos(ctx, eType, pos); ok {
+			return hoverData, true
+		}
 
 		if eType.CollExpr.Range().ContainsPos(pos) {
 			return newExpression(a.pathCtx, eType.CollExpr, a.cons).HoverAtPos(ctx, pos), true
@@ -111,6 +115,41 @@ func (a Any) hoverForExprAtPos(ctx context.Context, pos hcl.Pos) (*lang.HoverDat
 	return nil, false
 }
 
+// hoverForIteratorAtPos returns hover data for the key or value
+// iterator variable at the given position, including its type
+// inferred from the collection.
+func (a Any) hoverForIteratorAtPos(ctx context.Context, forExpr *hclsyntax.ForExpr, pos hcl.Pos) (*lang.HoverData, bool) {
+	if a.pathCtx == nil {
+		return nil, false
+	}
+	file, ok := a.pathCtx.Files[forExpr.Range().Filename]
+	if !ok {
+		return nil, false
+	}
+
+	name, rng, ok := forIteratorAtPos(file.Bytes, forExpr, pos)
+	if !ok {
+		return nil, false
+	}
+
+	for _, target := range forIteratorTargets(forExpr, forCollectionType(a.pathCtx, forExpr)) {
+		if target.LocalAddr[0].String() != name {
+			continue
+		}
+		content, err := hoverContentForReferenceTarget(ctx, target, pos)
+		if err != nil {
+			return nil, false
+		}
+		return &lang.HoverData{
+			Content: lang.Markdown(content),
+			Range:   rng,
+			Kind:    lang.ReferenceHoverKind,
+		}, true
+	}
+
+	return nil, false
+}
+
 func (a Any) semanticTokensForForExpr(ctx context.Context) ([]lang.SemanticToken, bool) {
 	tokens := make([]lang.SemanticToken, 0)
 
@@ -120,8 +159,20 @@ func (a Any) semanticTokensForForExpr(ctx context.Context) ([]lang.SemanticToken
 			return nil, false
 		}
 
-		// TODO: eType.KeyVarExpr.Range() to report key as keyword
-		// TODO: eType.ValVarExpr.Range() to display value as keyword
+		if a.pathCtx != nil {
+			if file, ok := a.pathCtx.Files[eType.Range().Filename]; ok {
+				for _, token := range forIteratorDeclarations(file.Bytes, eType) {
+					tokens = append(tokens, lang.SemanticToken{
+						Type:      lang.TokenReferenceStep,
+						Modifiers: []lang.SemanticTokenModifier{},
+						Range:     token.Range,
+					})
+				}
+			}
+		}
+		for _, traversal := range forIteratorTraversals(eType) {
+			tokens = append(tokens, semanticTokensForTraversal(traversal.Traversal)...)
+		}
 
 		tokens = append(tokens, newExpression(a.pathCtx, eType.CollExpr, a.cons).SemanticTokens(ctx)...)
 
@@ -227,6 +278,209 @@ func (a Any) refOriginsForForExpr(ctx context.Context, allowSelfRefs bool) (refe
 	return origins, false
 }
 
+// pathCtxWithForIterators returns a copy of the given path context
+// with iterator variables of the for expression registered
+// as reference targets, scoped to the for expression itself.
+func pathCtxWithForIterators(pathCtx *PathContext, forExpr *hclsyntax.ForExpr) *PathContext {
+	if pathCtx == nil {
+		return nil
+	}
+
+	collType := forCollectionType(pathCtx, forExpr)
+
+	newCtx := *pathCtx
+	newCtx.ReferenceTargets = append(forIteratorTargets(forExpr, collType), pathCtx.ReferenceTargets...)
+
+	return &newCtx
+}
+
+// forIteratorTargets returns reference targets representing
+// the key (if declared) and value iterator variables
+// of the given for expression, typed per the collection type.
+func forIteratorTargets(forExpr *hclsyntax.ForExpr, collType cty.Type) reference.Targets {
+	targets := make(reference.Targets, 0)
+
+	if forExpr.KeyVar != "" {
+		keyType, ok := iterableKeyType(collType)
+		if !ok {
+			keyType = cty.DynamicPseudoType
+		}
+		targets = append(targets, reference.Target{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: forExpr.KeyVar},
+			},
+			TargetableFromRangePtr: forExpr.Range().Ptr(),
+			Type:                   keyType,
+		})
+	}
+
+	valType, ok := iterableValueType(collType)
+	if !ok {
+		valType = cty.DynamicPseudoType
+	}
+	targets = append(targets, reference.Target{
+		LocalAddr: lang.Address{
+			lang.RootStep{Name: forExpr.ValVar},
+		},
+		TargetableFromRangePtr: forExpr.Range().Ptr(),
+		Type:                   valType,
+	})
+
+	return targets
+}
+
+// forCollectionType returns type of the collection the given
+// for expression iterates over, which is either type of the
+// referenced target or type of a literal collection.
+//
+// cty.DynamicPseudoType is returned if the type cannot be resolved.
+func forCollectionType(pathCtx *PathContext, forExpr *hclsyntax.ForExpr) cty.Type {
+	switch collExpr := forExpr.CollExpr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		if pathCtx == nil {
+			return cty.DynamicPseudoType
+		}
+		addr, err := lang.TraversalToAddress(collExpr.Traversal)
+		if err != nil {
+			return cty.DynamicPseudoType
+		}
+		target, ok := pathCtx.ReferenceTargets.ResolveAddress(addr, false)
+		if !ok || target.Type == cty.NilType {
+			return cty.DynamicPseudoType
+		}
+		return target.Type
+	default:
+		if len(collExpr.Variables()) > 0 {
+			return cty.DynamicPseudoType
+		}
+		val, diags := collExpr.Value(nil)
+		if diags.HasErrors() {
+			return cty.DynamicPseudoType
+		}
+		return val.Type()
+	}
+}
+
+// forIteratorAtPos returns name and range of the iterator variable
+// of the given for expression at the given position, i.e. either
+// its declaration or its use within the result or condition expressions.
+func forIteratorAtPos(src []byte, forExpr *hclsyntax.ForExpr, pos hcl.Pos) (string, hcl.Range, bool) {
+	for _, token := range forIteratorDeclarations(src, forExpr) {
+		if token.Range.ContainsPos(pos) {
+			return string(token.Bytes), token.Range, true
+		}
+	}
+
+	for _, traversal := range forIteratorTraversals(forExpr) {
+		rootRng := traversal.Traversal[0].SourceRange()
+		if rootRng.ContainsPos(pos) {
+			return traversal.Traversal.RootName(), rootRng, true
+		}
+	}
+
+	return "", hcl.Range{}, false
+}
+
+// forIteratorDeclarations returns identifier tokens declaring
+// the iterator variables in the header of the given for expression,
+// i.e. between "for" and "in".
+func forIteratorDeclarations(src []byte, forExpr *hclsyntax.ForExpr) hclsyntax.Tokens {
+	declarations := make(hclsyntax.Tokens, 0)
+
+	headerRng := hcl.Range{
+		Filename: forExpr.Range().Filename,
+		Start:    forExpr.OpenRange.End,
+		End:      forExpr.CollExpr.Range().Start,
+	}
+	if headerRng.End.Byte > len(src) || headerRng.Start.Byte > headerRng.End.Byte {
+		return declarations
+	}
+
+	tokens, _ := hclsyntax.LexExpression(headerRng.SliceBytes(src), headerRng.Filename, headerRng.Start)
+	for _, token := range tokens {
+		if token.Type != hclsyntax.TokenIdent {
+			continue
+		}
+		name := string(token.Bytes)
+		if name == "in" {
+			break
+		}
+		if name == forExpr.KeyVar || name == forExpr.ValVar {
+			declarations = append(declarations, token)
+		}
+	}
+
+	return declarations
+}
+
+// forIteratorTraversals returns traversals referring to iterator
+// variables of the given for expression within its result and
+// condition expressions, except for those shadowed by nested
+// for expressions declaring variables of the same name.
+func forIteratorTraversals(forExpr *hclsyntax.ForExpr) []*hclsyntax.ScopeTraversalExpr {
+	type iteratorScope struct {
+		name string
+		rng  hcl.Range
+	}
+
+	isIterator := func(name string) bool {
+		return name != "" && (name == forExpr.KeyVar || name == forExpr.ValVar)
+	}
+
+	candidates := make([]*hclsyntax.ScopeTraversalExpr, 0)
+	shadowingScopes := make([]iteratorScope, 0)
+	for _, expr := range forBodyExpressions(forExpr) {
+		hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+			switch nodeType := node.(type) {
+			case *hclsyntax.ForExpr:
+				for _, bodyExpr := range forBodyExpressions(nodeType) {
+					for _, name := range []string{nodeType.KeyVar, nodeType.ValVar} {
+						if isIterator(name) {
+							shadowingScopes = append(shadowingScopes, iteratorScope{name, bodyExpr.Range()})
+						}
+					}
+				}
+			case *hclsyntax.ScopeTraversalExpr:
+				if isIterator(nodeType.Traversal.RootName()) {
+					candidates = append(candidates, nodeType)
+				}
+			}
+			return nil
+		})
+	}
+
+	traversals := make([]*hclsyntax.ScopeTraversalExpr, 0)
+	for _, traversal := range candidates {
+		isShadowed := false
+		for _, scope := range shadowingScopes {
+			if scope.name == traversal.Traversal.RootName() && scope.rng.ContainsPos(traversal.Range().Start) {
+				isShadowed = true
+				break
+			}
+		}
+		if !isShadowed {
+			traversals = append(traversals, traversal)
+		}
+	}
+
+	return traversals
+}
+
+// forBodyExpressions returns the result (key and value)
+// and condition expressions of the given for expression,
+// i.e. those where the iterator variables are in scope.
+func forBodyExpressions(forExpr *hclsyntax.ForExpr) []hclsyntax.Expression {
+	exprs := make([]hclsyntax.Expression, 0)
+	if forExpr.KeyExpr != nil {
+		exprs = append(exprs, forExpr.KeyExpr)
+	}
+	exprs = append(exprs, forExpr.ValExpr)
+	if forExpr.CondExpr != nil {
+		exprs = append(exprs, forExpr.CondExpr)
+	}
+	return exprs
+}
+
 func isTypeIterable(typ cty.Type) bool {
 	if typ == cty.DynamicPseudoType {
 		return true
@@ -86,7 +86,7 @@ func (a Any) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 		}
 
 		cons := schema.Object{
-			Attributes:            ctyObjectToObjectAttributes(typ),
+			Attributes:            schema.ObjectAttributesForType(typ, nil),
 			AllowInterpolatedKeys: true,
 		}
 		return newExpression(a.pathCtx, expr, cons).HoverAtPos(ctx, pos)
@@ -61,8 +61,73 @@ func TestHoverAtPos_exprAny_functions(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
 				},
+				Kind: lang.FunctionHoverKind,
 			},
 		},
+		{
+			"over closing parenthesis",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			`attr = lower("FOO")
+`,
+			hcl.Pos{Line: 1, Column: 19, Byte: 18},
+			&lang.HoverData{
+				Content: lang.MarkupContent{
+					Value: "```terraform\nlower(str string) string\n```\n\n`lower` converts all cased letters in the given string to lowercase.",
+					Kind:  lang.MarkdownKind,
+				},
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+				},
+				Kind: lang.FunctionHoverKind,
+			},
+		},
+		{
+			"over closing parenthesis of variadic function",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			`attr = join(",", ["a"], ["b"])
+`,
+			hcl.Pos{Line: 1, Column: 30, Byte: 29},
+			&lang.HoverData{
+				Content: lang.MarkupContent{
+					Value: "```terraform\njoin(separator string, …lists list of string) string\n```\n\n`join` produces a string by concatenating together all elements of a given list of strings with the given delimiter.",
+					Kind:  lang.MarkdownKind,
+				},
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 31, Byte: 30},
+				},
+				Kind: lang.FunctionHoverKind,
+			},
+		},
+		{
+			"over closing parenthesis of unknown function",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.String,
+					},
+				},
+			},
+			`attr = unknown("FOO")
+`,
+			hcl.Pos{Line: 1, Column: 21, Byte: 20},
+			nil,
+		},
 		{
 			"over function parameter",
 			map[string]*schema.AttributeSchema{
@@ -85,6 +150,7 @@ func TestHoverAtPos_exprAny_functions(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -109,6 +175,7 @@ func TestHoverAtPos_exprAny_functions(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 19, Byte: 18},
 					End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -197,6 +264,7 @@ func TestHoverAtPos_exprAny_literalTypes(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -219,6 +287,7 @@ func TestHoverAtPos_exprAny_literalTypes(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -239,6 +308,7 @@ func TestHoverAtPos_exprAny_literalTypes(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -259,6 +329,7 @@ func TestHoverAtPos_exprAny_literalTypes(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -279,6 +350,7 @@ func TestHoverAtPos_exprAny_literalTypes(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -303,6 +375,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 5, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -323,6 +396,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -345,6 +419,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -365,6 +440,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -387,6 +463,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -407,6 +484,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -435,6 +513,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -457,6 +536,7 @@ TEXT
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 11, Byte: 19},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -496,6 +576,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
 					End:      hcl.Pos{Line: 3, Column: 8, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -518,6 +599,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -538,6 +620,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -560,6 +643,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -580,6 +664,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -608,6 +693,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -647,6 +733,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
 					End:      hcl.Pos{Line: 3, Column: 8, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -669,6 +756,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -691,6 +779,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -714,6 +803,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -738,6 +828,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -761,6 +852,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -792,6 +884,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -837,6 +930,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 23},
 					End:      hcl.Pos{Line: 3, Column: 11, Byte: 31},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -859,6 +953,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -881,6 +976,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -901,6 +997,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -972,6 +1069,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 17, Byte: 41},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1011,6 +1109,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1033,6 +1132,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1055,6 +1155,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1079,6 +1180,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1103,6 +1205,7 @@ TEXT
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 16, Byte: 24},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -1131,6 +1234,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1159,6 +1263,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 25},
 					End:      hcl.Pos{Line: 3, Column: 14, Byte: 36},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -1187,6 +1292,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 17, Byte: 41},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1241,6 +1347,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 2, Byte: 38},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1280,6 +1387,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 2, Byte: 35},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -1454,6 +1562,7 @@ foo = "noot"
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 	}
@@ -1513,6 +1622,7 @@ func TestHoverAtPos_exprAny_operators(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1534,6 +1644,7 @@ func TestHoverAtPos_exprAny_operators(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
 					End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1569,6 +1680,7 @@ func TestHoverAtPos_exprAny_operators(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1644,6 +1756,7 @@ func TestHoverAtPos_exprAny_parenthesis(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1667,6 +1780,7 @@ func TestHoverAtPos_exprAny_parenthesis(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1731,6 +1845,7 @@ func TestHoverAtPos_exprAny_parenthesis(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 4, Byte: 12},
 					End:      hcl.Pos{Line: 2, Column: 11, Byte: 19},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -1781,6 +1896,7 @@ func TestHoverAtPos_exprAny_parenthesis(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 4, Byte: 12},
 					End:      hcl.Pos{Line: 2, Column: 11, Byte: 19},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 	}
@@ -1870,6 +1986,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 18, Byte: 17},
 					End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -1893,6 +2010,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
 					End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1916,6 +2034,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 32, Byte: 31},
 					End:      hcl.Pos{Line: 1, Column: 36, Byte: 35},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -1966,6 +2085,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 18, Byte: 17},
 					End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -1989,6 +2109,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
 					End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2012,6 +2133,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 32, Byte: 31},
 					End:      hcl.Pos{Line: 1, Column: 36, Byte: 35},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -2062,6 +2184,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 18, Byte: 17},
 					End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -2085,6 +2208,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 23, Byte: 22},
 					End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2108,6 +2232,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 32, Byte: 31},
 					End:      hcl.Pos{Line: 1, Column: 36, Byte: 35},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -2158,6 +2283,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 21, Byte: 20},
 					End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -2181,6 +2307,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 30, Byte: 29},
 					End:      hcl.Pos{Line: 1, Column: 35, Byte: 34},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2204,6 +2331,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 39, Byte: 38},
 					End:      hcl.Pos{Line: 1, Column: 44, Byte: 43},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2227,6 +2355,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 48, Byte: 47},
 					End:      hcl.Pos{Line: 1, Column: 52, Byte: 51},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -2277,6 +2406,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 21, Byte: 20},
 					End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -2300,6 +2430,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 30, Byte: 29},
 					End:      hcl.Pos{Line: 1, Column: 35, Byte: 34},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2323,6 +2454,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 39, Byte: 38},
 					End:      hcl.Pos{Line: 1, Column: 44, Byte: 43},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2346,6 +2478,7 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 48, Byte: 47},
 					End:      hcl.Pos{Line: 1, Column: 52, Byte: 51},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -2378,6 +2511,229 @@ func TestHoverAtPos_exprAny_forExpr(t *testing.T) {
 	}
 }
 
+func TestHoverAtPos_exprAny_forExprIterators(t *testing.T) {
+	testCases := []struct {
+		testName          string
+		attrSchema        map[string]*schema.AttributeSchema
+		refTargets        reference.Targets
+		cfg               string
+		pos               hcl.Pos
+		expectedHoverData *lang.HoverData
+	}{
+		{
+			"value declaration",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.String),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.List(cty.String),
+				},
+			},
+			`attr = [for v in var.foo: v]
+`,
+			hcl.Pos{Line: 1, Column: 13, Byte: 12},
+			&lang.HoverData{
+				Content: lang.Markdown("`v`\n_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"value usage",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.String),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.List(cty.String),
+				},
+			},
+			`attr = [for v in var.foo: v]
+`,
+			hcl.Pos{Line: 1, Column: 27, Byte: 26},
+			&lang.HoverData{
+				Content: lang.Markdown("`v`\n_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 27, Byte: 26},
+					End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"key declaration of map",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.Map(cty.Number),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.Map(cty.Number),
+				},
+			},
+			`attr = {for k, v in var.foo: k => v}
+`,
+			hcl.Pos{Line: 1, Column: 13, Byte: 12},
+			&lang.HoverData{
+				Content: lang.Markdown("`k`\n_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"value usage of map",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.Map(cty.Number),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.Map(cty.Number),
+				},
+			},
+			`attr = {for k, v in var.foo: k => v}
+`,
+			hcl.Pos{Line: 1, Column: 35, Byte: 34},
+			&lang.HoverData{
+				Content: lang.Markdown("`v`\n_number_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 35, Byte: 34},
+					End:      hcl.Pos{Line: 1, Column: 36, Byte: 35},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"key of unknown collection",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.String),
+					},
+				},
+			},
+			reference.Targets{},
+			`attr = [for k, v in local.foo: v]
+`,
+			hcl.Pos{Line: 1, Column: 13, Byte: 12},
+			&lang.HoverData{
+				Content: lang.Markdown("`k`\n_dynamic_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"shadowed value usage",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.List(cty.List(cty.String)),
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.List(cty.Number),
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "bar"},
+					},
+					Type: cty.List(cty.Bool),
+				},
+			},
+			`attr = [for v in var.foo: [for v in var.bar: v]]
+`,
+			hcl.Pos{Line: 1, Column: 46, Byte: 45},
+			&lang.HoverData{
+				Content: lang.Markdown("`v`\n_bool_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 46, Byte: 45},
+					End:      hcl.Pos{Line: 1, Column: 47, Byte: 46},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: tc.attrSchema,
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema:           bodySchema,
+				ReferenceTargets: tc.refTargets,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			ctx := context.Background()
+			hoverData, err := d.HoverAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedHoverData, hoverData); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+		})
+	}
+}
+
 func TestHoverAtPos_exprAny_templates(t *testing.T) {
 	testCases := []struct {
 		testName          string
@@ -2409,6 +2765,7 @@ func TestHoverAtPos_exprAny_templates(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
 					End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2462,6 +2819,7 @@ func TestHoverAtPos_exprAny_templates(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
 					End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -2519,6 +2877,7 @@ EOT
 					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 13},
 					End:      hcl.Pos{Line: 3, Column: 1, Byte: 17},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -2583,6 +2942,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2606,6 +2966,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
 					End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2629,6 +2990,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 22, Byte: 21},
 					End:      hcl.Pos{Line: 1, Column: 26, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2652,6 +3014,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 12, Byte: 11},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2675,6 +3038,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
 					End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2698,6 +3062,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 20, Byte: 19},
 					End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -2721,6 +3086,7 @@ func TestHoverAtPos_exprAny_conditional(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
 					End:      hcl.Pos{Line: 1, Column: 35, Byte: 34},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
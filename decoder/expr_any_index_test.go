@@ -110,35 +110,41 @@ func TestCompletionAtPos_exprAny_index(t *testing.T) {
 						NewText: `aws_instance.name.tags["name"]`,
 						Snippet: `aws_instance.name.tags["name"]`,
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0aws_instance.name.tags[\"name\"]",
+					CommitCharacters: []string{"."},
 				},
 				{
-					Label:  `aws_instance.name`,
-					Detail: "object",
+					Label:  `local.name`,
+					Detail: "string",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
 							End:      hcl.Pos{Line: 1, Column: 31, Byte: 30},
 						},
-						NewText: `aws_instance.name`,
-						Snippet: `aws_instance.name`,
+						NewText: `local.name`,
+						Snippet: `local.name`,
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.name",
+					CommitCharacters: []string{"."},
 				},
 				{
-					Label:  `local.name`,
-					Detail: "string",
+					Label:  `aws_instance.name`,
+					Detail: "object",
 					TextEdit: lang.TextEdit{
 						Range: hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
 							End:      hcl.Pos{Line: 1, Column: 31, Byte: 30},
 						},
-						NewText: `local.name`,
-						Snippet: `local.name`,
+						NewText: `aws_instance.name`,
+						Snippet: `aws_instance.name`,
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2aws_instance.name",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -226,7 +232,9 @@ func TestCompletionAtPos_exprAny_index(t *testing.T) {
 						NewText: `local.name`,
 						Snippet: `local.name`,
 					},
-					Kind: lang.ReferenceCandidateKind,
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.name",
+					CommitCharacters: []string{"."},
 				},
 			}),
 		},
@@ -338,6 +346,7 @@ func TestHoverAtPos_exprAny_index(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 31, Byte: 30},
 					End:      hcl.Pos{Line: 1, Column: 41, Byte: 40},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 	}
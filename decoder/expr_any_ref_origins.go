@@ -63,8 +63,8 @@ func (a Any) ReferenceOrigins(ctx context.Context, allowSelfRefs bool) reference
 			Elems: make([]schema.Constraint, len(elemTypes)),
 		}
 		for i, elemType := range elemTypes {
-			cons.Elems[i] = schema.LiteralType{
-				Type: elemType,
+			cons.Elems[i] = schema.AnyExpression{
+				OfType: elemType,
 			}
 		}
 
@@ -105,7 +105,7 @@ func (a Any) ReferenceOrigins(ctx context.Context, allowSelfRefs bool) reference
 			expr:    a.expr,
 			pathCtx: a.pathCtx,
 			cons: schema.Object{
-				Attributes:            ctyObjectToObjectAttributes(typ),
+				Attributes:            schema.ObjectAttributesForType(typ, nil),
 				AllowInterpolatedKeys: true,
 			},
 		}
@@ -108,7 +108,7 @@ func (a Any) ReferenceTargets(ctx context.Context, targetCtx *TargetContext) ref
 	if typ.IsObjectType() {
 		obj := Object{
 			cons: schema.Object{
-				Attributes: ctyObjectToObjectAttributes(typ),
+				Attributes: schema.ObjectAttributesForType(typ, nil),
 			},
 			expr:    a.expr,
 			pathCtx: a.pathCtx,
@@ -29,6 +29,11 @@ func (a Any) completeTemplateExprAtPos(ctx context.Context, pos hcl.Pos) ([]lang
 				break
 			}
 
+			// Plain literal text between interpolations offers no candidates
+			if isTemplateLiteralText(partExpr) {
+				continue
+			}
+
 			// We're not checking the end byte position here, because we don't
 			// allow completion after the }
 			if partExpr.Range().ContainsPos(pos) || partExpr.Range().End.Byte == pos.Byte {
@@ -185,3 +190,10 @@ func (a Any) semanticTokensForTemplateExpr(ctx context.Context) ([]lang.Semantic
 
 	return tokens, false
 }
+
+// isTemplateLiteralText reports whether the given template part
+// represents plain literal text, as opposed to an interpolation.
+func isTemplateLiteralText(partExpr hclsyntax.Expression) bool {
+	litExpr, ok := partExpr.(*hclsyntax.LiteralValueExpr)
+	return ok && litExpr.Val.Type() == cty.String
+}
@@ -34,7 +34,7 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 			End:      pos,
 		}
 
-		return fe.matchingFunctions("", editRange)
+		return fe.matchingFunctions(ctx, "", editRange)
 	}
 
 	switch eType := fe.expr.(type) {
@@ -54,16 +54,16 @@ func (fe functionExpr) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.
 		}
 
 		prefix := rootName[0:prefixLen]
-		return fe.matchingFunctions(prefix, eType.Range())
+		return fe.matchingFunctions(ctx, prefix, eType.Range())
 	case *hclsyntax.FunctionCallExpr:
 		if eType.NameRange.ContainsPos(pos) {
 			prefixLen := pos.Byte - eType.NameRange.Start.Byte
 			prefix := eType.Name[0:prefixLen]
 			editRange := eType.Range()
-			return fe.matchingFunctions(prefix, editRange)
+			return fe.matchingFunctions(ctx, prefix, editRange)
 		}
 
-		f, ok := fe.pathCtx.Functions[eType.Name]
+		f, ok := fe.pathCtx.functionSignature(eType.Name)
 		if !ok {
 			return []lang.Candidate{} // Unknown function
 		}
@@ -144,16 +144,18 @@ func (fe functionExpr) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverD
 		return nil
 	}
 
-	funcSig, ok := fe.pathCtx.Functions[funcExpr.Name]
+	funcSig, ok := fe.pathCtx.functionSignature(funcExpr.Name)
 	if !ok {
 		return nil
 	}
 
-	if funcExpr.NameRange.ContainsPos(pos) {
+	// hovering the name or closing parenthesis describes the whole call
+	if funcExpr.NameRange.ContainsPos(pos) || funcExpr.CloseParenRange.ContainsPos(pos) {
 		return &lang.HoverData{
 			Content: lang.Markdown(fmt.Sprintf("```terraform\n%s(%s) %s\n```\n\n%s",
 				funcExpr.Name, parameterNamesAsString(funcSig), funcSig.ReturnType.FriendlyName(), funcSig.Description)),
 			Range: fe.expr.Range(),
+			Kind:  lang.FunctionHoverKind,
 		}
 	}
 
@@ -188,7 +190,7 @@ func (fe functionExpr) SemanticTokens(ctx context.Context) []lang.SemanticToken
 	if !ok {
 		return []lang.SemanticToken{}
 	}
-	funcSig, ok := fe.pathCtx.Functions[funcExpr.Name]
+	funcSig, ok := fe.pathCtx.functionSignature(funcExpr.Name)
 	if !ok {
 		return []lang.SemanticToken{}
 	}
@@ -231,7 +233,7 @@ func (fe functionExpr) ReferenceOrigins(ctx context.Context, allowSelfRefs bool)
 		return reference.Origins{}
 	}
 
-	funcSig, ok := fe.pathCtx.Functions[funcExpr.Name]
+	funcSig, ok := fe.pathCtx.functionSignature(funcExpr.Name)
 	if !ok {
 		return nil
 	}
@@ -266,10 +268,15 @@ func (fe functionExpr) ReferenceOrigins(ctx context.Context, allowSelfRefs bool)
 	return origins
 }
 
-func (fe functionExpr) matchingFunctions(prefix string, editRange hcl.Range) []lang.Candidate {
+func (fe functionExpr) matchingFunctions(ctx context.Context, prefix string, editRange hcl.Range) []lang.Candidate {
 	candidates := make([]lang.Candidate, 0)
 
-	for name, f := range fe.pathCtx.Functions {
+	signatures, ok := schema.FunctionSignaturesFromContext(ctx)
+	if !ok {
+		signatures = fe.pathCtx.functionSignatures()
+	}
+
+	for name, f := range signatures {
 		if !strings.HasPrefix(name, prefix) {
 			continue
 		}
@@ -9,6 +9,7 @@ import (
 )
 
 type Keyword struct {
-	expr hcl.Expression
-	cons schema.Keyword
+	expr    hcl.Expression
+	cons    schema.Keyword
+	pathCtx *PathContext
 }
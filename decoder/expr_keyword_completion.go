@@ -42,14 +42,15 @@ func (kw Keyword) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		return []lang.Candidate{}
 	}
 
-	prefixLen := pos.Byte - eType.Traversal.SourceRange().Start.Byte
-	if prefixLen < 0 || prefixLen > len(eType.Traversal.RootName()) {
-		// The user has probably typed an extra character, such as a
-		// period, that is not (yet) part of the expression. This prefix
-		// won't match anything, so we'll return early.
+	if pos.Byte < eType.Range().Start.Byte {
+		// position is in front of the keyword
+		return []lang.Candidate{}
+	}
+
+	prefix, editRange, ok := kw.prefixAtPos(eType, pos)
+	if !ok {
 		return []lang.Candidate{}
 	}
-	prefix := eType.Traversal.RootName()[0:prefixLen]
 
 	if strings.HasPrefix(kw.cons.Keyword, prefix) {
 		return []lang.Candidate{
@@ -61,7 +62,7 @@ func (kw Keyword) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 				TextEdit: lang.TextEdit{
 					NewText: kw.cons.Keyword,
 					Snippet: kw.cons.Keyword,
-					Range:   eType.Range(),
+					Range:   editRange,
 				},
 			},
 		}
@@ -69,3 +70,24 @@ func (kw Keyword) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 
 	return []lang.Candidate{}
 }
+
+// prefixAtPos returns the (partially typed) keyword up to the given
+// position, along with the range to be replaced by the keyword.
+func (kw Keyword) prefixAtPos(eType *hclsyntax.ScopeTraversalExpr, pos hcl.Pos) (string, hcl.Range, bool) {
+	if kw.pathCtx != nil {
+		if file, ok := kw.pathCtx.Files[eType.Range().Filename]; ok {
+			prefix, editRange := tokenRangeAtPos(file.Bytes, eType.Range().Filename, pos)
+			return prefix, editRange, true
+		}
+	}
+
+	// fall back to the AST if the source is not available
+	prefixLen := pos.Byte - eType.Traversal.SourceRange().Start.Byte
+	if prefixLen > len(eType.Traversal.RootName()) {
+		// The user has probably typed an extra character, such as a
+		// period, that is not (yet) part of the expression. This prefix
+		// won't match anything, so we'll return early.
+		return "", hcl.Range{}, false
+	}
+	return eType.Traversal.RootName()[0:prefixLen], eType.Range(), true
+}
@@ -31,6 +31,7 @@ func (kw Keyword) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 		return &lang.HoverData{
 			Content: lang.Markdown(content),
 			Range:   eType.SrcRange,
+			Kind:    lang.ValueHoverKind,
 		}
 	}
 
@@ -67,6 +67,7 @@ func TestHoverAtPos_exprKeyword(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -89,6 +90,7 @@ func TestHoverAtPos_exprKeyword(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
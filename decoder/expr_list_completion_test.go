@@ -322,7 +322,7 @@ func TestCompletionAtPos_exprList(t *testing.T) {
 			},
 			`attr = [ key ]
 `,
-			hcl.Pos{Line: 1, Column: 8, Byte: 9},
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
 					Label:  `keyword`,
@@ -659,6 +659,138 @@ func TestCompletionAtPos_exprList(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"inside single-line directly after trailing comma",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.List{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [ true,  ]
+`,
+			hcl.Pos{Line: 1, Column: 15, Byte: 14},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
+							End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
+							End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"inside single-line after trailing comma without space",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.List{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [true,]
+`,
+			hcl.Pos{Line: 1, Column: 14, Byte: 13},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+							End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+							End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"inside multi-line after trailing comma with whitespace only",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.List{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [
+  true,
+  
+]
+`,
+			hcl.Pos{Line: 3, Column: 3, Byte: 19},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 19},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 19},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 19},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 19},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -726,9 +858,11 @@ func TestCompletionAtPos_exprList_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 13},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "reference",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "reference",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -741,6 +875,71 @@ func TestCompletionAtPos_exprList_references(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"single-line with trailing dot after typed reference",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.List{
+						Elem: schema.Reference{OfType: cty.String},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "foo"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.Object(map[string]cty.Type{
+						"id":   cty.String,
+						"ami":  cty.String,
+						"tags": cty.Map(cty.String),
+					}),
+				},
+			},
+			`attr = [ aws_instance.foo. ]
+`,
+			hcl.Pos{Line: 1, Column: 27, Byte: 26},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "aws_instance.foo.ami",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0aws_instance.foo.ami",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "aws_instance.foo.ami",
+						Snippet: "aws_instance.foo.ami",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
+				{
+					Label:            "aws_instance.foo.id",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0aws_instance.foo.id",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "aws_instance.foo.id",
+						Snippet: "aws_instance.foo.id",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -771,3 +970,175 @@ func TestCompletionAtPos_exprList_references(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprList_nested(t *testing.T) {
+	listOfMap := map[string]*schema.AttributeSchema{
+		"attr": {
+			Constraint: schema.List{
+				Elem: schema.Map{
+					Elem: schema.LiteralType{Type: cty.Bool},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		attrSchema         map[string]*schema.AttributeSchema
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"list of map new element",
+			listOfMap,
+			`attr = [  ]
+`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `{ "key" = bool }`,
+					Detail: "map of bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						},
+						NewText: "{\n  \"name\" = false\n}",
+						Snippet: "{\n  \"${1:name}\" = ${2:false}\n}",
+					},
+					Kind: lang.MapCandidateKind,
+				},
+			}),
+		},
+		{
+			"list of map new key",
+			listOfMap,
+			`attr = [ {  } ]
+`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `"key" = bool`,
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 12, Byte: 11},
+							End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						},
+						NewText: `"key" = false`,
+						Snippet: `"${1:key}" = ${2:false}`,
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"list of map value",
+			listOfMap,
+			`attr = [
+  {
+    foo = 
+  },
+]
+`,
+			hcl.Pos{Line: 3, Column: 11, Byte: 23},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"list of map value in second element",
+			listOfMap,
+			`attr = [ { foo = true }, { bar =  } ]
+`,
+			hcl.Pos{Line: 1, Column: 34, Byte: 33},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 34, Byte: 33},
+							End:      hcl.Pos{Line: 1, Column: 34, Byte: 33},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 34, Byte: 33},
+							End:      hcl.Pos{Line: 1, Column: 34, Byte: 33},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: tc.attrSchema,
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Logf("position: %#v in config: %s", tc.pos, tc.cfg)
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
@@ -33,5 +33,6 @@ func (list List) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 	return &lang.HoverData{
 		Content: lang.Markdown(content),
 		Range:   eType.Range(),
+		Kind:    lang.ValueHoverKind,
 	}
 }
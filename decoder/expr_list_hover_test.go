@@ -17,6 +17,17 @@ import (
 )
 
 func TestHoverAtPos_exprList(t *testing.T) {
+	nestedListCons := schema.List{
+		Elem: schema.Map{
+			Elem: schema.List{
+				Elem: schema.OneOf{
+					schema.LiteralType{Type: cty.String},
+					schema.LiteralType{Type: cty.Number},
+				},
+			},
+		},
+	}
+
 	testCases := []struct {
 		testName          string
 		attrSchema        map[string]*schema.AttributeSchema
@@ -40,6 +51,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -60,6 +72,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -82,6 +95,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -105,6 +119,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -129,6 +144,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -152,6 +168,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -176,6 +193,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -207,6 +225,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -233,6 +252,7 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -280,6 +300,83 @@ func TestHoverAtPos_exprList(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 22},
 					End:      hcl.Pos{Line: 3, Column: 10, Byte: 29},
 				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"deeply nested list whole expression",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: nestedListCons,
+				},
+			},
+			`attr = [ { foo = [ "x" ] } ]`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("_list of map of list of (string or number)_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 29, Byte: 28},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"deeply nested list map element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: nestedListCons,
+				},
+			},
+			`attr = [ { foo = [ "x" ] } ]`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			&lang.HoverData{
+				Content: lang.Markdown("_map of list of (string or number)_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"deeply nested list inner list element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: nestedListCons,
+				},
+			},
+			`attr = [ { foo = [ "x" ] } ]`,
+			hcl.Pos{Line: 1, Column: 19, Byte: 18},
+			&lang.HoverData{
+				Content: lang.Markdown("_list of (string or number)_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 18, Byte: 17},
+					End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"deeply nested list innermost element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: nestedListCons,
+				},
+			},
+			`attr = [ { foo = [ "x" ] } ]`,
+			hcl.Pos{Line: 1, Column: 21, Byte: 20},
+			&lang.HoverData{
+				Content: lang.Markdown("_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 20, Byte: 19},
+					End:      hcl.Pos{Line: 1, Column: 23, Byte: 22},
+				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type ListOrSingle struct {
+	expr    hcl.Expression
+	cons    schema.ListOrSingle
+	pathCtx *PathContext
+}
+
+func (ls ListOrSingle) oneOf() OneOf {
+	return OneOf{
+		expr:    ls.expr,
+		cons:    ls.cons.OneOf(),
+		pathCtx: ls.pathCtx,
+	}
+}
+
+func (ls ListOrSingle) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
+	if !isEmptyExpression(ls.expr) || ls.cons.Elem == nil {
+		return ls.oneOf().CompletionAtPos(ctx, pos)
+	}
+
+	candidates := newExpression(ls.pathCtx, ls.expr, ls.cons.Elem).CompletionAtPos(ctx, pos)
+	if len(candidates) == 0 {
+		// Elements such as strings or numbers do not provide any
+		// candidates of their own, so we offer the bare value
+		// alongside the list to make both forms discoverable
+		if candidate, ok := ls.singleValueCandidate(ctx, pos); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	list := newExpression(ls.pathCtx, ls.expr, schema.List{
+		Elem:        ls.cons.Elem,
+		Description: ls.cons.Description,
+	})
+	return append(candidates, list.CompletionAtPos(ctx, pos)...)
+}
+
+func (ls ListOrSingle) singleValueCandidate(ctx context.Context, pos hcl.Pos) (lang.Candidate, bool) {
+	d := schema.EmptyCompletionDataOf(ctx, ls.cons.Elem, 1, 0)
+	if d.NewText == "" || d.Snippet == "" {
+		return lang.Candidate{}, false
+	}
+
+	return lang.Candidate{
+		Label:       ls.cons.Elem.FriendlyName(),
+		Detail:      ls.cons.Elem.FriendlyName(),
+		Kind:        candidateKindForConstraint(ls.cons.Elem),
+		Description: ls.cons.Description,
+		TextEdit: lang.TextEdit{
+			NewText: d.NewText,
+			Snippet: d.Snippet,
+			Range: hcl.Range{
+				Filename: ls.expr.Range().Filename,
+				Start:    pos,
+				End:      pos,
+			},
+		},
+		TriggerSuggest: d.TriggerSuggest,
+	}, true
+}
+
+func (ls ListOrSingle) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
+	return ls.oneOf().HoverAtPos(ctx, pos)
+}
+
+func (ls ListOrSingle) SemanticTokens(ctx context.Context) []lang.SemanticToken {
+	// the list form yields no tokens for a single value
+	// and vice versa, so whichever form is written wins
+	return ls.oneOf().SemanticTokens(ctx)
+}
+
+func (ls ListOrSingle) ReferenceOrigins(ctx context.Context, allowSelfRefs bool) reference.Origins {
+	return ls.oneOf().ReferenceOrigins(ctx, allowSelfRefs)
+}
+
+func (ls ListOrSingle) ReferenceTargets(ctx context.Context, targetCtx *TargetContext) reference.Targets {
+	return ls.oneOf().ReferenceTargets(ctx, targetCtx)
+}
+
+func (ls ListOrSingle) InferType() (cty.Type, bool) {
+	return ls.oneOf().InferType()
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -65,7 +66,8 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 
 		cons := schema.List{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 
@@ -80,7 +82,8 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 
 		cons := schema.Set{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 
@@ -114,7 +117,8 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 
 		cons := schema.Map{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 		return newExpression(lt.pathCtx, expr, cons).CompletionAtPos(ctx, pos)
@@ -127,7 +131,7 @@ func (lt LiteralType) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.C
 		}
 
 		cons := schema.Object{
-			Attributes: ctyObjectToObjectAttributes(typ),
+			Attributes: schema.ObjectAttributesForType(typ, lt.cons.Defaults),
 		}
 		return newExpression(lt.pathCtx, expr, cons).CompletionAtPos(ctx, pos)
 	}
@@ -195,23 +199,10 @@ func boolLiteralTypeCandidates(prefix string, editRange hcl.Range) []lang.Candid
 	return candidates
 }
 
-func ctyObjectToObjectAttributes(objType cty.Type) schema.ObjectAttributes {
-	attrTypes := objType.AttributeTypes()
-	objAttributes := make(schema.ObjectAttributes, len(attrTypes))
-
-	for name, attrType := range attrTypes {
-		aSchema := &schema.AttributeSchema{
-			Constraint: schema.LiteralType{
-				Type: attrType,
-			},
-		}
-		if objType.AttributeOptional(name) {
-			aSchema.IsOptional = true
-		} else {
-			aSchema.IsRequired = true
-		}
-		objAttributes[name] = aSchema
+// elemDefaults returns defaults of collection elements, if any
+func elemDefaults(defaults *typeexpr.Defaults) *typeexpr.Defaults {
+	if defaults == nil {
+		return nil
 	}
-
-	return objAttributes
+	return defaults.Children[""]
 }
@@ -110,6 +110,124 @@ func TestCompletionAtPos_exprLiteralType(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 10, Byte: 9},
 			lang.CompleteCandidates([]lang.Candidate{}),
 		},
+		{
+			"nullable bool",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.LiteralType{
+						Type: cty.Bool,
+					},
+					IsNullable: true,
+				},
+			},
+			`attr = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: cty.Bool.FriendlyNameForConstraint(),
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "false",
+						Snippet: "false",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+				{
+					Label:  "true",
+					Detail: cty.Bool.FriendlyNameForConstraint(),
+					Kind:   lang.BoolCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "true",
+						Snippet: "true",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "null",
+						Snippet: "null",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"nullable bool by prefix",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.LiteralType{
+						Type: cty.Bool,
+					},
+					IsNullable: true,
+				},
+			},
+			`attr = n
+`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "null",
+						Snippet: "null",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"nullable string with null",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.LiteralType{
+						Type: cty.String,
+					},
+					IsNullable: true,
+				},
+			},
+			`attr = null
+`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "null",
+					Detail: "null",
+					Kind:   lang.KeywordCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "null",
+						Snippet: "null",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						},
+					},
+				},
+			}),
+		},
 		{
 			"string",
 			map[string]*schema.AttributeSchema{
@@ -33,6 +33,7 @@ func (lt LiteralType) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDa
 			return &lang.HoverData{
 				Content: lang.Markdown(fmt.Sprintf(`_%s_`, typ.FriendlyName())),
 				Range:   expr.Range(),
+				Kind:    lang.ValueHoverKind,
 			}
 		}
 		// We may however land here from within AnyExpression, in which case
@@ -56,6 +57,7 @@ func (lt LiteralType) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDa
 		return &lang.HoverData{
 			Content: lang.Markdown(fmt.Sprintf(`_%s_`, typ.FriendlyName())),
 			Range:   expr.Range(),
+			Kind:    lang.ValueHoverKind,
 		}
 	}
 
@@ -67,7 +69,8 @@ func (lt LiteralType) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDa
 
 		cons := schema.List{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 
@@ -82,7 +85,8 @@ func (lt LiteralType) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDa
 
 		cons := schema.Set{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 
@@ -116,7 +120,8 @@ func (lt LiteralType) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDa
 
 		cons := schema.Map{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 		return newExpression(lt.pathCtx, expr, cons).HoverAtPos(ctx, pos)
@@ -129,7 +134,7 @@ func (lt LiteralType) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDa
 		}
 
 		cons := schema.Object{
-			Attributes: ctyObjectToObjectAttributes(typ),
+			Attributes: schema.ObjectAttributesForType(typ, lt.cons.Defaults),
 		}
 		return newExpression(lt.pathCtx, expr, cons).HoverAtPos(ctx, pos)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -43,6 +44,7 @@ func TestHoverAtPos_exprLiteralType(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -65,6 +67,7 @@ func TestHoverAtPos_exprLiteralType(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -85,6 +88,7 @@ func TestHoverAtPos_exprLiteralType(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -105,6 +109,7 @@ func TestHoverAtPos_exprLiteralType(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -125,6 +130,7 @@ func TestHoverAtPos_exprLiteralType(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -149,6 +155,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 5, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -184,6 +191,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -204,6 +212,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -226,6 +235,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -246,6 +256,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -274,6 +285,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -296,6 +308,7 @@ TEXT
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 11, Byte: 19},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -335,6 +348,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
 					End:      hcl.Pos{Line: 3, Column: 8, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -357,6 +371,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -377,6 +392,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -399,6 +415,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -419,6 +436,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -447,6 +465,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -486,6 +505,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
 					End:      hcl.Pos{Line: 3, Column: 8, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -508,6 +528,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -530,6 +551,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -553,6 +575,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -577,6 +600,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -600,6 +624,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -631,6 +656,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -676,6 +702,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 23},
 					End:      hcl.Pos{Line: 3, Column: 11, Byte: 31},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -698,6 +725,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -720,6 +748,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -740,6 +769,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -811,6 +841,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 17, Byte: 41},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -850,6 +881,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -872,6 +904,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -894,6 +927,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -918,6 +952,50 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"empty object with optional attribute defaults",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: literalTypeFromTypeExpr(`object({
+  name = string
+  port = optional(number, 8080)
+})`),
+				},
+			},
+			`attr = {}`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("```\n{\n  name = string\n  port = number # optional, default: 8080\n}\n```\n_object_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"empty object in list with optional attribute defaults",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: literalTypeFromTypeExpr(`list(object({
+  protocol = optional(string, "tcp")
+}))`),
+				},
+			},
+			`attr = [ {} ]`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			&lang.HoverData{
+				Content: lang.Markdown("```\n{\n  protocol = string # optional, default: \"tcp\"\n}\n```\n_object_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -942,6 +1020,7 @@ TEXT
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 16, Byte: 24},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -970,6 +1049,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -998,6 +1078,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 25},
 					End:      hcl.Pos{Line: 3, Column: 14, Byte: 36},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -1026,6 +1107,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 17, Byte: 41},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1080,6 +1162,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 2, Byte: 38},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1119,6 +1202,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 2, Byte: 35},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -1149,3 +1233,18 @@ TEXT
 		})
 	}
 }
+
+func literalTypeFromTypeExpr(src string) schema.LiteralType {
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "type.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		panic(diags.Error())
+	}
+	typ, defaults, diags := typeexpr.TypeConstraintWithDefaults(expr)
+	if diags.HasErrors() {
+		panic(diags.Error())
+	}
+	return schema.LiteralType{
+		Type:     typ,
+		Defaults: defaults,
+	}
+}
@@ -27,6 +27,7 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 	}
 
 	if typ.IsPrimitiveType() {
+		value := cty.NilVal
 		if !isEmptyExpression(lt.expr) {
 			// checking the expression strictly against constraint
 			// allows us to pick the right one if it's inside OneOf
@@ -37,6 +38,9 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 			if !val.Type().Equals(typ) {
 				return reference.Targets{}
 			}
+			if referenceTargetValuesFromContext(ctx) {
+				value = val
+			}
 		}
 
 		var rangePtr *hcl.Range
@@ -55,6 +59,7 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 				RangePtr:               rangePtr,
 				DefRangePtr:            targetCtx.ParentDefRangePtr,
 				Type:                   typ,
+				Value:                  value,
 			},
 		}
 	}
@@ -63,7 +68,8 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 		list := List{
 			cons: schema.List{
 				Elem: schema.LiteralType{
-					Type: typ.ElementType(),
+					Type:     typ.ElementType(),
+					Defaults: elemDefaults(lt.cons.Defaults),
 				},
 			},
 			expr:    lt.expr,
@@ -76,7 +82,8 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 		set := Set{
 			cons: schema.Set{
 				Elem: schema.LiteralType{
-					Type: typ.ElementType(),
+					Type:     typ.ElementType(),
+					Defaults: elemDefaults(lt.cons.Defaults),
 				},
 			},
 			expr:    lt.expr,
@@ -108,7 +115,8 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 		m := Map{
 			cons: schema.Map{
 				Elem: schema.LiteralType{
-					Type: typ.ElementType(),
+					Type:     typ.ElementType(),
+					Defaults: elemDefaults(lt.cons.Defaults),
 				},
 			},
 			expr:    lt.expr,
@@ -120,7 +128,7 @@ func (lt LiteralType) ReferenceTargets(ctx context.Context, targetCtx *TargetCon
 	if typ.IsObjectType() {
 		obj := Object{
 			cons: schema.Object{
-				Attributes: ctyObjectToObjectAttributes(typ),
+				Attributes: schema.ObjectAttributesForType(typ, lt.cons.Defaults),
 			},
 			expr:    lt.expr,
 			pathCtx: lt.pathCtx,
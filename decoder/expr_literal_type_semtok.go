@@ -48,6 +48,16 @@ func (lt LiteralType) SemanticTokens(ctx context.Context) []lang.SemanticToken {
 			return []lang.SemanticToken{}
 		}
 
+		if isNullExpression(expr) {
+			return []lang.SemanticToken{
+				{
+					Type:      lang.TokenNull,
+					Modifiers: lang.SemanticTokenModifiers{},
+					Range:     expr.Range(),
+				},
+			}
+		}
+
 		// While interpolation is not allowed/expected in LiteralType
 		// we still assume that expressions are convertible.
 		// This makes it easier to deal with a case where we land here
@@ -102,7 +112,8 @@ func (lt LiteralType) SemanticTokens(ctx context.Context) []lang.SemanticToken {
 
 		cons := schema.List{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 
@@ -117,7 +128,8 @@ func (lt LiteralType) SemanticTokens(ctx context.Context) []lang.SemanticToken {
 
 		cons := schema.Set{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 
@@ -151,7 +163,8 @@ func (lt LiteralType) SemanticTokens(ctx context.Context) []lang.SemanticToken {
 
 		cons := schema.Map{
 			Elem: schema.LiteralType{
-				Type: typ.ElementType(),
+				Type:     typ.ElementType(),
+				Defaults: elemDefaults(lt.cons.Defaults),
 			},
 		}
 		return newExpression(lt.pathCtx, expr, cons).SemanticTokens(ctx)
@@ -164,7 +177,7 @@ func (lt LiteralType) SemanticTokens(ctx context.Context) []lang.SemanticToken {
 		}
 
 		cons := schema.Object{
-			Attributes: ctyObjectToObjectAttributes(typ),
+			Attributes: schema.ObjectAttributesForType(typ, lt.cons.Defaults),
 		}
 		return newExpression(lt.pathCtx, expr, cons).SemanticTokens(ctx)
 	}
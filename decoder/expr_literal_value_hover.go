@@ -42,6 +42,7 @@ func (lv LiteralValue) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverD
 			return &lang.HoverData{
 				Content: lang.Markdown(content),
 				Range:   expr.Range(),
+				Kind:    lang.ValueHoverKind,
 			}
 		}
 
@@ -70,6 +71,7 @@ func (lv LiteralValue) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverD
 		return &lang.HoverData{
 			Content: lang.Markdown(content),
 			Range:   expr.Range(),
+			Kind:    lang.ValueHoverKind,
 		}
 	}
 
@@ -231,7 +233,7 @@ func (lv LiteralValue) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverD
 		}
 
 		cons := schema.Object{
-			Attributes:  ctyObjectToObjectAttributes(typ),
+			Attributes:  schema.ObjectAttributesForType(typ, nil),
 			Description: lv.cons.Description,
 		}
 		return newExpression(lv.pathCtx, expr, cons).HoverAtPos(ctx, pos)
@@ -44,6 +44,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -65,6 +66,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -86,6 +88,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -108,6 +111,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -128,6 +132,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -148,6 +153,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -168,6 +174,7 @@ func TestHoverAtPos_exprLiteralValue(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -192,6 +199,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 5, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -227,6 +235,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -250,6 +259,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -275,6 +285,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -298,6 +309,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -329,6 +341,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -393,6 +406,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
 					End:      hcl.Pos{Line: 3, Column: 8, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -415,6 +429,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -437,6 +452,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -461,6 +477,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -483,6 +500,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -514,6 +532,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -578,6 +597,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
 					End:      hcl.Pos{Line: 3, Column: 8, Byte: 25},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -600,6 +620,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -622,6 +643,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -645,6 +667,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -669,6 +692,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -692,6 +716,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -723,6 +748,7 @@ TEXT
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -768,6 +794,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 23},
 					End:      hcl.Pos{Line: 3, Column: 11, Byte: 31},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 
@@ -790,6 +817,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -812,6 +840,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -834,6 +863,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -915,6 +945,7 @@ TEXT
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 17, Byte: 41},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -958,6 +989,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -980,6 +1012,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -1008,6 +1041,7 @@ TEXT
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
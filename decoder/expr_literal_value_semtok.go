@@ -228,7 +228,7 @@ func (lv LiteralValue) SemanticTokens(ctx context.Context) []lang.SemanticToken
 		}
 
 		cons := schema.Object{
-			Attributes: ctyObjectToObjectAttributes(typ),
+			Attributes: schema.ObjectAttributesForType(typ, nil),
 		}
 		return newExpression(lv.pathCtx, expr, cons).SemanticTokens(ctx)
 	}
@@ -62,12 +62,14 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 		},
 	}
 
-	if betweenBraces.ContainsPos(pos) {
+	// position right before the closing brace (e.g. in empty {})
+	// is still considered to be between braces
+	if betweenBraces.ContainsPos(pos) || posEqual(pos, betweenBraces.End) {
 		if m.cons.Elem == nil {
 			return []lang.Candidate{}
 		}
 
-		cData := m.cons.Elem.EmptyCompletionData(ctx, 2, 0)
+		cData := schema.EmptyCompletionDataOf(ctx, m.cons.Elem, 2, 0)
 		kind := lang.AttributeCandidateKind
 		// TODO: replace "attribute" kind w/ Elem type
 
@@ -157,6 +159,15 @@ func (m Map) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate
 			}
 		}
 
+		// check any incomplete nested collection, e.g. { foo = { bar = } }
+		nestedFrom := eType.OpenRange.End
+		if recoveryPos.Byte > nestedFrom.Byte {
+			nestedFrom = recoveryPos
+		}
+		if _, valueExpr, ok := recoverNestedObjectItem(m.pathCtx, eType.Range().Filename, nestedFrom, pos); ok {
+			return newExpression(m.pathCtx, valueExpr, m.cons.Elem).CompletionAtPos(ctx, pos)
+		}
+
 		// check any incomplete configuration up to a terminating character
 		fileBytes := m.pathCtx.Files[eType.Range().Filename].Bytes
 		recoveredBytes := recoverLeftBytes(fileBytes, pos, func(offset int, r rune) bool {
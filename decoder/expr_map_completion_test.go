@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestCompletionAtPos_exprMap(t *testing.T) {
@@ -82,8 +83,97 @@ func TestCompletionAtPos_exprMap(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"empty expression with literal element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Map{
+						Elem:        schema.LiteralType{Type: cty.String},
+						Description: lang.PlainText("Map of tags"),
+					},
+				},
+			},
+			`attr = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:       `{ "key" = string }`,
+					Detail:      "map of string",
+					Description: lang.PlainText("Map of tags"),
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+						NewText: "{\n  \"name\" = \"value\"\n}",
+						Snippet: "{\n  \"${1:name}\" = \"${2:value}\"\n}",
+					},
+					Kind: lang.MapCandidateKind,
+				},
+			}),
+		},
 
 		// single line tests
+		{
+			"inside empty braces without space",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Map{
+						Elem: schema.LiteralType{Type: cty.String},
+					},
+				},
+			},
+			`attr = {}
+`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `"key" = string`,
+					Detail: "string",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+							End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						},
+						NewText: `"key" = "value"`,
+						Snippet: `"${1:key}" = "${2:value}"`,
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"inside empty braces without space of any map",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.AnyExpression{
+						OfType: cty.Map(cty.Number),
+					},
+				},
+			},
+			`attr = {}
+`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `"key" = number`,
+					Detail: "number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+							End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						},
+						NewText: `"key" = `,
+						Snippet: `"${1:key}" = `,
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
 		{
 			"inside braces single-line",
 			map[string]*schema.AttributeSchema{
@@ -1062,3 +1152,191 @@ func TestCompletionAtPos_exprMap(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprMap_nested(t *testing.T) {
+	mapOfList := map[string]*schema.AttributeSchema{
+		"attr": {
+			Constraint: schema.Map{
+				Elem: schema.List{
+					Elem: schema.LiteralType{Type: cty.Bool},
+				},
+			},
+		},
+	}
+	mapOfMap := map[string]*schema.AttributeSchema{
+		"attr": {
+			Constraint: schema.Map{
+				Elem: schema.Map{
+					Elem: schema.LiteralType{Type: cty.Bool},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		attrSchema         map[string]*schema.AttributeSchema
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"map of list value",
+			mapOfList,
+			`attr = {
+  foo = 
+}
+`,
+			hcl.Pos{Line: 2, Column: 9, Byte: 17},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "[ bool ]",
+					Detail: "list of bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 9, Byte: 17},
+							End:      hcl.Pos{Line: 2, Column: 9, Byte: 17},
+						},
+						NewText: "[ false ]",
+						Snippet: "[ ${1:false} ]",
+					},
+					Kind: lang.ListCandidateKind,
+				},
+			}),
+		},
+		{
+			"map of list new element",
+			mapOfList,
+			`attr = {
+  foo = [  ]
+}
+`,
+			hcl.Pos{Line: 2, Column: 10, Byte: 18},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 18},
+							End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 18},
+							End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"map of list new key",
+			mapOfList,
+			`attr = {
+  foo = [ true ]
+  
+}
+`,
+			hcl.Pos{Line: 3, Column: 3, Byte: 27},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `"key" = list of bool`,
+					Detail: "list of bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 27},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 27},
+						},
+						NewText: `"key" = [ false ]`,
+						Snippet: `"${1:key}" = [ ${2:false} ]`,
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+		{
+			"map of map incomplete inner value",
+			mapOfMap,
+			`attr = {
+  foo = {
+    bar = 
+  }
+}
+`,
+			hcl.Pos{Line: 3, Column: 11, Byte: 27},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 27},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 27},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 11, Byte: 27},
+							End:      hcl.Pos{Line: 3, Column: 11, Byte: 27},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: tc.attrSchema,
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Logf("pos: %#v, config: %s\n", tc.pos, tc.cfg)
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
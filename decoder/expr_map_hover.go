@@ -50,5 +50,6 @@ func (m Map) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 	return &lang.HoverData{
 		Content: lang.Markdown(content),
 		Range:   eType.Range(),
+		Kind:    lang.ValueHoverKind,
 	}
 }
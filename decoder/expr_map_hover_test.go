@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestHoverAtPos_exprMap(t *testing.T) {
@@ -39,6 +40,7 @@ func TestHoverAtPos_exprMap(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -59,6 +61,7 @@ func TestHoverAtPos_exprMap(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -81,6 +84,7 @@ func TestHoverAtPos_exprMap(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -107,6 +111,7 @@ func TestHoverAtPos_exprMap(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -186,6 +191,7 @@ func TestHoverAtPos_exprMap(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 19, Byte: 43},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -207,6 +213,81 @@ func TestHoverAtPos_exprMap(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 13, Byte: 21},
 			nil,
 		},
+		{
+			"deeply nested map whole expression",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Map{
+						Elem: schema.List{
+							Elem: schema.Map{
+								Elem: schema.LiteralType{Type: cty.String},
+							},
+						},
+					},
+				},
+			},
+			`attr = { a = [ { foo = "bar" } ] }`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("_map of list of map of string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 35, Byte: 34},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"deeply nested map list element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Map{
+						Elem: schema.List{
+							Elem: schema.Map{
+								Elem: schema.LiteralType{Type: cty.String},
+							},
+						},
+					},
+				},
+			},
+			`attr = { a = [ { foo = "bar" } ] }`,
+			hcl.Pos{Line: 1, Column: 15, Byte: 14},
+			&lang.HoverData{
+				Content: lang.Markdown("_list of map of string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+					End:      hcl.Pos{Line: 1, Column: 33, Byte: 32},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"deeply nested map innermost map",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Map{
+						Elem: schema.List{
+							Elem: schema.Map{
+								Elem: schema.LiteralType{Type: cty.String},
+							},
+						},
+					},
+				},
+			},
+			`attr = { a = [ { foo = "bar" } ] }`,
+			hcl.Pos{Line: 1, Column: 17, Byte: 16},
+			&lang.HoverData{
+				Content: lang.Markdown("_map of string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					End:      hcl.Pos{Line: 1, Column: 31, Byte: 30},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
 	}
 
 	for i, tc := range testCases {
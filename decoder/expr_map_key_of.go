@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type MapKeyOf struct {
+	expr    hcl.Expression
+	cons    schema.MapKeyOf
+	pathCtx *PathContext
+}
+
+func (mk MapKeyOf) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
+	return newExpression(mk.pathCtx, mk.expr, mk.keysConstraint()).CompletionAtPos(ctx, pos)
+}
+
+func (mk MapKeyOf) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
+	return newExpression(mk.pathCtx, mk.expr, mk.keysConstraint()).HoverAtPos(ctx, pos)
+}
+
+func (mk MapKeyOf) SemanticTokens(ctx context.Context) []lang.SemanticToken {
+	return newExpression(mk.pathCtx, mk.expr, mk.keysConstraint()).SemanticTokens(ctx)
+}
+
+// keysConstraint returns a constraint matching the known keys
+// of the referenced map, or any string if the keys are not known.
+func (mk MapKeyOf) keysConstraint() schema.Constraint {
+	keys, ok := mk.pathCtx.ReferenceTargets.MapKeysOf(mk.cons.Address)
+	if !ok {
+		return schema.LiteralType{Type: cty.String}
+	}
+
+	cons := make(schema.OneOf, 0, len(keys))
+	for _, key := range keys {
+		cons = append(cons, schema.LiteralValue{
+			Value:       cty.StringVal(key),
+			Description: mk.cons.Description,
+		})
+	}
+	return cons
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestCompletionAtPos_exprMapKeyOf(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"regions": {
+				Constraint: schema.Map{
+					Elem: schema.LiteralType{Type: cty.String},
+				},
+				IsOptional: true,
+				Address: &schema.AttributeAddrSchema{
+					Steps: schema.Address{
+						schema.AttrNameStep{},
+					},
+					AsExprType: true,
+				},
+			},
+			"region": {
+				Constraint: schema.MapKeyOf{
+					Address: lang.Address{
+						lang.RootStep{Name: "regions"},
+					},
+				},
+				IsOptional: true,
+			},
+			"zone": {
+				Constraint: schema.MapKeyOf{
+					Address: lang.Address{
+						lang.RootStep{Name: "zones"},
+					},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName           string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"empty expression with known keys",
+			`regions = {
+  us = "us-east-1"
+  eu = "eu-west-1"
+}
+region = 
+`,
+			hcl.Pos{Line: 5, Column: 10, Byte: 61},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "eu",
+					Detail: "string",
+					Kind:   lang.StringCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 10, Byte: 61},
+							End:      hcl.Pos{Line: 5, Column: 10, Byte: 61},
+						},
+						NewText: `"eu"`,
+						Snippet: `"eu"`,
+					},
+				},
+				{
+					Label:  "us",
+					Detail: "string",
+					Kind:   lang.StringCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 10, Byte: 61},
+							End:      hcl.Pos{Line: 5, Column: 10, Byte: 61},
+						},
+						NewText: `"us"`,
+						Snippet: `"us"`,
+					},
+				},
+			}),
+		},
+		{
+			"partial key with known keys",
+			`regions = {
+  us = "us-east-1"
+  eu = "eu-west-1"
+}
+region = "e"
+`,
+			hcl.Pos{Line: 5, Column: 11, Byte: 62},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "eu",
+					Detail: "string",
+					Kind:   lang.StringCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 10, Byte: 61},
+							End:      hcl.Pos{Line: 5, Column: 13, Byte: 64},
+						},
+						NewText: `"eu"`,
+						Snippet: `"eu"`,
+					},
+				},
+				{
+					Label:  "us",
+					Detail: "string",
+					Kind:   lang.StringCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 10, Byte: 61},
+							End:      hcl.Pos{Line: 5, Column: 13, Byte: 64},
+						},
+						NewText: `"us"`,
+						Snippet: `"us"`,
+					},
+				},
+			}),
+		},
+		{
+			"empty expression with unknown map",
+			`zone = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			pathCtx := &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+			targets, err := testPathDecoder(t, pathCtx).CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceTargets = targets
+			d := testPathDecoder(t, pathCtx)
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
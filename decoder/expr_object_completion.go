@@ -146,8 +146,32 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 		}
 	}
 
+	// check any incomplete nested collection, e.g. { foo = { bar = } }
+	nestedFrom := eType.OpenRange.End
+	if recoveryPos.Byte > nestedFrom.Byte {
+		nestedFrom = recoveryPos
+	}
+	if attrName, valueExpr, ok := recoverNestedObjectItem(obj.pathCtx, eType.Range().Filename, nestedFrom, pos); ok {
+		aSchema, ok := obj.cons.Attributes[attrName]
+		if !ok {
+			// unknown attribute
+			return []lang.Candidate{}
+		}
+		return newExpression(obj.pathCtx, valueExpr, aSchema.Constraint).CompletionAtPos(ctx, pos)
+	}
+
 	// check any incomplete configuration up to a terminating character
 	fileBytes := obj.pathCtx.Files[eType.Range().Filename].Bytes
+
+	// incomplete configuration may prevent the parser from recovering
+	// items declared elsewhere in the object, so we look for them
+	// in the source to avoid suggesting them again
+	for name, rng := range recoverDeclaredObjectKeys(fileBytes, betweenBraces, pos) {
+		if _, ok := declared[name]; !ok {
+			declared[name] = rng
+		}
+	}
+
 	leftBytes := recoverLeftBytes(fileBytes, pos, func(offset int, r rune) bool {
 		return isObjectItemTerminatingRune(r) && offset > recoveryPos.Byte
 	})
@@ -230,7 +254,7 @@ func (obj Object) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candi
 func objectItemPrefixBasedEditRange(remainingRange hcl.Range, fileBytes []byte, rawPrefixBytes []byte) hcl.Range {
 	remainingBytes := remainingRange.SliceBytes(fileBytes)
 	roughEndByteOffset := bytes.IndexFunc(remainingBytes, func(r rune) bool {
-		return r == '\n' || r == '}'
+		return r == '\n' || r == '}' || r == ','
 	})
 	// avoid editing over whitespace
 	trimmedRightBytes := bytes.TrimRightFunc(remainingBytes[:roughEndByteOffset], func(r rune) bool {
@@ -280,6 +304,67 @@ func objectAttributesToCandidates(ctx context.Context, prefix string, attrs sche
 	return candidates
 }
 
+// recoverDeclaredObjectKeys finds raw keys of items declared
+// within the given range of an object by scanning its tokens,
+// ignoring any key which contains the given position.
+func recoverDeclaredObjectKeys(fileBytes []byte, rng hcl.Range, pos hcl.Pos) declaredAttributes {
+	declared := make(declaredAttributes, 0)
+
+	tokens, _ := hclsyntax.LexExpression(rng.SliceBytes(fileBytes), rng.Filename, rng.Start)
+
+	depth := 0
+	itemStart := true
+	for i, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenOBrace, hclsyntax.TokenOBrack, hclsyntax.TokenOParen,
+			hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+			depth++
+			itemStart = false
+			continue
+		case hclsyntax.TokenCBrace, hclsyntax.TokenCBrack, hclsyntax.TokenCParen,
+			hclsyntax.TokenTemplateSeqEnd:
+			depth--
+			itemStart = false
+			continue
+		case hclsyntax.TokenNewline, hclsyntax.TokenComma:
+			itemStart = depth == 0
+			continue
+		case hclsyntax.TokenComment:
+			// single-line comments include the trailing newline
+			itemStart = depth == 0 && bytes.HasSuffix(token.Bytes, []byte("\n"))
+			continue
+		}
+
+		if depth != 0 || !itemStart {
+			continue
+		}
+		itemStart = false
+
+		var name string
+		var keyRange hcl.Range
+		switch {
+		case token.Type == hclsyntax.TokenIdent &&
+			i+1 < len(tokens) && tokens[i+1].Type == hclsyntax.TokenEqual:
+			name = string(token.Bytes)
+			keyRange = token.Range
+		case token.Type == hclsyntax.TokenOQuote &&
+			i+3 < len(tokens) && tokens[i+1].Type == hclsyntax.TokenQuotedLit &&
+			tokens[i+2].Type == hclsyntax.TokenCQuote && tokens[i+3].Type == hclsyntax.TokenEqual:
+			name = string(tokens[i+1].Bytes)
+			keyRange = hcl.RangeBetween(token.Range, tokens[i+2].Range)
+		default:
+			continue
+		}
+
+		if keyRange.ContainsPos(pos) || keyRange.End.Byte == pos.Byte {
+			continue
+		}
+		declared[name] = keyRange
+	}
+
+	return declared
+}
+
 func sortedObjectAttributeNames(objAttributes schema.ObjectAttributes) []string {
 	names := make([]string, 0, len(objAttributes))
 	for name := range objAttributes {
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestCompletionAtPos_exprObject(t *testing.T) {
@@ -726,24 +727,6 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 `,
 			hcl.Pos{Line: 1, Column: 20, Byte: 19},
 			lang.CompleteCandidates([]lang.Candidate{
-				// Ideally bar attribute should be ignored here
-				// but because of the double comma the HCL parser ignores it
-				// TODO: Try to recover trailing configuration from remaining bytes?
-				{
-					Label:  `bar`,
-					Detail: "optional, keyword",
-					TextEdit: lang.TextEdit{
-						Range: hcl.Range{
-							Filename: "test.tf",
-							Start:    hcl.Pos{Line: 1, Column: 20, Byte: 19},
-							End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
-						},
-						NewText: `bar`,
-						Snippet: `bar = `,
-					},
-					Kind:           lang.AttributeCandidateKind,
-					TriggerSuggest: true,
-				},
 				{
 					Label:  `baz`,
 					Detail: "optional, keyword",
@@ -967,6 +950,126 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"single-line partial attribute before declared attribute",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"bar": {
+								Constraint: schema.Keyword{Keyword: "kw2"},
+								IsOptional: true,
+							},
+							"baz": {
+								Constraint: schema.Keyword{Keyword: "kw3"},
+								IsOptional: true,
+							},
+						},
+					},
+				},
+			},
+			`attr = { ba, bar = kw2 }`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "baz",
+					Detail: "optional, keyword",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "baz",
+						Snippet: "baz = ",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+						},
+					},
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"multi-line partial attribute before declared attribute",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"bar": {
+								Constraint: schema.Keyword{Keyword: "kw2"},
+								IsOptional: true,
+							},
+							"baz": {
+								Constraint: schema.Keyword{Keyword: "kw3"},
+								IsOptional: true,
+							},
+						},
+					},
+				},
+			},
+			`attr = {
+  ba
+  bar = kw2
+}`,
+			hcl.Pos{Line: 2, Column: 5, Byte: 13},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "baz",
+					Detail: "optional, keyword",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "baz",
+						Snippet: "baz = ",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
+							End:      hcl.Pos{Line: 2, Column: 5, Byte: 13},
+						},
+					},
+					TriggerSuggest: true,
+				},
+			}),
+		},
+		{
+			"multi-line partial attribute after declared attribute",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Object{
+						Attributes: schema.ObjectAttributes{
+							"bar": {
+								Constraint: schema.Keyword{Keyword: "kw2"},
+								IsOptional: true,
+							},
+							"baz": {
+								Constraint: schema.Keyword{Keyword: "kw3"},
+								IsOptional: true,
+							},
+						},
+					},
+				},
+			},
+			`attr = {
+  bar = kw2
+  ba
+}`,
+			hcl.Pos{Line: 3, Column: 5, Byte: 25},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "baz",
+					Detail: "optional, keyword",
+					Kind:   lang.AttributeCandidateKind,
+					TextEdit: lang.TextEdit{
+						NewText: "baz",
+						Snippet: "baz = ",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 23},
+							End:      hcl.Pos{Line: 3, Column: 5, Byte: 25},
+						},
+					},
+					TriggerSuggest: true,
+				},
+			}),
+		},
 		{
 			"inside multi-line partial new element value near end",
 			map[string]*schema.AttributeSchema{
@@ -1827,9 +1930,21 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 `,
 			hcl.Pos{Line: 3, Column: 7, Byte: 25},
 			lang.CompleteCandidates([]lang.Candidate{
-				// TODO: This requires some upstream HCL parser changes
-				// as currently we receive ObjectConsExpr w/ zero Items.
-				// See https://github.com/hashicorp/hcl/issues/597
+				{
+					Label:  "noot",
+					Detail: "required, keyword",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 5, Byte: 23},
+							End:      hcl.Pos{Line: 3, Column: 7, Byte: 25},
+						},
+						NewText: "noot",
+						Snippet: "noot = ",
+					},
+					Kind:           lang.AttributeCandidateKind,
+					TriggerSuggest: true,
+				},
 			}),
 		},
 		{
@@ -1882,8 +1997,20 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 `,
 			hcl.Pos{Line: 3, Column: 12, Byte: 30},
 			lang.CompleteCandidates([]lang.Candidate{
-				// TODO: This requires some upstream HCL parser changes
-				// as currently we receive ObjectConsExpr w/ zero Items.
+				{
+					Label:  "noot",
+					Detail: "keyword",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 12, Byte: 30},
+							End:      hcl.Pos{Line: 3, Column: 12, Byte: 30},
+						},
+						NewText: "noot",
+						Snippet: "noot",
+					},
+					Kind: lang.KeywordCandidateKind,
+				},
 			}),
 		},
 	}
@@ -1914,3 +2041,65 @@ func TestCompletionAtPos_exprObject(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprObject_prefillRequiredFields(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Object{
+					Attributes: schema.ObjectAttributes{
+						"name": {
+							IsRequired: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+						"enabled": {
+							IsRequired: true,
+							Constraint: schema.LiteralType{Type: cty.Bool},
+						},
+						"description": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+					Description: lang.PlainText("Settings"),
+				},
+			},
+		},
+	}
+	cfg := `attr = 
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	d.PrefillRequiredFields = true
+
+	candidates, err := d.CompletionAtPos(context.Background(), "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:       `{…}`,
+			Detail:      "object",
+			Description: lang.PlainText("Settings"),
+			TextEdit: lang.TextEdit{
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				},
+				NewText: "{\n  enabled = false\n  name = \"value\"\n}",
+				Snippet: "{\n  enabled = ${1:false}\n  name = \"${2:value}\"\n}",
+			},
+			Kind: lang.ObjectCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
@@ -49,6 +49,7 @@ func (obj Object) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 				return &lang.HoverData{
 					Content: content,
 					Range:   itemRng,
+					Kind:    lang.AttributeHoverKind,
 				}
 			}
 		}
@@ -72,6 +73,7 @@ func (obj Object) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
 	return &lang.HoverData{
 		Content: lang.Markdown(content),
 		Range:   eType.Range(),
+		Kind:    lang.ValueHoverKind,
 	}
 }
 
@@ -80,8 +82,17 @@ func hoverContentForAttribute(name string, aSchema *schema.AttributeSchema) lang
 	if aSchema.Description.Value != "" {
 		value += fmt.Sprintf("\n\n%s", aSchema.Description.Value)
 	}
+	if aSchema.ReplacedBy != "" {
+		value += replacedByHint(aSchema.ReplacedBy)
+	}
 	return lang.MarkupContent{
 		Kind:  lang.MarkdownKind,
 		Value: value,
 	}
 }
+
+// replacedByHint returns markdown hint pointing to the replacement
+// of a deprecated attribute or block
+func replacedByHint(replacement string) string {
+	return fmt.Sprintf("\n\n**Deprecated**: use `%s` instead", replacement)
+}
@@ -40,6 +40,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -60,6 +61,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -87,6 +89,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -116,6 +119,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -145,6 +149,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -175,6 +180,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 16, Byte: 24},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -208,6 +214,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 16, Byte: 24},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -237,6 +244,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -280,6 +288,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 30},
 					End:      hcl.Pos{Line: 3, Column: 19, Byte: 46},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -323,6 +332,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 9, Byte: 33},
 					End:      hcl.Pos{Line: 3, Column: 19, Byte: 43},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -407,6 +417,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 2, Byte: 38},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -467,6 +478,7 @@ func TestHoverAtPos_exprObject(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 4, Column: 2, Byte: 35},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -5,21 +5,96 @@ package decoder
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func (oo OneOf) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
+	matchingCons := make([]schema.Constraint, 0)
+	matchingData := make([]*lang.HoverData, 0)
 	for _, con := range oo.cons {
-		// since we cannot know which "constraint was typed"
-		// we just pick the first which returns some data
 		expr := newExpression(oo.pathCtx, oo.expr, con)
 		hoverData := expr.HoverAtPos(ctx, pos)
 		if hoverData != nil {
-			return hoverData
+			matchingCons = append(matchingCons, con)
+			matchingData = append(matchingData, hoverData)
 		}
 	}
 
-	return nil
+	if len(matchingData) == 0 {
+		return nil
+	}
+	if len(matchingData) == 1 {
+		return matchingData[0]
+	}
+
+	// Prefer the branch which matches the expression exactly,
+	// e.g. a string literal matching LiteralType{Type: cty.String}
+	// rather than LiteralType{Type: cty.Number} it is convertible to
+	exactIdx := -1
+	for i, con := range matchingCons {
+		if oneOfBranchMatchesExactly(oo.expr, con) {
+			if exactIdx >= 0 {
+				// more than one exact match
+				exactIdx = -1
+				break
+			}
+			exactIdx = i
+		}
+	}
+	if exactIdx >= 0 {
+		return matchingData[exactIdx]
+	}
+
+	// We cannot tell which branch was meant, so we list all of them
+	contents := make([]string, 0)
+	for _, data := range matchingData {
+		if !stringsContain(contents, data.Content.Value) {
+			contents = append(contents, data.Content.Value)
+		}
+	}
+	if len(contents) == 1 {
+		return matchingData[0]
+	}
+
+	return &lang.HoverData{
+		Content: lang.Markdown(strings.Join(contents, "\n\n---\n\n")),
+		Range:   matchingData[0].Range,
+		Kind:    matchingData[0].Kind,
+	}
+}
+
+// oneOfBranchMatchesExactly returns true if the given expression
+// matches the constraint without any type conversion
+func oneOfBranchMatchesExactly(expr hcl.Expression, cons schema.Constraint) bool {
+	switch c := cons.(type) {
+	case schema.LiteralType:
+		switch eType := expr.(type) {
+		case *hclsyntax.LiteralValueExpr:
+			return eType.Val.Type().Equals(c.Type)
+		case *hclsyntax.TemplateExpr:
+			return eType.IsStringLiteral() && c.Type == cty.String
+		}
+	case schema.Reference:
+		_, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+		return ok
+	case schema.Keyword:
+		eType, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+		return ok && len(eType.Traversal) == 1 && eType.Traversal.RootName() == c.Keyword
+	}
+	return false
+}
+
+func stringsContain(slice []string, value string) bool {
+	for _, s := range slice {
+		if s == value {
+			return true
+		}
+	}
+	return false
 }
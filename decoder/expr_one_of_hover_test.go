@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestHoverAtPos_exprOneOf(t *testing.T) {
@@ -49,6 +50,7 @@ func TestHoverAtPos_exprOneOf(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -74,6 +76,51 @@ func TestHoverAtPos_exprOneOf(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"matching exact literal type",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.OneOf{
+						schema.LiteralType{Type: cty.String},
+						schema.LiteralType{Type: cty.Number},
+					},
+				},
+			},
+			`attr = 42`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("_number_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"ambiguous match",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.OneOf{
+						schema.LiteralType{Type: cty.String},
+						schema.LiteralType{Type: cty.DynamicPseudoType},
+					},
+				},
+			},
+			`attr = 42`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.HoverData{
+				Content: lang.Markdown("_string_\n\n---\n\n_number_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
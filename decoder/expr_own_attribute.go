@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+type OwnAttribute struct {
+	expr    hcl.Expression
+	cons    schema.OwnAttribute
+	pathCtx *PathContext
+}
+
+func (oa OwnAttribute) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
+	if isEmptyExpression(oa.expr) {
+		return oa.candidatesWithPrefix("", hcl.Range{
+			Filename: oa.expr.Range().Filename,
+			Start:    pos,
+			End:      pos,
+		})
+	}
+
+	eType, ok := oa.expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return []lang.Candidate{}
+	}
+
+	// only the attribute name (root step) is completed,
+	// any further steps (e.g. ["Name"] in tags["Name"]) are kept
+	rootRng := eType.Traversal[0].SourceRange()
+	if pos.Byte < rootRng.Start.Byte || pos.Byte > rootRng.End.Byte {
+		return []lang.Candidate{}
+	}
+
+	prefixRng := hcl.Range{
+		Filename: rootRng.Filename,
+		Start:    rootRng.Start,
+		End:      pos,
+	}
+	file, ok := oa.pathCtx.Files[rootRng.Filename]
+	if !ok {
+		return []lang.Candidate{}
+	}
+
+	return oa.candidatesWithPrefix(string(prefixRng.SliceBytes(file.Bytes)), rootRng)
+}
+
+func (oa OwnAttribute) candidatesWithPrefix(prefix string, editRng hcl.Range) []lang.Candidate {
+	candidates := make([]lang.Candidate, 0)
+	for _, name := range sortedAttributeNames(oa.cons.Attributes) {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		candidates = append(candidates, lang.Candidate{
+			Label:       name,
+			Detail:      oa.cons.FriendlyName(),
+			Description: oa.cons.Attributes[name].Description,
+			Kind:        lang.ReferenceCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: name,
+				Snippet: name,
+				Range:   editRng,
+			},
+		})
+	}
+	return candidates
+}
+
+func (oa OwnAttribute) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData {
+	eType, attr, ok := oa.traversalOfAttribute()
+	if !ok {
+		return nil
+	}
+
+	addr, err := lang.TraversalToAddress(eType.Traversal)
+	if err != nil {
+		return nil
+	}
+
+	content := fmt.Sprintf("`%s` _%s_", addr.String(), oa.cons.FriendlyName())
+	if attr.Description.Value != "" {
+		content += "\n\n" + attr.Description.Value
+	}
+
+	return &lang.HoverData{
+		Content: lang.Markdown(content),
+		Range:   eType.SrcRange,
+		Kind:    lang.ReferenceHoverKind,
+	}
+}
+
+func (oa OwnAttribute) SemanticTokens(ctx context.Context) []lang.SemanticToken {
+	eType, _, ok := oa.traversalOfAttribute()
+	if !ok {
+		return []lang.SemanticToken{}
+	}
+
+	return semanticTokensForTraversal(eType.Traversal)
+}
+
+// traversalOfAttribute returns the traversal expression along with
+// schema of the attribute it references, if the expression is
+// a traversal starting with a name of a known attribute.
+func (oa OwnAttribute) traversalOfAttribute() (*hclsyntax.ScopeTraversalExpr, *schema.AttributeSchema, bool) {
+	eType, ok := oa.expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return nil, nil, false
+	}
+
+	attr, ok := oa.cons.Attributes[eType.Traversal.RootName()]
+	if !ok {
+		return nil, nil, false
+	}
+
+	return eType, attr, true
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestHoverAtPos_exprOwnAttribute(t *testing.T) {
+	attrSchema := map[string]*schema.AttributeSchema{
+		"attr": {
+			Constraint: schema.OwnAttribute{
+				Attributes: map[string]*schema.AttributeSchema{
+					"tags": {
+						Constraint:  schema.Map{Elem: schema.LiteralType{Type: cty.String}},
+						Description: lang.Markdown("Tags of the resource"),
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName          string
+		cfg               string
+		pos               hcl.Pos
+		expectedHoverData *lang.HoverData
+	}{
+		{
+			"attribute",
+			`attr = tags`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			&lang.HoverData{
+				Content: lang.Markdown("`tags` _attribute reference_\n\nTags of the resource"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"nested path",
+			`attr = tags["Name"]`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			&lang.HoverData{
+				Content: lang.Markdown("`tags[\"Name\"]` _attribute reference_\n\nTags of the resource"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"unknown attribute",
+			`attr = foo`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
+			bodySchema := &schema.BodySchema{
+				Attributes: attrSchema,
+			}
+
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			ctx := context.Background()
+			hoverData, err := d.HoverAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedHoverData, hoverData); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRefOrLiteral_matchesOneOf(t *testing.T) {
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "str"},
+			},
+			Type: cty.String,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "num"},
+			},
+			Type: cty.Number,
+		},
+	}
+
+	testCases := []struct {
+		testName string
+		cfg      string
+		pos      hcl.Pos
+	}{
+		{
+			"empty expression",
+			`attr = 
+`,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+		},
+		{
+			"reference",
+			`attr = var.str
+`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+		},
+		{
+			"literal value",
+			`attr = "foo"
+`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+		},
+	}
+
+	decoderForCons := func(t *testing.T, cfg string, cons schema.Constraint) *PathDecoder {
+		f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+		return testPathDecoder(t, &PathContext{
+			Schema: &schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"attr": {
+						Constraint: cons,
+						IsOptional: true,
+					},
+				},
+			},
+			Files: map[string]*hcl.File{
+				"test.tf": f,
+			},
+			ReferenceTargets: refTargets,
+		})
+	}
+
+	refOrLiteral := schema.RefOrLiteral{Type: cty.String}
+	oneOf := schema.OneOf{
+		schema.Reference{OfType: cty.String},
+		schema.LiteralType{Type: cty.String},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			ctx := context.Background()
+			rlDecoder := decoderForCons(t, tc.cfg, refOrLiteral)
+			ooDecoder := decoderForCons(t, tc.cfg, oneOf)
+
+			expectedCandidates, err := ooDecoder.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			candidates, err := rlDecoder.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+
+			// hover may error e.g. in empty expression for both
+			expectedHoverData, expectedErr := ooDecoder.HoverAtPos(ctx, "test.tf", tc.pos)
+			hoverData, err := rlDecoder.HoverAtPos(ctx, "test.tf", tc.pos)
+			if diff := cmp.Diff(fmt.Sprint(expectedErr), fmt.Sprint(err)); diff != "" {
+				t.Fatalf("unexpected hover error: %s", diff)
+			}
+			if diff := cmp.Diff(expectedHoverData, hoverData); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+
+			expectedTokens, err := ooDecoder.SemanticTokensInFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tokens, err := rlDecoder.SemanticTokensInFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+				t.Fatalf("unexpected semantic tokens: %s", diff)
+			}
+		})
+	}
+}
@@ -5,11 +5,15 @@ package decoder
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidate {
@@ -28,13 +32,14 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 		return []lang.Candidate{}
 	}
 
-	outerBodyRng := rootBody.Range()
 	// Find outer block body range to allow filtering
-	// of references pointing back to the same block
-	outerBlock := rootBody.OutermostBlockAtPos(pos)
+	// of references pointing back to the same block.
+	// Attributes of the root body have no such block
+	// and so all targets remain available to them.
+	outerBodyRng := hcl.Range{}
+	outerBlock := outermostBlockAtPos(rootBody, pos)
 	if outerBlock != nil {
-		ob := outerBlock.Body.(*hclsyntax.Body)
-		outerBodyRng = ob.Range()
+		outerBodyRng = outerBlock.Body.Range()
 	}
 
 	if isEmptyExpression(ref.expr) {
@@ -57,9 +62,11 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 					Snippet: address,
 					Range:   editRng,
 				},
+				SortText: ref.sortText(target, address),
 			})
 			return nil
 		})
+		sortReferenceCandidates(candidates)
 		return candidates
 	}
 
@@ -69,10 +76,11 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 	}
 
 	editRng := eType.Range()
-	if !editRng.ContainsPos(pos) {
-		// account for trailing character(s) which doesn't appear in AST
-		// such as dot, opening bracket etc.
-		editRng.End = pos
+	// account for trailing character(s) which doesn't appear in AST
+	// such as dot, opening bracket etc.
+	_, tokenRng := tokenRangeAtPos(file.Bytes, eType.Range().Filename, pos)
+	if tokenRng.End.Byte > editRng.End.Byte {
+		editRng.End = tokenRng.End
 	}
 	prefixRng := hcl.Range{
 		Filename: eType.Range().Filename,
@@ -95,8 +103,155 @@ func (ref Reference) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Ca
 				Snippet: address,
 				Range:   editRng,
 			},
+			SortText: ref.sortText(target, address),
 		})
 		return nil
 	})
+
+	if strings.HasSuffix(prefix, ".") {
+		// Targets without nested targets may still be traversable
+		// via attributes of their (object) type, e.g. aws_instance.foo.
+		parentAddr := strings.TrimSuffix(prefix, ".")
+		for _, target := range ref.attributeTargetsOf(ctx, parentAddr, outerBodyRng, editRng) {
+			address := target.Addr.String()
+
+			candidates = append(candidates, lang.Candidate{
+				Label:  address,
+				Detail: target.FriendlyName(),
+				Kind:   lang.ReferenceCandidateKind,
+				TextEdit: lang.TextEdit{
+					NewText: address,
+					Snippet: address,
+					Range:   editRng,
+				},
+				SortText: ref.sortText(target, address),
+			})
+		}
+	}
+
+	sortReferenceCandidates(candidates)
 	return candidates
 }
+
+// Reference candidates are ranked by how closely the target
+// matches the constraint, i.e. exact type matches come first,
+// followed by targets of any (dynamic) type and then any other
+// targets which match the scope only (or are merely convertible).
+const (
+	refRankExactType = iota
+	refRankDynamicType
+	refRankScopeOnly
+)
+
+func (ref Reference) matchRank(target reference.Target) int {
+	if ref.cons.OfType != cty.NilType && target.Type != cty.NilType {
+		if target.Type.Equals(ref.cons.OfType) {
+			return refRankExactType
+		}
+		if target.Type == cty.DynamicPseudoType {
+			return refRankDynamicType
+		}
+	}
+	return refRankScopeOnly
+}
+
+// sortText returns SortText for a reference candidate, which preserves
+// the ranking of targets and sorts alphabetically within the same rank.
+func (ref Reference) sortText(target reference.Target, address string) string {
+	return fmt.Sprintf("%d%s", ref.matchRank(target), address)
+}
+
+func sortReferenceCandidates(candidates []lang.Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].SortText < candidates[j].SortText
+	})
+}
+
+// attributeTargetsOf returns targets implied by attributes of the object
+// type of a target addressable by parentAddr which has no nested targets.
+// parentAddr may also point to a nested object attribute of such target,
+// up to the depth limited by MaxReferenceStepDepth (if any).
+// Only targets matching the constraint are returned.
+func (ref Reference) attributeTargetsOf(ctx context.Context, parentAddr string, outerBodyRng, editRng hcl.Range) reference.Targets {
+	targets := make(reference.Targets, 0)
+	maxDepth := maxReferenceStepDepthFromContext(ctx)
+
+	var walk func(reference.Targets)
+	walk = func(refTargets reference.Targets) {
+		for _, target := range refTargets {
+			if len(target.NestedTargets) > 0 {
+				walk(target.NestedTargets)
+				continue
+			}
+			if !target.Type.IsObjectType() {
+				continue
+			}
+
+			addr := target.Address(ctx, editRng.Start)
+			attrPath, ok := attributePathOf(addr.String(), parentAddr)
+			if !ok {
+				continue
+			}
+			if maxDepth > 0 && len(attrPath)+1 > maxDepth {
+				continue
+			}
+			// Reject references to block's own fields from within the body
+			if target.RangePtr != nil && outerBodyRng.ContainsPos(target.RangePtr.Start) &&
+				target.RangePtr.Filename == outerBodyRng.Filename {
+				continue
+			}
+
+			parentType := target.Type
+			parentTargetAddr := addr.Copy()
+			for _, name := range attrPath {
+				if !parentType.IsObjectType() || !parentType.HasAttribute(name) {
+					ok = false
+					break
+				}
+				parentType = parentType.AttributeType(name)
+				parentTargetAddr = append(parentTargetAddr, lang.AttrStep{Name: name})
+			}
+			if !ok || !parentType.IsObjectType() {
+				continue
+			}
+
+			for _, name := range sortedObjectAttrNames(parentType) {
+				attrTarget := reference.Target{
+					Addr:    append(parentTargetAddr.Copy(), lang.AttrStep{Name: name}),
+					ScopeId: target.ScopeId,
+					Type:    parentType.AttributeType(name),
+				}
+				if attrTarget.MatchesConstraint(ref.cons) {
+					targets = append(targets, attrTarget)
+				}
+			}
+		}
+	}
+	walk(ref.pathCtx.ReferenceTargets)
+
+	return targets
+}
+
+// attributePathOf returns names of attribute steps of parentAddr
+// following the given target address, e.g. [bar, baz]
+// for var.foo.bar.baz and var.foo (or empty path if equal).
+func attributePathOf(targetAddr, parentAddr string) ([]string, bool) {
+	if parentAddr == targetAddr {
+		return []string{}, true
+	}
+	if !strings.HasPrefix(parentAddr, targetAddr+".") {
+		return nil, false
+	}
+	return strings.Split(strings.TrimPrefix(parentAddr, targetAddr+"."), "."), true
+}
+
+type maxReferenceStepDepthKey struct{}
+
+func withMaxReferenceStepDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, maxReferenceStepDepthKey{}, depth)
+}
+
+func maxReferenceStepDepthFromContext(ctx context.Context) int {
+	depth, _ := ctx.Value(maxReferenceStepDepthKey{}).(int)
+	return depth
+}
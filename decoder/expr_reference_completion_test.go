@@ -62,9 +62,11 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 8, Byte: 7},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -76,9 +78,11 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 					},
 				},
 				{
-					Label:  "local.baz",
-					Detail: "number",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.baz",
+					Detail:           "number",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2local.baz",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.baz",
 						Snippet: "local.baz",
@@ -127,9 +131,11 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 13, Byte: 12},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.bar",
-					Detail: "number",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.bar",
+					Detail:           "number",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.bar",
 						Snippet: "local.bar",
@@ -178,9 +184,11 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 11, Byte: 10},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -229,9 +237,11 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 13},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "local.foo",
-					Detail: "string",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "local.foo",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0local.foo",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "local.foo",
 						Snippet: "local.foo",
@@ -280,6 +290,114 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			lang.CompleteCandidates([]lang.Candidate{}),
 		},
+		{
+			"required path",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfScopeId: lang.ScopeId("module"),
+						RequiredPath: lang.Address{
+							lang.AttrStep{Name: "some_output"},
+						},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "module"},
+						lang.AttrStep{Name: "bar"},
+					},
+					ScopeId: lang.ScopeId("module"),
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "module"},
+						lang.AttrStep{Name: "foo"},
+					},
+					ScopeId: lang.ScopeId("module"),
+					NestedTargets: reference.Targets{
+						{
+							Addr: lang.Address{
+								lang.RootStep{Name: "module"},
+								lang.AttrStep{Name: "foo"},
+								lang.AttrStep{Name: "some_output"},
+							},
+							ScopeId: lang.ScopeId("module"),
+						},
+					},
+				},
+			},
+			`attr = module.foo.`,
+			hcl.Pos{Line: 1, Column: 19, Byte: 18},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "module.foo.some_output",
+					Detail:           "reference",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2module.foo.some_output",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "module.foo.some_output",
+						Snippet: "module.foo.some_output",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						},
+					},
+				},
+			}),
+		},
+		{
+			"block type",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfType:      cty.String,
+						OfBlockType: "aws_instance",
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "foo"},
+						lang.AttrStep{Name: "id"},
+					},
+					Type: cty.String,
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_vpc"},
+						lang.AttrStep{Name: "bar"},
+						lang.AttrStep{Name: "id"},
+					},
+					Type: cty.String,
+				},
+			},
+			`attr = `,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "aws_instance.foo.id",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0aws_instance.foo.id",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "aws_instance.foo.id",
+						Snippet: "aws_instance.foo.id",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+							End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						},
+					},
+				},
+			}),
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%d-%s", i, tc.testName), func(t *testing.T) {
@@ -308,3 +426,301 @@ func TestCompletionAtPos_exprReference(t *testing.T) {
 		})
 	}
 }
+
+func TestCompletionAtPos_exprReference_ranking(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{
+					OfScopeId: lang.ScopeId("variable"),
+					OfType:    cty.String,
+				},
+			},
+		},
+	}
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "aaa"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+			Type:    cty.Number,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "bbb"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+			Type:    cty.DynamicPseudoType,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "ccc"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+			Type:    cty.String,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "abc"},
+			},
+			ScopeId: lang.ScopeId("variable"),
+			Type:    cty.String,
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte(`attr = `), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: refTargets,
+	})
+
+	ctx := context.Background()
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{Line: 1, Column: 8, Byte: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type rankedCandidate struct {
+		Label    string
+		SortText string
+	}
+	expectedCandidates := []rankedCandidate{
+		{Label: "var.abc", SortText: "0var.abc"},
+		{Label: "var.ccc", SortText: "0var.ccc"},
+		{Label: "var.bbb", SortText: "1var.bbb"},
+		{Label: "var.aaa", SortText: "2var.aaa"},
+	}
+	givenCandidates := make([]rankedCandidate, len(candidates.List))
+	for i, c := range candidates.List {
+		givenCandidates[i] = rankedCandidate{Label: c.Label, SortText: c.SortText}
+	}
+
+	if diff := cmp.Diff(expectedCandidates, givenCandidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestCompletionAtPos_exprReference_maxStepDepth(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{
+					OfType: cty.DynamicPseudoType,
+				},
+			},
+		},
+	}
+	refTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "foo"},
+			},
+			Type: cty.Object(map[string]cty.Type{
+				"name": cty.String,
+				"bar": cty.Object(map[string]cty.Type{
+					"baz": cty.Object(map[string]cty.Type{
+						"qux": cty.String,
+					}),
+				}),
+			}),
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		maxDepth       int
+		cfg            string
+		expectedLabels []string
+	}{
+		{
+			"unlimited first level",
+			0,
+			`attr = var.foo.`,
+			[]string{"var.foo.bar", "var.foo.name"},
+		},
+		{
+			"unlimited third level",
+			0,
+			`attr = var.foo.bar.baz.`,
+			[]string{"var.foo.bar.baz.qux"},
+		},
+		{
+			"limited within depth",
+			2,
+			`attr = var.foo.bar.`,
+			[]string{"var.foo.bar.baz"},
+		},
+		{
+			"limited beyond depth",
+			2,
+			`attr = var.foo.bar.baz.`,
+			[]string{},
+		},
+		{
+			"limited to first level",
+			1,
+			`attr = var.foo.bar.`,
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			dirPath := t.TempDir()
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Schema: bodySchema,
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+						ReferenceTargets: refTargets,
+					},
+				},
+			})
+			decoderCtx := NewDecoderContext()
+			decoderCtx.MaxReferenceStepDepth = tc.maxDepth
+			d.SetContext(decoderCtx)
+
+			pos := hcl.Pos{Line: 1, Column: len(tc.cfg) + 1, Byte: len(tc.cfg)}
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPosInPath(ctx, lang.Path{Path: dirPath}, "test.tf", pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, c := range candidates.List {
+				labels = append(labels, c.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCompletionAtPos_exprReference_collectedTargets(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					BodyAsData: true,
+					InferBody:  true,
+					ScopeId:    lang.ScopeId("resource"),
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+						"config": {
+							IsOptional: true,
+							Constraint: schema.AnyExpression{
+								OfType: cty.Object(map[string]cty.Type{
+									"size": cty.Number,
+								}),
+							},
+						},
+					},
+				},
+			},
+		},
+		Attributes: map[string]*schema.AttributeSchema{
+			"x": {
+				IsOptional: true,
+				Constraint: schema.AnyExpression{OfType: cty.DynamicPseudoType},
+			},
+		},
+	}
+	resourcesCfg := `resource "aws_instance" "app" {
+  ami = "ami-123"
+}
+
+resource "aws_instance" "db" {
+  ami = "ami-456"
+}
+`
+
+	testCases := []struct {
+		name           string
+		cfg            string
+		expectedLabels []string
+	}{
+		{
+			"instance names after type",
+			`x = aws_instance.`,
+			[]string{"aws_instance.app", "aws_instance.db"},
+		},
+		{
+			"attributes after instance name",
+			`x = aws_instance.app.`,
+			[]string{"aws_instance.app.ami", "aws_instance.app.config"},
+		},
+		{
+			"attributes of attribute type",
+			`x = aws_instance.app.config.`,
+			[]string{"aws_instance.app.config.size"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			cfg := resourcesCfg + tc.cfg
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+			targets, err := d.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			d = testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+			})
+
+			pos := hcl.Pos{Line: 8, Column: len(tc.cfg) + 1, Byte: len(cfg)}
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0)
+			for _, c := range candidates.List {
+				labels = append(labels, c.Label)
+			}
+			if diff := cmp.Diff(tc.expectedLabels, labels); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
@@ -41,6 +41,7 @@ func (ref Reference) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverDat
 			return &lang.HoverData{
 				Content: lang.Markdown(content),
 				Range:   eType.Range(),
+				Kind:    lang.ReferenceHoverKind,
 			}
 		}
 	}
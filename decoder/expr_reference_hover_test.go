@@ -160,6 +160,67 @@ foo = "noot"
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
 				},
+				Kind: lang.ReferenceHoverKind,
+			},
+		},
+		{
+			"matching origin and target with metadata",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Reference{
+						OfType: cty.String,
+					},
+				},
+			},
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "local"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+					Constraints: reference.OriginConstraints{
+						{
+							OfType: cty.String,
+						},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "local"},
+						lang.AttrStep{Name: "foo"},
+					},
+					Type: cty.String,
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 13, Byte: 29},
+					},
+					Metadata: map[string]string{
+						reference.MetadataKeySource:  "example/foo 1.2.0",
+						reference.MetadataKeyDocsURL: "https://example.com/docs/foo",
+						"unknown":                    "ignored",
+					},
+				},
+			},
+			`attr = local.foo
+foo = "noot"
+`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			&lang.HoverData{
+				Content: lang.Markdown("`local.foo`\n_string_\n\nSource: `example/foo 1.2.0`\n\n[Documentation](https://example.com/docs/foo)"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -218,6 +279,7 @@ foo = "noot"
 					Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
 					End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 	}
@@ -104,6 +104,7 @@ func originConstraintsFromCons(cons schema.Reference) reference.OriginConstraint
 		{
 			OfType:    cons.OfType,
 			OfScopeId: cons.OfScopeId,
+			Optional:  cons.IsTargetOptional,
 		},
 	}
 }
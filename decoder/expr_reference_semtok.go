@@ -30,18 +30,37 @@ func (ref Reference) SemanticTokens(ctx context.Context) []lang.SemanticToken {
 		if !ok {
 			continue
 		}
-		_, ok = ref.pathCtx.ReferenceTargets.Match(matchableOrigin)
+		targets, ok := ref.pathCtx.ReferenceTargets.Match(matchableOrigin)
 		if !ok {
 			// target not found
 			continue
 		}
 
-		return semanticTokensForTraversal(eType.Traversal)
+		tokens := semanticTokensForTraversal(eType.Traversal)
+		if isExtensionTarget(targets[0]) {
+			for i := range tokens {
+				tokens[i].Modifiers = append(tokens[i].Modifiers, lang.TokenModifierSynthetic)
+			}
+		}
+		return tokens
 	}
 
 	return []lang.SemanticToken{}
 }
 
+// isExtensionTarget returns true if the target is provided
+// by the count or for_each body extension, i.e. count.* or each.*
+func isExtensionTarget(target reference.Target) bool {
+	if len(target.Addr) > 0 || len(target.LocalAddr) == 0 {
+		return false
+	}
+	rootStep, ok := target.LocalAddr[0].(lang.RootStep)
+	if !ok {
+		return false
+	}
+	return rootStep.Name == "count" || rootStep.Name == "each"
+}
+
 func semanticTokensForTraversal(traversal hcl.Traversal) []lang.SemanticToken {
 	tokens := make([]lang.SemanticToken, 0)
 
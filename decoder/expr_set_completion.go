@@ -67,12 +67,14 @@ func (set Set) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidat
 		// filter out declared elements to provide uniqueness as that is the nature of set.
 		// See https://github.com/hashicorp/hcl-lang/issues/225
 
+		skippedEmptyElem := false
 		for _, elemExpr := range eType.Exprs {
-			// We cannot trust ranges of empty expressions, so we imply
-			// that invalid configuration follows and we stop here
+			// We cannot trust ranges of empty expressions, so we skip them
+			// and keep looking for the element under the cursor
 			// e.g. for completion between commas [keyword, ,keyword]
 			if isEmptyExpression(elemExpr) {
-				break
+				skippedEmptyElem = true
+				continue
 			}
 			// We overshot the position and stop
 			if elemExpr.Range().Start.Byte > pos.Byte {
@@ -81,13 +83,16 @@ func (set Set) CompletionAtPos(ctx context.Context, pos hcl.Pos) []lang.Candidat
 			if elemExpr.Range().ContainsPos(pos) || elemExpr.Range().End.Byte == pos.Byte {
 				return newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
 			}
-			if pos.Byte-elemExpr.Range().End.Byte == 1 {
-				fileBytes := set.pathCtx.Files[eType.Range().Filename].Bytes
-				trailingRune := fileBytes[elemExpr.Range().End.Byte:pos.Byte][0]
+			if isTrailingDotAfterExpr(set.pathCtx, elemExpr, pos) {
+				return newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
+			}
+		}
 
-				if trailingRune == '.' {
-					return newExpression(set.pathCtx, elemExpr, set.cons.Elem).CompletionAtPos(ctx, pos)
-				}
+		if skippedEmptyElem {
+			// The parser gives up after an empty element, so any element
+			// following it is not in the AST and has to be recovered
+			if expr, ok := recoverExpressionAtPos(set.pathCtx, eType.Range().Filename, pos); ok {
+				return newExpression(set.pathCtx, expr, set.cons.Elem).CompletionAtPos(ctx, pos)
 			}
 		}
 
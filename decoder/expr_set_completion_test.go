@@ -310,6 +310,38 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 		},
 
 		// multi line tests
+		{
+			// TODO: revisit if we allow only unique elements in sets
+			"inside single-line partial element after empty element",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.Keyword{
+							Keyword: "keyword",
+						},
+					},
+				},
+			},
+			`attr = [ keyword, , keyw ]
+`,
+			hcl.Pos{Line: 1, Column: 25, Byte: 24},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  `keyword`,
+					Detail: "keyword",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 21, Byte: 20},
+							End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+						},
+						NewText: `keyword`,
+						Snippet: `keyword`,
+					},
+					Kind: lang.KeywordCandidateKind,
+				},
+			}),
+		},
 		{
 			"inside brackets multi-line",
 			map[string]*schema.AttributeSchema{
@@ -620,6 +652,138 @@ func TestCompletionAtPos_exprSet(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"inside single-line directly after trailing comma",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [ true,  ]
+`,
+			hcl.Pos{Line: 1, Column: 15, Byte: 14},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
+							End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
+							End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"inside single-line after trailing comma without space",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [true,]
+`,
+			hcl.Pos{Line: 1, Column: 14, Byte: 13},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+							End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+							End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
+		{
+			"inside multi-line after trailing comma with whitespace only",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.LiteralType{Type: cty.Bool},
+					},
+				},
+			},
+			`attr = [
+  true,
+  
+]
+`,
+			hcl.Pos{Line: 3, Column: 3, Byte: 19},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "false",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 19},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 19},
+						},
+						NewText: "false",
+						Snippet: "false",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+				{
+					Label:  "true",
+					Detail: "bool",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 3, Column: 3, Byte: 19},
+							End:      hcl.Pos{Line: 3, Column: 3, Byte: 19},
+						},
+						NewText: "true",
+						Snippet: "true",
+					},
+					Kind: lang.BoolCandidateKind,
+				},
+			}),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -687,9 +851,11 @@ func TestCompletionAtPos_exprSet_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 13},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "reference",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "reference",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -702,6 +868,71 @@ func TestCompletionAtPos_exprSet_references(t *testing.T) {
 				},
 			}),
 		},
+		{
+			"single-line with trailing dot after typed reference",
+			map[string]*schema.AttributeSchema{
+				"attr": {
+					Constraint: schema.Set{
+						Elem: schema.Reference{OfType: cty.String},
+					},
+				},
+			},
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "foo"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 17},
+						End:      hcl.Pos{Line: 2, Column: 3, Byte: 19},
+					},
+					Type: cty.Object(map[string]cty.Type{
+						"id":   cty.String,
+						"ami":  cty.String,
+						"tags": cty.Map(cty.String),
+					}),
+				},
+			},
+			`attr = [ aws_instance.foo. ]
+`,
+			hcl.Pos{Line: 1, Column: 27, Byte: 26},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:            "aws_instance.foo.ami",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0aws_instance.foo.ami",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "aws_instance.foo.ami",
+						Snippet: "aws_instance.foo.ami",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
+				{
+					Label:            "aws_instance.foo.id",
+					Detail:           "string",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "0aws_instance.foo.id",
+					CommitCharacters: []string{"."},
+					TextEdit: lang.TextEdit{
+						NewText: "aws_instance.foo.id",
+						Snippet: "aws_instance.foo.id",
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+							End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+						},
+					},
+				},
+			}),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -40,6 +40,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -60,6 +61,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -82,6 +84,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -105,6 +108,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -129,6 +133,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -152,6 +157,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -176,6 +182,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -207,6 +214,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -233,6 +241,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -280,6 +289,7 @@ func TestHoverAtPos_exprSet(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 22},
 					End:      hcl.Pos{Line: 3, Column: 10, Byte: 29},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
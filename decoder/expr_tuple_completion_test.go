@@ -802,9 +802,11 @@ func TestCompletionAtPos_exprTuple_references(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 14, Byte: 13},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "var.bar",
-					Detail: "reference",
-					Kind:   lang.ReferenceCandidateKind,
+					Label:            "var.bar",
+					Detail:           "reference",
+					Kind:             lang.ReferenceCandidateKind,
+					SortText:         "2var.bar",
+					CommitCharacters: []string{"."},
 					TextEdit: lang.TextEdit{
 						NewText: "var.bar",
 						Snippet: "var.bar",
@@ -39,5 +39,6 @@ func (tuple Tuple) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.HoverData
 	return &lang.HoverData{
 		Content: lang.Markdown(content),
 		Range:   eType.Range(),
+		Kind:    lang.ValueHoverKind,
 	}
 }
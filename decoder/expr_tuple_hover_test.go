@@ -40,6 +40,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -60,6 +61,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -87,6 +89,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -115,6 +118,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -141,6 +145,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 2, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -169,6 +174,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -199,6 +205,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -235,6 +242,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 						Byte:   23,
 					},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -267,6 +275,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 11},
 					End:      hcl.Pos{Line: 2, Column: 10, Byte: 18},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -326,6 +335,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 22},
 					End:      hcl.Pos{Line: 3, Column: 10, Byte: 29},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -350,6 +360,7 @@ func TestHoverAtPos_exprTuple(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
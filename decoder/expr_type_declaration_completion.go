@@ -333,9 +333,10 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 
 	if strings.HasPrefix("list", prefix) {
 		candidates = append(candidates, lang.Candidate{
-			Label:  "list(…)",
-			Detail: "list",
-			Kind:   lang.ListCandidateKind,
+			Label:      "list(…)",
+			FilterText: "list",
+			Detail:     "list",
+			Kind:       lang.ListCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "list()",
 				Snippet: fmt.Sprintf("list(${%d})", 0),
@@ -346,9 +347,10 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 	}
 	if strings.HasPrefix("set", prefix) {
 		candidates = append(candidates, lang.Candidate{
-			Label:  "set(…)",
-			Detail: "set",
-			Kind:   lang.SetCandidateKind,
+			Label:      "set(…)",
+			FilterText: "set",
+			Detail:     "set",
+			Kind:       lang.SetCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "set()",
 				Snippet: fmt.Sprintf("set(${%d})", 0),
@@ -359,9 +361,10 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 	}
 	if strings.HasPrefix("tuple", prefix) {
 		candidates = append(candidates, lang.Candidate{
-			Label:  "tuple([…])",
-			Detail: "tuple",
-			Kind:   lang.TupleCandidateKind,
+			Label:      "tuple([…])",
+			FilterText: "tuple",
+			Detail:     "tuple",
+			Kind:       lang.TupleCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "tuple([])",
 				Snippet: fmt.Sprintf("tuple([ ${%d} ])", 0),
@@ -372,9 +375,10 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 	}
 	if strings.HasPrefix("map", prefix) {
 		candidates = append(candidates, lang.Candidate{
-			Label:  "map(…)",
-			Detail: "map",
-			Kind:   lang.MapCandidateKind,
+			Label:      "map(…)",
+			FilterText: "map",
+			Detail:     "map",
+			Kind:       lang.MapCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "map()",
 				Snippet: fmt.Sprintf("map(${%d})", 0),
@@ -385,9 +389,10 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 	}
 	if strings.HasPrefix("object", prefix) {
 		candidates = append(candidates, lang.Candidate{
-			Label:  "object({…})",
-			Detail: "object",
-			Kind:   lang.ObjectCandidateKind,
+			Label:      "object({…})",
+			FilterText: "object",
+			Detail:     "object",
+			Kind:       lang.ObjectCandidateKind,
 			TextEdit: lang.TextEdit{
 				NewText: "object({\n\n})",
 				Snippet: fmt.Sprintf("object({\n  ${%d:name} = ${%d}\n})", 1, 2),
@@ -401,9 +406,10 @@ func complexTypeDeclarationsAsCandidates(prefix string, editRange hcl.Range) []l
 
 func objectAttributeItemAsCompletionCandidate(editRange hcl.Range) lang.Candidate {
 	return lang.Candidate{
-		Label:  "name = type",
-		Detail: "type",
-		Kind:   lang.AttributeCandidateKind,
+		Label:      "name = type",
+		FilterText: "name",
+		Detail:     "type",
+		Kind:       lang.AttributeCandidateKind,
 		TextEdit: lang.TextEdit{
 			NewText: "name = ",
 			Snippet: fmt.Sprintf("${%d:name} = ", 1),
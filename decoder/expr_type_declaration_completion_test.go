@@ -81,9 +81,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 					},
 				},
 				{
-					Label:  "set(…)",
-					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Label:      "set(…)",
+					FilterText: "set",
+					Detail:     "set",
+					Kind:       lang.SetCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: fmt.Sprintf("set(${%d})", 0),
@@ -148,9 +149,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 10, Byte: 9},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "list(…)",
-					Detail: "list",
-					Kind:   lang.ListCandidateKind,
+					Label:      "list(…)",
+					FilterText: "list",
+					Detail:     "list",
+					Kind:       lang.ListCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "list()",
 						Snippet: fmt.Sprintf("list(${%d})", 0),
@@ -289,9 +291,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 					},
 				},
 				{
-					Label:  "set(…)",
-					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Label:      "set(…)",
+					FilterText: "set",
+					Detail:     "set",
+					Kind:       lang.SetCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -357,9 +360,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 16, Byte: 15},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -450,9 +454,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 					},
 				},
 				{
-					Label:  "set(…)",
-					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Label:      "set(…)",
+					FilterText: "set",
+					Detail:     "set",
+					Kind:       lang.SetCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -492,9 +497,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 					},
 				},
 				{
-					Label:  "set(…)",
-					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Label:      "set(…)",
+					FilterText: "set",
+					Detail:     "set",
+					Kind:       lang.SetCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -532,9 +538,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 29, Byte: 30},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -571,9 +578,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 1, Column: 29, Byte: 30},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -614,9 +622,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 3, Byte: 18},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -675,9 +684,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 					},
 				},
 				{
-					Label:  "set(…)",
-					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Label:      "set(…)",
+					FilterText: "set",
+					Detail:     "set",
+					Kind:       lang.SetCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -719,9 +729,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 					},
 				},
 				{
-					Label:  "set(…)",
-					Detail: "set",
-					Kind:   lang.SetCandidateKind,
+					Label:      "set(…)",
+					FilterText: "set",
+					Detail:     "set",
+					Kind:       lang.SetCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "set()",
 						Snippet: "set(${0})",
@@ -822,9 +833,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 3, Byte: 18},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -852,9 +864,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 3, Column: 3, Byte: 33},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -882,9 +895,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 3, Column: 3, Byte: 34},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -911,9 +925,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 17, Byte: 32},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -956,9 +971,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 3, Column: 3, Byte: 33},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
@@ -986,9 +1002,10 @@ func TestCompletionAtPos_exprTypeDeclaration(t *testing.T) {
 			hcl.Pos{Line: 2, Column: 17, Byte: 32},
 			lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label:  "name = type",
-					Detail: "type",
-					Kind:   lang.AttributeCandidateKind,
+					Label:      "name = type",
+					FilterText: "name",
+					Detail:     "type",
+					Kind:       lang.AttributeCandidateKind,
 					TextEdit: lang.TextEdit{
 						NewText: "name = ",
 						Snippet: fmt.Sprintf("${%d:name} = ", 1),
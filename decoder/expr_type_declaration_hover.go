@@ -29,6 +29,7 @@ func (td TypeDeclaration) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.Hov
 			return &lang.HoverData{
 				Content: lang.Markdown(content),
 				Range:   eType.Range(),
+				Kind:    lang.ValueHoverKind,
 			}
 		}
 	case *hclsyntax.FunctionCallExpr:
@@ -46,6 +47,7 @@ func (td TypeDeclaration) HoverAtPos(ctx context.Context, pos hcl.Pos) *lang.Hov
 			return &lang.HoverData{
 				Content: lang.Markdown(content),
 				Range:   eType.Range(),
+				Kind:    lang.ValueHoverKind,
 			}
 		}
 
@@ -113,6 +115,7 @@ func (td TypeDeclaration) objectHoverAtPos(ctx context.Context, funcExpr *hclsyn
 		return &lang.HoverData{
 			Content: lang.Markdown(content),
 			Range:   objExpr.Range(),
+			Kind:    lang.ValueHoverKind,
 		}
 	}
 
@@ -128,6 +131,7 @@ func (td TypeDeclaration) objectHoverAtPos(ctx context.Context, funcExpr *hclsyn
 			return &lang.HoverData{
 				Content: lang.Markdown(fmt.Sprintf("`%s` = _%s_", rawKey, typ.FriendlyNameForConstraint())),
 				Range:   hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range()),
+				Kind:    lang.ValueHoverKind,
 			}
 		}
 		if item.ValueExpr.Range().ContainsPos(pos) {
@@ -175,6 +179,7 @@ func (td TypeDeclaration) tupleHoverAtPos(ctx context.Context, funcExpr *hclsynt
 		return &lang.HoverData{
 			Content: lang.Markdown(content),
 			Range:   funcExpr.Range(),
+			Kind:    lang.ValueHoverKind,
 		}
 	}
 
@@ -39,6 +39,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -57,6 +58,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -75,6 +77,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
 					End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -93,6 +96,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 1, Column: 23, Byte: 22},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -114,6 +118,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
 					End:      hcl.Pos{Line: 3, Column: 3, Byte: 33},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -135,6 +140,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 18},
 					End:      hcl.Pos{Line: 2, Column: 15, Byte: 30},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -156,6 +162,7 @@ func TestHoverAtPos_exprTypeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 9, Byte: 24},
 					End:      hcl.Pos{Line: 2, Column: 15, Byte: 30},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
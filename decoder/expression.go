@@ -4,7 +4,9 @@
 package decoder
 
 import (
+	"bytes"
 	"context"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/hashicorp/hcl-lang/lang"
@@ -107,6 +109,21 @@ func (tctx *TargetContext) Copy() *TargetContext {
 	return newCtx
 }
 
+// NewExpression returns Expression for the given hcl.Expression
+// and constraint, which provides completion candidates, hover data
+// and semantic tokens without the need to decode a whole file.
+//
+// This is useful e.g. for testing completion of individual constraints.
+// pathCtx may be nil, in which case an empty PathContext is assumed.
+// Constraints such as Reference require PathContext with the files
+// and reference targets to provide any meaningful data.
+func NewExpression(pathCtx *PathContext, expr hcl.Expression, cons schema.Constraint) Expression {
+	if pathCtx == nil {
+		pathCtx = &PathContext{}
+	}
+	return newExpression(pathCtx, expr, cons)
+}
+
 func (d *PathDecoder) newExpression(expr hcl.Expression, cons schema.Constraint) Expression {
 	return newExpression(d.pathCtx, expr, cons)
 }
@@ -139,8 +156,9 @@ func newExpression(pathContext *PathContext, expr hcl.Expression, cons schema.Co
 		}
 	case schema.Keyword:
 		return Keyword{
-			expr: expr,
-			cons: c,
+			expr:    expr,
+			cons:    c,
+			pathCtx: pathContext,
 		}
 	case schema.Reference:
 		return Reference{
@@ -184,6 +202,30 @@ func newExpression(pathContext *PathContext, expr hcl.Expression, cons schema.Co
 			cons:    c,
 			pathCtx: pathContext,
 		}
+	case schema.RefOrLiteral:
+		return OneOf{
+			expr:    expr,
+			cons:    c.OneOf(),
+			pathCtx: pathContext,
+		}
+	case schema.ListOrSingle:
+		return ListOrSingle{
+			expr:    expr,
+			cons:    c,
+			pathCtx: pathContext,
+		}
+	case schema.OwnAttribute:
+		return OwnAttribute{
+			expr:    expr,
+			cons:    c,
+			pathCtx: pathContext,
+		}
+	case schema.MapKeyOf:
+		return MapKeyOf{
+			expr:    expr,
+			cons:    c,
+			pathCtx: pathContext,
+		}
 
 	}
 
@@ -207,6 +249,32 @@ func isEmptyExpression(expr hcl.Expression) bool {
 	return true
 }
 
+// isTrailingDotAfterExpr returns true if the given position
+// immediately follows a dot which trails the given expression,
+// e.g. [ aws_instance.foo. ] where the dot does not appear in the AST.
+func isTrailingDotAfterExpr(pathCtx *PathContext, expr hcl.Expression, pos hcl.Pos) bool {
+	if pos.Byte-expr.Range().End.Byte != 1 {
+		return false
+	}
+
+	file, ok := pathCtx.Files[expr.Range().Filename]
+	if !ok || len(file.Bytes) < pos.Byte {
+		return false
+	}
+
+	return file.Bytes[expr.Range().End.Byte] == '.'
+}
+
+// isNullExpression returns true if given expression
+// is the null literal, i.e. attr = null
+func isNullExpression(expr hcl.Expression) bool {
+	l, ok := expr.(*hclsyntax.LiteralValueExpr)
+	if !ok {
+		return false
+	}
+	return l.Val.IsKnown() && l.Val.IsNull()
+}
+
 // newEmptyExpressionAtPos returns a new "artificial" empty expression
 // which can be used during completion inside of another expression
 // in an empty space which isn't already represented by empty expression.
@@ -253,6 +321,216 @@ func recoverLeftBytes(b []byte, pos hcl.Pos, f func(byteOffset int, r rune) bool
 	return []byte{}
 }
 
+// tokenRangeAtPos returns the range of a (partially typed) token
+// under or immediately before the given position, which is to be
+// replaced by a completion candidate, and the prefix of the token
+// up to the position. A token is an identifier or a traversal,
+// optionally wrapped in quotes, which are part of the range
+// but not part of the prefix.
+//
+// Empty range at the given position is returned
+// if there is no such token.
+func tokenRangeAtPos(src []byte, filename string, pos hcl.Pos) (string, hcl.Range) {
+	rng := hcl.Range{
+		Filename: filename,
+		Start:    pos,
+		End:      pos,
+	}
+	if pos.Byte > len(src) {
+		return "", rng
+	}
+
+	start, startCols := pos.Byte, 0
+	for start > 0 {
+		r, size := utf8.DecodeLastRune(src[:start])
+		if !isTokenRune(r) {
+			break
+		}
+		start -= size
+		startCols++
+	}
+	// identifiers cannot begin with a dash, so
+	// any leading dashes are likely operators
+	for start < pos.Byte && src[start] == '-' {
+		start++
+		startCols--
+	}
+	end, endCols := pos.Byte, 0
+	for end < len(src) {
+		r, size := utf8.DecodeRune(src[end:])
+		if !isTokenRune(r) {
+			break
+		}
+		end += size
+		endCols++
+	}
+	prefix := string(src[start:pos.Byte])
+
+	if start > 0 && src[start-1] == '"' {
+		start--
+		startCols++
+		if end < len(src) && src[end] == '"' {
+			end++
+			endCols++
+		}
+	}
+
+	rng.Start = hcl.Pos{
+		Line:   pos.Line,
+		Column: pos.Column - startCols,
+		Byte:   start,
+	}
+	rng.End = hcl.Pos{
+		Line:   pos.Line,
+		Column: pos.Column + endCols,
+		Byte:   end,
+	}
+
+	return prefix, rng
+}
+
+func isTokenRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) ||
+		r == '_' || r == '-' || r == '.'
+}
+
+// recoverExpressionAtPos recovers an expression ending at given pos
+// which is not present in the AST, such as an element following
+// an empty (invalid) element in a list or set, which the parser
+// does not reach.
+//
+// Only a single whitespace-delimited expression, such as
+// a keyword or a reference, can be recovered.
+func recoverExpressionAtPos(pathCtx *PathContext, filename string, pos hcl.Pos) (hcl.Expression, bool) {
+	file, ok := pathCtx.Files[filename]
+	if !ok || len(file.Bytes) < pos.Byte {
+		return nil, false
+	}
+
+	recoveredBytes := recoverLeftBytes(file.Bytes, pos, func(byteOffset int, r rune) bool {
+		return r == '[' || r == ',' || unicode.IsSpace(r)
+	})
+	if len(recoveredBytes) == 0 {
+		return nil, false
+	}
+	// strip the terminating rune
+	_, size := utf8.DecodeRune(recoveredBytes)
+	exprBytes := recoveredBytes[size:]
+	if len(exprBytes) == 0 {
+		return nil, false
+	}
+
+	startPos := hcl.Pos{
+		Line:   pos.Line,
+		Column: pos.Column - utf8.RuneCount(exprBytes),
+		Byte:   pos.Byte - len(exprBytes),
+	}
+	expr, diags := hclsyntax.ParseExpression(exprBytes, filename, startPos)
+	if diags.HasErrors() {
+		return nil, false
+	}
+
+	return expr, true
+}
+
+// recoverNestedObjectItem attempts to recover an object item
+// (key and value) which hclsyntax failed to parse because its value
+// is an incomplete nested collection, e.g. { foo = { bar = } }.
+//
+// It looks for the outermost bracket opened between from and pos
+// which is left unclosed at pos and parses the value from
+// that bracket to its matching closing bracket.
+func recoverNestedObjectItem(pathCtx *PathContext, filename string, from, pos hcl.Pos) (string, hclsyntax.Expression, bool) {
+	file, ok := pathCtx.Files[filename]
+	if !ok || len(file.Bytes) < pos.Byte || from.Byte > pos.Byte {
+		return "", nil, false
+	}
+	b := file.Bytes
+
+	openOffset := -1
+	depth := 0
+	inString := false
+	for i := from.Byte; i < pos.Byte; i++ {
+		switch c := b[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			if depth == 0 {
+				openOffset = i
+			}
+			depth++
+		case c == '}' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	if depth == 0 || openOffset < 0 {
+		return "", nil, false
+	}
+
+	closeOffset := -1
+	depth = 0
+	inString = false
+	for i := openOffset; i < len(b) && closeOffset < 0; i++ {
+		switch c := b[i]; {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				closeOffset = i
+			}
+		}
+	}
+	if closeOffset < 0 {
+		return "", nil, false
+	}
+
+	keyBytes := bytes.TrimRightFunc(b[from.Byte:openOffset], unicode.IsSpace)
+	if len(keyBytes) == 0 || keyBytes[len(keyBytes)-1] != '=' {
+		return "", nil, false
+	}
+	keyBytes = keyBytes[:len(keyBytes)-1]
+	if idx := bytes.LastIndexFunc(keyBytes, isObjectItemTerminatingRune); idx >= 0 {
+		keyBytes = keyBytes[idx+1:]
+	}
+	key := string(bytes.TrimFunc(keyBytes, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '"'
+	}))
+
+	openPos := hcl.Pos{Line: 1, Column: 1, Byte: openOffset}
+	for _, r := range string(b[:openOffset]) {
+		if r == '\n' {
+			openPos.Line++
+			openPos.Column = 1
+			continue
+		}
+		openPos.Column++
+	}
+
+	expr, _ := hclsyntax.ParseExpression(b[openOffset:closeOffset+1], filename, openPos)
+	if expr == nil || !expr.Range().ContainsPos(pos) {
+		return "", nil, false
+	}
+
+	return key, expr, true
+}
+
 // isObjectItemTerminatingRune returns true if the given rune
 // is considered a left terminating character for an item
 // in hclsyntax.ObjectConsExpr.
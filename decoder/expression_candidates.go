@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
@@ -20,6 +21,13 @@ func (d *PathDecoder) attrValueCompletionAtPos(ctx context.Context, attr *hclsyn
 	candidates := lang.NewCandidates()
 	candidates.IsComplete = true
 
+	if cc, ok := ctx.Value(completionContextKey{}).(*completionContext); ok {
+		cc.cons = schema.Constraint
+		if schemaPath, ok := schemacontext.FromContext(ctx); ok {
+			cc.schemaPath = &schemaPath
+		}
+	}
+
 	if len(schema.CompletionHooks) > 0 {
 		candidates.IsComplete = false
 		candidates.List = append(candidates.List, d.candidatesFromHooks(ctx, attr, schema, outerBodyRng, pos)...)
@@ -38,9 +46,75 @@ func (d *PathDecoder) attrValueCompletionAtPos(ctx context.Context, attr *hclsyn
 		}
 	}
 
+	if schema.IsNullable {
+		for _, candidate := range nullCandidates(attr.Expr, pos) {
+			if uint(count) >= d.maxCandidates {
+				return candidates, nil
+			}
+
+			candidates.List = append(candidates.List, candidate)
+			count++
+		}
+	}
+
 	return candidates, nil
 }
 
+// nullCandidates returns the null keyword candidate
+// if the given expression is empty or a partial (or complete) null
+func nullCandidates(expr hcl.Expression, pos hcl.Pos) []lang.Candidate {
+	editRng := expr.Range()
+	prefix := ""
+
+	switch eType := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		if len(eType.Traversal) != 1 {
+			return []lang.Candidate{}
+		}
+		prefixLen := pos.Byte - eType.Range().Start.Byte
+		if prefixLen < 0 || prefixLen > len(eType.Traversal.RootName()) {
+			return []lang.Candidate{}
+		}
+		prefix = eType.Traversal.RootName()[0:prefixLen]
+	case *hclsyntax.LiteralValueExpr:
+		if isEmptyExpression(eType) {
+			editRng = hcl.Range{
+				Filename: eType.Range().Filename,
+				Start:    pos,
+				End:      pos,
+			}
+			break
+		}
+		if !isNullExpression(eType) {
+			return []lang.Candidate{}
+		}
+		prefixLen := pos.Byte - eType.Range().Start.Byte
+		if prefixLen < 0 || prefixLen > len("null") {
+			return []lang.Candidate{}
+		}
+		prefix = "null"[0:prefixLen]
+	default:
+		return []lang.Candidate{}
+	}
+
+	if !strings.HasPrefix("null", prefix) {
+		return []lang.Candidate{}
+	}
+
+	return []lang.Candidate{
+		{
+			Label:  "null",
+			Detail: "null",
+			Kind:   lang.KeywordCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "null",
+				Snippet: "null",
+				Range:   editRng,
+			},
+		},
+	}
+}
+
 type pathKey struct{}
 
 // WithPath is not intended to be used outside this package
@@ -93,6 +167,31 @@ func MaxCandidatesFromContext(ctx context.Context) (uint, bool) {
 	return mc, ok
 }
 
+type completionContextKey struct{}
+
+// completionContext holds data resolved during completion, so that it
+// can be made available to PathContext.CandidateHook
+type completionContext struct {
+	cons       schema.Constraint
+	schemaPath *schemacontext.SchemaPath
+}
+
+func withCompletionContext(ctx context.Context) (context.Context, *completionContext) {
+	cc := &completionContext{}
+	return context.WithValue(ctx, completionContextKey{}, cc), cc
+}
+
+// CompletionConstraintFromContext returns the constraint
+// of the attribute for which completion candidates were resolved.
+// This is intended to be used within PathContext.CandidateHook.
+func CompletionConstraintFromContext(ctx context.Context) (schema.Constraint, bool) {
+	cc, ok := ctx.Value(completionContextKey{}).(*completionContext)
+	if !ok || cc.cons == nil {
+		return nil, false
+	}
+	return cc.cons, true
+}
+
 func isMultilineTemplateExpr(expr hclsyntax.Expression) bool {
 	t, ok := expr.(*hclsyntax.TemplateExpr)
 	if !ok {
@@ -156,6 +255,7 @@ func (d *PathDecoder) candidatesFromHooks(ctx context.Context, attr *hclsyntax.A
 					},
 					ResolveHook: c.ResolveHook,
 					SortText:    c.SortText,
+					FilterText:  c.FilterText,
 				})
 				count++
 			}
@@ -195,6 +295,17 @@ func candidateKindForType(t cty.Type) lang.CandidateKind {
 	return lang.NilCandidateKind
 }
 
+// candidateKindForConstraint returns the candidate kind for values
+// of the given constraint, if it is type-aware.
+func candidateKindForConstraint(cons schema.Constraint) lang.CandidateKind {
+	if c, ok := cons.(schema.TypeAwareConstraint); ok {
+		if typ, ok := c.ConstraintType(); ok {
+			return candidateKindForType(typ)
+		}
+	}
+	return lang.NilCandidateKind
+}
+
 type snippetGenerator struct {
 	placeholder uint
 }
@@ -5,14 +5,18 @@ package decoder
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"testing"
 	"unicode"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
 )
 
 var (
@@ -99,6 +103,107 @@ func TestRecoverLeftBytes(t *testing.T) {
 	}
 }
 
+func TestTokenRangeAtPos(t *testing.T) {
+	testCases := []struct {
+		src            string
+		pos            hcl.Pos
+		expectedPrefix string
+		expectedRange  hcl.Range
+	}{
+		{
+			`attr = `,
+			hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			"",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			},
+		},
+		{
+			`attr = fo`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			"fo",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			},
+		},
+		{
+			`attr = foobar`,
+			hcl.Pos{Line: 1, Column: 10, Byte: 9},
+			"fo",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+			},
+		},
+		{
+			`attr = var.foo.`,
+			hcl.Pos{Line: 1, Column: 16, Byte: 15},
+			"var.foo.",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+			},
+		},
+		{
+			`attr = -var.fo`,
+			hcl.Pos{Line: 1, Column: 15, Byte: 14},
+			"var.fo",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+				End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+			},
+		},
+		{
+			`attr = "fo"`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			"fo",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			},
+		},
+		{
+			`attr = "fo`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			"fo",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			},
+		},
+		{
+			`attr = föö`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 12},
+			"föö",
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 11, Byte: 12},
+			},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			prefix, rng := tokenRangeAtPos([]byte(tc.src), "test.tf", tc.pos)
+			if prefix != tc.expectedPrefix {
+				t.Fatalf("prefix mismatch!\nexpected: %q\ngiven:    %q\n", tc.expectedPrefix, prefix)
+			}
+			if diff := cmp.Diff(tc.expectedRange, rng); diff != "" {
+				t.Fatalf("unexpected range: %s", diff)
+			}
+		})
+	}
+}
+
 func TestRawObjectKey(t *testing.T) {
 	testCases := []struct {
 		cfg           string
@@ -334,3 +439,34 @@ func TestRawObjectKey_json(t *testing.T) {
 		})
 	}
 }
+
+func TestNewExpression_completion(t *testing.T) {
+	ctx := context.Background()
+	expr, diags := hclsyntax.ParseExpression([]byte(`f`), "test.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	candidates := NewExpression(nil, expr, schema.LiteralType{Type: cty.Bool}).
+		CompletionAtPos(ctx, hcl.Pos{Line: 1, Column: 2, Byte: 1})
+
+	expectedCandidates := []lang.Candidate{
+		{
+			Label:  "false",
+			Detail: "bool",
+			Kind:   lang.BoolCandidateKind,
+			TextEdit: lang.TextEdit{
+				NewText: "false",
+				Snippet: "false",
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 2, Byte: 1},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
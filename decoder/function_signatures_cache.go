@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"sync"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+)
+
+// functionSignaturesCache holds signatures of all functions
+// of a path context, computed on first use.
+type functionSignaturesCache struct {
+	once       sync.Once
+	signatures map[string]schema.FunctionSignature
+}
+
+type functionSignaturesCacheEntry struct {
+	version uint64
+	cache   *functionSignaturesCache
+}
+
+// functionSignaturesCacheFor returns the cache of function signatures
+// for the given path, which is shared across calls for the same
+// (non-zero) version of the path context.
+func (d *Decoder) functionSignaturesCacheFor(path lang.Path, version uint64) *functionSignaturesCache {
+	if version == 0 {
+		return &functionSignaturesCache{}
+	}
+
+	d.functionSigsCacheMu.Lock()
+	defer d.functionSigsCacheMu.Unlock()
+
+	entry, ok := d.functionSigsCache[path]
+	if ok && entry.version == version {
+		return entry.cache
+	}
+
+	if d.functionSigsCache == nil {
+		d.functionSigsCache = make(map[lang.Path]functionSignaturesCacheEntry)
+	}
+	cache := &functionSignaturesCache{}
+	d.functionSigsCache[path] = functionSignaturesCacheEntry{
+		version: version,
+		cache:   cache,
+	}
+	return cache
+}
+
+// functionSignatures returns signatures of all functions
+// of the path context, computed once per version of the path context
+// (or once per PathDecoder if the version is zero).
+//
+// The returned map may be shared and must not be modified.
+func (d *PathDecoder) functionSignatures() map[string]schema.FunctionSignature {
+	if d.functionSigs == nil {
+		return d.pathCtx.functionSignatures()
+	}
+
+	d.functionSigs.once.Do(func() {
+		d.functionSigs.signatures = d.pathCtx.functionSignatures()
+	})
+	return d.functionSigs.signatures
+}
@@ -4,19 +4,37 @@
 package decoder
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/hcl-lang/decoder/internal/ast"
 	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
 )
 
+// HoverAtPosInPath returns hover data for a given position in a file
+// within the given path.
+//
+// Unlike PathDecoder.HoverAtPos, the path reader is made available
+// via context, which allows resolution of references into other paths.
+func (d *Decoder) HoverAtPosInPath(ctx context.Context, path lang.Path, filename string, pos hcl.Pos) (*lang.HoverData, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.HoverAtPos(d.pathContext(ctx, pd), filename, pos)
+}
+
 func (d *PathDecoder) HoverAtPos(ctx context.Context, filename string, pos hcl.Pos) (*lang.HoverData, error) {
 	f, err := d.fileByName(filename)
 	if err != nil {
@@ -25,6 +43,11 @@ func (d *PathDecoder) HoverAtPos(ctx context.Context, filename string, pos hcl.P
 
 	rootBody, err := d.bodyForFileAndPos(filename, f, pos)
 	if err != nil {
+		var unknownFormatErr *UnknownFileFormatError
+		if errors.As(err, &unknownFormatErr) && d.pathCtx.Schema != nil {
+			// JSON body can only be decoded with schema
+			return d.hoverAtPosJSON(ctx, filename, f.Body, d.pathCtx.Schema, pos)
+		}
 		return nil, err
 	}
 
@@ -32,6 +55,8 @@ func (d *PathDecoder) HoverAtPos(ctx context.Context, filename string, pos hcl.P
 		return nil, &NoSchemaError{}
 	}
 
+	ctx = withReferenceTargetValues(ctx, d.decoderCtx.ReferenceTargetValues)
+
 	data, err := d.hoverAtPos(ctx, rootBody, d.pathCtx.Schema, pos)
 	if err != nil {
 		return nil, err
@@ -49,38 +74,43 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 
 	for name, attr := range body.Attributes {
 		if attr.Range().ContainsPos(pos) {
-			var aSchema *schema.AttributeSchema
 			if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
 				ctx = schema.WithActiveSelfRefs(ctx)
 			}
 
-			if bodySchema.Extensions != nil && bodySchema.Extensions.Count && name == "count" {
-				aSchema = schemahelper.CountAttributeSchema()
-			} else if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && name == "for_each" {
-				aSchema = schemahelper.ForEachAttributeSchema()
-			} else {
-				var ok bool
-				aSchema, ok = bodySchema.Attributes[attr.Name]
-				if !ok {
-					if bodySchema.AnyAttribute == nil {
-						return nil, &PositionalError{
-							Filename: filename,
-							Pos:      pos,
-							Msg:      fmt.Sprintf("unknown attribute %q", attr.Name),
-						}
-					}
-					aSchema = bodySchema.AnyAttribute
+			aSchema, ok := schemahelper.AttributeSchema(bodySchema, name)
+			if !ok {
+				if d.decoderCtx.HoverUnknownAttributes && attr.NameRange.ContainsPos(pos) {
+					return &lang.HoverData{
+						Content: hoverContentForUnknownAttribute(name, bodySchema),
+						Range:   attr.Range(),
+						Kind:    lang.AttributeHoverKind,
+					}, nil
+				}
+				return nil, &PositionalError{
+					Filename: filename,
+					Pos:      pos,
+					Msg:      fmt.Sprintf("unknown attribute %q", attr.Name),
 				}
 			}
 
 			if attr.NameRange.ContainsPos(pos) {
+				content := hoverContentForAttribute(name, aSchema)
 				return &lang.HoverData{
-					Content: hoverContentForAttribute(name, aSchema),
+					Content: d.hoverContentWithSourceComment(content, filename, attr.NameRange.Start),
 					Range:   attr.Range(),
+					Kind:    lang.AttributeHoverKind,
 				}, nil
 			}
 
 			if attr.Expr.Range().ContainsPos(pos) {
+				if isNullExpression(attr.Expr) {
+					return &lang.HoverData{
+						Content: lang.Markdown("_null_"),
+						Range:   attr.Expr.Range(),
+						Kind:    lang.ValueHoverKind,
+					}, nil
+				}
 				return d.newExpression(attr.Expr, aSchema.Constraint).HoverAtPos(ctx, pos), nil
 			}
 		}
@@ -88,7 +118,7 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 
 	for _, block := range body.Blocks {
 		if block.Range().ContainsPos(pos) {
-			blockSchema, ok := bodySchema.Blocks[block.Type]
+			blockSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 			if !ok {
 				return nil, &PositionalError{
 					Filename: filename,
@@ -98,9 +128,11 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 			}
 
 			if block.TypeRange.ContainsPos(pos) {
+				content := d.hoverContentForBlock(schemahelper.CanonicalBlockType(bodySchema, block.Type), blockSchema)
 				return &lang.HoverData{
-					Content: d.hoverContentForBlock(block.Type, blockSchema),
+					Content: d.hoverContentWithSourceComment(content, filename, block.TypeRange.Start),
 					Range:   block.TypeRange,
+					Kind:    lang.BlockHoverKind,
 				}, nil
 			}
 
@@ -115,8 +147,9 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 					}
 
 					return &lang.HoverData{
-						Content: d.hoverContentForLabel(i, block, blockSchema),
+						Content: d.hoverContentForLabel(i, block.AsHCLBlock(), blockSchema),
 						Range:   labelRange,
+						Kind:    lang.BlockHoverKind,
 					}, nil
 				}
 			}
@@ -144,12 +177,102 @@ func (d *PathDecoder) hoverAtPos(ctx context.Context, body *hclsyntax.Body, body
 	}
 }
 
-func (d *PathDecoder) hoverContentForLabel(i int, block *hclsyntax.Block, bSchema *schema.BlockSchema) lang.MarkupContent {
+// hoverAtPosJSON returns hover data for a given position in a JSON body.
+//
+// Since JSON bodies are decoded via schema, only keys and values
+// which correspond to a known attribute or block can be hovered.
+func (d *PathDecoder) hoverAtPosJSON(ctx context.Context, filename string, body hcl.Body, bodySchema *schema.BodySchema, pos hcl.Pos) (*lang.HoverData, error) {
+	if bodySchema == nil {
+		return nil, &UnknownFileFormatError{Filename: filename}
+	}
+
+	content := ast.DecodeBody(body, bodySchema)
+
+	for name, attr := range content.Attributes {
+		if !attr.Range.ContainsPos(pos) {
+			continue
+		}
+
+		aSchema, ok := schemahelper.AttributeSchema(bodySchema, name)
+		if !ok {
+			continue
+		}
+
+		if attr.NameRange.ContainsPos(pos) {
+			return &lang.HoverData{
+				Content: hoverContentForAttribute(name, aSchema),
+				Range:   attr.Range,
+				Kind:    lang.AttributeHoverKind,
+			}, nil
+		}
+
+		if attr.Expr.Range().ContainsPos(pos) {
+			return &lang.HoverData{
+				Content: lang.Markdown(hoverContentForJSONValue(attr.Expr, aSchema.Constraint)),
+				Range:   attr.Expr.Range(),
+				Kind:    lang.ValueHoverKind,
+			}, nil
+		}
+	}
+
+	for _, block := range content.Blocks {
+		blockSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
+		if !ok {
+			continue
+		}
+
+		if block.TypeRange.ContainsPos(pos) {
+			return &lang.HoverData{
+				Content: d.hoverContentForBlock(schemahelper.CanonicalBlockType(bodySchema, block.Type), blockSchema),
+				Range:   block.TypeRange,
+				Kind:    lang.BlockHoverKind,
+			}, nil
+		}
+
+		for i, labelRange := range block.LabelRanges {
+			if labelRange.ContainsPos(pos) && i < len(blockSchema.Labels) {
+				return &lang.HoverData{
+					Content: d.hoverContentForLabel(i, block.Block, blockSchema),
+					Range:   labelRange,
+					Kind:    lang.BlockHoverKind,
+				}, nil
+			}
+		}
+
+		if block.Range.ContainsPos(pos) {
+			mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.Block, blockSchema)
+			return d.hoverAtPosJSON(ctx, filename, block.Body, mergedSchema, pos)
+		}
+	}
+
+	// Position outside of any attribute or block known to the schema
+	return nil, &PositionalError{
+		Filename: filename,
+		Pos:      pos,
+		Msg:      "position outside of any attribute name, value or block",
+	}
+}
+
+// hoverContentForJSONValue returns hover content describing the type
+// of a JSON value, falling back to the constraint if the value cannot
+// be evaluated without further context.
+func hoverContentForJSONValue(expr hcl.Expression, cons schema.Constraint) string {
+	val, diags := expr.Value(nil)
+	if !diags.HasErrors() && val.Type() != cty.DynamicPseudoType {
+		return fmt.Sprintf("_%s_", val.Type().FriendlyName())
+	}
+	if cons != nil {
+		return fmt.Sprintf("_%s_", cons.FriendlyName())
+	}
+	return fmt.Sprintf("_%s_", cty.DynamicPseudoType.FriendlyName())
+}
+
+func (d *PathDecoder) hoverContentForLabel(i int, block *hcl.Block, bSchema *schema.BlockSchema) lang.MarkupContent {
 	value := block.Labels[i]
 	labelSchema := bSchema.Labels[i]
 
 	if labelSchema.IsDepKey {
-		bs, _, result := schemahelper.NewBlockSchema(bSchema).DependentBodySchema(block.AsHCLBlock())
+		bs, _, result := schemahelper.NewBlockSchema(bSchema).DependentBodySchema(block)
 		if result == schemahelper.LookupSuccessful || result == schemahelper.LookupPartiallySuccessful {
 			content := fmt.Sprintf("`%s`", value)
 			if bs.Detail != "" {
@@ -192,6 +315,9 @@ func (d *PathDecoder) hoverContentForBlock(bType string, schema *schema.BlockSch
 	if schema.Description.Value != "" {
 		value += fmt.Sprintf("\n\n%s", schema.Description.Value)
 	}
+	if schema.ReplacedBy != "" {
+		value += replacedByHint(schema.ReplacedBy)
+	}
 
 	if schema.Body != nil && schema.Body.HoverURL != "" {
 		u, err := d.docsURL(schema.Body.HoverURL, "documentHover")
@@ -207,6 +333,75 @@ func (d *PathDecoder) hoverContentForBlock(bType string, schema *schema.BlockSch
 	}
 }
 
+// hoverContentWithSourceComment appends any line comments placed
+// directly above the item starting at the given position to the content,
+// if enabled via DecoderContext.
+func (d *PathDecoder) hoverContentWithSourceComment(content lang.MarkupContent, filename string, pos hcl.Pos) lang.MarkupContent {
+	if !d.decoderCtx.HoverIncludeSourceComments {
+		return content
+	}
+
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return content
+	}
+
+	comment := leadingLineComment(f.Bytes, filename, pos)
+	if comment != "" {
+		content.Value += "\n\n" + comment
+	}
+
+	return content
+}
+
+// leadingLineComment returns text of line comments (# or //) placed
+// on the lines directly above the given position, with comment markers
+// stripped. Comments trailing other configuration on the same line
+// are ignored as they belong to that configuration.
+func leadingLineComment(src []byte, filename string, pos hcl.Pos) string {
+	tokens, _ := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+
+	itemIdx := -1
+	for i, t := range tokens {
+		if t.Range.Start.Byte == pos.Byte {
+			itemIdx = i
+			break
+		}
+	}
+
+	lines := make([]string, 0)
+	line := pos.Line
+	for i := itemIdx - 1; i >= 0; i-- {
+		t := tokens[i]
+		if t.Type != hclsyntax.TokenComment || t.Range.End.Line != line {
+			break
+		}
+
+		text := string(t.Bytes)
+		marker := "#"
+		if strings.HasPrefix(text, "//") {
+			marker = "//"
+		} else if !strings.HasPrefix(text, "#") {
+			// block comments are not supported
+			break
+		}
+
+		lineStart := bytes.LastIndexByte(src[:t.Range.Start.Byte], '\n') + 1
+		if len(bytes.TrimSpace(src[lineStart:t.Range.Start.Byte])) > 0 {
+			// trailing comment of previous configuration
+			break
+		}
+
+		text = strings.TrimPrefix(text, marker)
+		text = strings.TrimRight(text, "\r\n")
+		text = strings.TrimPrefix(text, " ")
+		lines = append([]string{text}, lines...)
+		line = t.Range.Start.Line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func hoverContentForReferenceTarget(ctx context.Context, ref reference.Target, pos hcl.Pos) (string, error) {
 	content := fmt.Sprintf("`%s`", ref.Address(ctx, pos))
 
@@ -226,9 +421,36 @@ func hoverContentForReferenceTarget(ctx context.Context, ref reference.Target, p
 		content += fmt.Sprintf("\n\n%s", ref.Description.Value)
 	}
 
+	if referenceTargetValuesFromContext(ctx) {
+		if value, ok := hoverContentForValue(ref.Value); ok {
+			content += "\n\nValue: " + value
+		}
+	}
+
+	if source, ok := ref.Metadata[reference.MetadataKeySource]; ok && source != "" {
+		content += fmt.Sprintf("\n\nSource: `%s`", source)
+	}
+	if docsURL, ok := ref.Metadata[reference.MetadataKeyDocsURL]; ok && docsURL != "" {
+		content += fmt.Sprintf("\n\n[Documentation](%s)", docsURL)
+	}
+
 	return content, nil
 }
 
+// hoverContentForValue renders the given value as HCL,
+// unless it is not wholly known or it is marked (e.g. as sensitive).
+func hoverContentForValue(val cty.Value) (string, bool) {
+	if val == cty.NilVal || !val.IsWhollyKnown() || val.ContainsMarked() {
+		return "", false
+	}
+
+	value := strings.TrimSpace(string(hclwrite.TokensForValue(val).Bytes()))
+	if strings.Contains(value, "\n") {
+		return fmt.Sprintf("\n```\n%s\n```", value), true
+	}
+	return fmt.Sprintf("`%s`", value), true
+}
+
 func hoverContentForType(attrType cty.Type, nestingLvl int) (string, error) {
 	if attrType.IsPrimitiveType() || attrType == cty.DynamicPseudoType {
 		if nestingLvl > 0 {
@@ -84,6 +84,126 @@ func TestDecoder_HoverAtPos_json(t *testing.T) {
 	}
 }
 
+func TestDecoder_HoverAtPos_jsonWithSchema(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"customblock": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				Description: lang.PlainText("custom block"),
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"num_attr": {
+							Constraint:  schema.LiteralType{Type: cty.Number},
+							IsOptional:  true,
+							Description: lang.PlainText("number attribute"),
+						},
+					},
+				},
+			},
+		},
+	}
+	f, pDiags := json.Parse([]byte(`{
+  "customblock": {
+    "label1": {
+      "num_attr": 42,
+      "unknown": true
+    }
+  }
+}`), "test.tf.json")
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf.json": f,
+		},
+	})
+
+	testCases := []struct {
+		name         string
+		pos          hcl.Pos
+		expectedData *lang.HoverData
+	}{
+		{
+			"block type",
+			hcl.Pos{Line: 2, Column: 6, Byte: 7},
+			&lang.HoverData{
+				Content: lang.Markdown("**customblock** _Block_\n\ncustom block"),
+				Range: hcl.Range{
+					Filename: "test.tf.json",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+					End:      hcl.Pos{Line: 2, Column: 16, Byte: 17},
+				},
+				Kind: lang.BlockHoverKind,
+			},
+		},
+		{
+			"label",
+			hcl.Pos{Line: 3, Column: 8, Byte: 28},
+			&lang.HoverData{
+				Content: lang.Markdown("\"label1\" (name)"),
+				Range: hcl.Range{
+					Filename: "test.tf.json",
+					Start:    hcl.Pos{Line: 3, Column: 5, Byte: 25},
+					End:      hcl.Pos{Line: 3, Column: 13, Byte: 33},
+				},
+				Kind: lang.BlockHoverKind,
+			},
+		},
+		{
+			"attribute name",
+			hcl.Pos{Line: 4, Column: 10, Byte: 46},
+			&lang.HoverData{
+				Content: lang.Markdown("**num_attr** _optional, number_\n\nnumber attribute"),
+				Range: hcl.Range{
+					Filename: "test.tf.json",
+					Start:    hcl.Pos{Line: 4, Column: 7, Byte: 43},
+					End:      hcl.Pos{Line: 4, Column: 21, Byte: 57},
+				},
+				Kind: lang.AttributeHoverKind,
+			},
+		},
+		{
+			"attribute value",
+			hcl.Pos{Line: 4, Column: 20, Byte: 56},
+			&lang.HoverData{
+				Content: lang.Markdown("_number_"),
+				Range: hcl.Range{
+					Filename: "test.tf.json",
+					Start:    hcl.Pos{Line: 4, Column: 19, Byte: 55},
+					End:      hcl.Pos{Line: 4, Column: 21, Byte: 57},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			ctx := context.Background()
+			data, err := d.HoverAtPos(ctx, "test.tf.json", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedData, data); diff != "" {
+				t.Fatalf("hover data mismatch: %s", diff)
+			}
+		})
+	}
+
+	ctx := context.Background()
+	_, err := d.HoverAtPos(ctx, "test.tf.json", hcl.Pos{Line: 5, Column: 10, Byte: 67})
+	posErr := &PositionalError{}
+	if !errors.As(err, &posErr) {
+		t.Fatalf("expected PositionalError for unknown attribute, given: %#v", err)
+	}
+}
+
 func TestDecoder_HoverAtPos_nilBodySchema(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -130,6 +250,7 @@ func TestDecoder_HoverAtPos_nilBodySchema(t *testing.T) {
 						Byte:   8,
 					},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -183,6 +304,7 @@ func TestDecoder_HoverAtPos_nilBodySchema(t *testing.T) {
 						Byte:   17,
 					},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -236,6 +358,7 @@ func TestDecoder_HoverAtPos_nilBodySchema(t *testing.T) {
 						Byte:   46,
 					},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 	}
@@ -315,6 +438,80 @@ func TestDecoder_HoverAtPos_unknownAttribute(t *testing.T) {
 	}
 }
 
+func TestDecoder_HoverAtPos_unknownAttributeEnabled(t *testing.T) {
+	dirPath := t.TempDir()
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"count":       {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+			"description": {Constraint: schema.LiteralType{Type: cty.String}, IsOptional: true},
+		},
+	}
+	cfg := `cuont = 42
+blablah = 1
+`
+
+	testCases := []struct {
+		name          string
+		pos           hcl.Pos
+		expectedHover *lang.HoverData
+	}{
+		{
+			"close to known attribute",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			&lang.HoverData{
+				Content: lang.Markdown("**cuont** _unknown attribute_\n\nDid you mean `count`?"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+				},
+				Kind: lang.AttributeHoverKind,
+			},
+		},
+		{
+			"far from any known attribute",
+			hcl.Pos{Line: 2, Column: 3, Byte: 13},
+			&lang.HoverData{
+				Content: lang.Markdown("**blablah** _unknown attribute_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 1, Byte: 11},
+					End:      hcl.Pos{Line: 2, Column: 12, Byte: 22},
+				},
+				Kind: lang.AttributeHoverKind,
+			},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Schema: bodySchema,
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+					},
+				},
+			})
+			decoderCtx := NewDecoderContext()
+			decoderCtx.HoverUnknownAttributes = true
+			d.SetContext(decoderCtx)
+
+			ctx := context.Background()
+			data, err := d.HoverAtPosInPath(ctx, lang.Path{Path: dirPath}, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedHover, data); diff != "" {
+				t.Fatalf("hover data mismatch: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_HoverAtPos_unknownBlock(t *testing.T) {
 	resourceLabelSchema := []*schema.LabelSchema{
 		{Name: "type"},
@@ -440,6 +637,251 @@ func TestDecoder_HoverAtPos_invalidBlockPositions(t *testing.T) {
 	}
 }
 
+func TestDecoder_HoverAtPos_blockAlias(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Aliases:     []string{"legacyblock"},
+				Description: lang.PlainText("My special block"),
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"num_attr": {Constraint: schema.LiteralType{Type: cty.Number}},
+					},
+				},
+			},
+		},
+	}
+	testConfig := []byte(`legacyblock {
+  num_attr = 4
+}
+`)
+	testCases := []struct {
+		name         string
+		pos          hcl.Pos
+		expectedData *lang.HoverData
+	}{
+		{
+			"block type displays canonical type",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			&lang.HoverData{
+				Content: lang.Markdown("**myblock** _Block_\n\nMy special block"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+					End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+				},
+				Kind: lang.BlockHoverKind,
+			},
+		},
+		{
+			"attribute within aliased block",
+			hcl.Pos{Line: 2, Column: 5, Byte: 18},
+			&lang.HoverData{
+				Content: lang.Markdown("**num_attr** _number_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 16},
+					End:      hcl.Pos{Line: 2, Column: 15, Byte: 28},
+				},
+				Kind: lang.AttributeHoverKind,
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig(testConfig, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			ctx := context.Background()
+			data, err := d.HoverAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedData, data, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_HoverAtPos_partialParse(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+				},
+			},
+			"variable": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"default": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+				},
+			},
+		},
+	}
+	testConfig := []byte(`resource "aws_instance" "web" {
+  ami = 
+}
+
+variable "name" {
+  default = "foo"
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testConfig, "test.tf", hcl.InitialPos)
+	if !pDiags.HasErrors() {
+		t.Fatal("expected parser to report malformed block")
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+	data, err := d.HoverAtPos(ctx, "test.tf", hcl.Pos{Line: 6, Column: 5, Byte: 66})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedData := &lang.HoverData{
+		Content: lang.Markdown("**default** _optional, string_"),
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 6, Column: 3, Byte: 64},
+			End:      hcl.Pos{Line: 6, Column: 18, Byte: 79},
+		},
+		Kind: lang.AttributeHoverKind,
+	}
+	if diff := cmp.Diff(expectedData, data, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected hover data: %s", diff)
+	}
+}
+
+func TestDecoder_HoverAtPos_noTrailingNewline(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+				},
+			},
+		},
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				IsOptional: true,
+				Constraint: schema.LiteralType{Type: cty.String},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		cfg          string
+		pos          hcl.Pos
+		eofPos       hcl.Pos
+		expectedData *lang.HoverData
+	}{
+		{
+			"last attribute",
+			`name = "foo"`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			hcl.Pos{Line: 1, Column: 13, Byte: 12},
+			&lang.HoverData{
+				Content: lang.Markdown("_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+					End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"last attribute of unterminated block",
+			`resource "aws_instance" "web" {
+  ami = "foo"`,
+			hcl.Pos{Line: 2, Column: 13, Byte: 44},
+			hcl.Pos{Line: 2, Column: 14, Byte: 45},
+			&lang.HoverData{
+				Content: lang.Markdown("_string_"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 9, Byte: 40},
+					End:      hcl.Pos{Line: 2, Column: 14, Byte: 45},
+				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			ctx := context.Background()
+			data, err := d.HoverAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedData, data, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("unexpected hover data: %s", diff)
+			}
+
+			// cursor at the very end of the file points past any token
+			_, err = d.HoverAtPos(ctx, "test.tf", tc.eofPos)
+			posErr := &PositionalError{}
+			if !errors.As(err, &posErr) {
+				t.Fatalf("expected PositionalError at end of file, given: %#v", err)
+			}
+		})
+	}
+}
+
 func TestDecoder_HoverAtPos_rightHandSide(t *testing.T) {
 	resourceLabelSchema := []*schema.LabelSchema{
 		{Name: "type"},
@@ -488,12 +930,119 @@ func TestDecoder_HoverAtPos_rightHandSide(t *testing.T) {
 			Start:    hcl.Pos{Line: 2, Column: 14, Byte: 29},
 			End:      hcl.Pos{Line: 2, Column: 20, Byte: 35},
 		},
+		Kind: lang.ValueHoverKind,
+	}
+	if diff := cmp.Diff(expectedData, data, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("hover data mismatch: %s", diff)
+	}
+}
+
+func TestDecoder_HoverAtPos_null(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.LiteralType{Type: cty.List(cty.String)},
+				IsNullable: true,
+			},
+		},
+	}
+	testConfig := []byte(`attr = null
+`)
+
+	f, _ := hclsyntax.ParseConfig(testConfig, "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+	data, err := d.HoverAtPos(ctx, "test.tf", hcl.Pos{
+		Line:   1,
+		Column: 10,
+		Byte:   9,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedData := &lang.HoverData{
+		Content: lang.Markdown("_null_"),
+		Range: hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+			End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+		},
+		Kind: lang.ValueHoverKind,
 	}
 	if diff := cmp.Diff(expectedData, data, ctydebug.CmpOptions); diff != "" {
 		t.Fatalf("hover data mismatch: %s", diff)
 	}
 }
 
+func TestDecoder_HoverAtPos_replacedBy(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"old_attr": {
+				Constraint:   schema.LiteralType{Type: cty.String},
+				IsOptional:   true,
+				IsDeprecated: true,
+				ReplacedBy:   "new_attr",
+				Description:  lang.PlainText("Old attribute"),
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"old_block": {
+				IsDeprecated: true,
+				ReplacedBy:   "new_block",
+			},
+		},
+	}
+	testConfig := []byte(`old_attr = "foo"
+old_block {
+}
+`)
+
+	f, _ := hclsyntax.ParseConfig(testConfig, "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	testCases := []struct {
+		name            string
+		pos             hcl.Pos
+		expectedContent lang.MarkupContent
+	}{
+		{
+			"attribute",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			lang.Markdown("**old_attr** _optional, string_\n\nOld attribute\n\n**Deprecated**: use `new_attr` instead"),
+		},
+		{
+			"block",
+			hcl.Pos{Line: 2, Column: 3, Byte: 19},
+			lang.Markdown("**old_block** _Block_\n\n**Deprecated**: use `new_block` instead"),
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			ctx := context.Background()
+			data, err := d.HoverAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedContent, data.Content); diff != "" {
+				t.Fatalf("hover content mismatch: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_HoverAtPos_basic(t *testing.T) {
 	resourceLabelSchema := []*schema.LabelSchema{
 		{Name: "type", IsDepKey: true},
@@ -583,6 +1132,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 29},
 					End:      hcl.Pos{Line: 2, Column: 20, Byte: 46},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -595,6 +1145,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 					Start:    hcl.Pos{Line: 4, Column: 3, Byte: 64},
 					End:      hcl.Pos{Line: 4, Column: 19, Byte: 80},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -607,6 +1158,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
 					End:      hcl.Pos{Line: 1, Column: 8, Byte: 7},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -619,6 +1171,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
 					End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -631,6 +1184,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 					Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
 					End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -643,6 +1197,7 @@ func TestDecoder_HoverAtPos_basic(t *testing.T) {
 					Start:    hcl.Pos{Line: 6, Column: 4, Byte: 102},
 					End:      hcl.Pos{Line: 6, Column: 20, Byte: 118},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 	}
@@ -744,6 +1299,7 @@ Sushi, the Rolls-Rice of Japanese cuisine
 						Byte:   15,
 					},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -775,6 +1331,7 @@ Sushi, the Rolls-Rice of Japanese cuisine
 						Byte:   15,
 					},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -810,6 +1367,7 @@ My food block
 						Byte:   7,
 					},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 	}
@@ -836,6 +1394,177 @@ My food block
 	}
 }
 
+func TestDecoder_HoverAtPos_sourceComments(t *testing.T) {
+	dirPath := t.TempDir()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"foo": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+						"bar": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+					},
+				},
+			},
+		},
+	}
+	cfg := `# The block
+# documents itself
+myblock "first" {
+  // number of foos
+  foo = 42 # trailing comment
+  bar = 1
+}
+`
+
+	testCases := []struct {
+		name            string
+		includeComments bool
+		pos             hcl.Pos
+		expectedContent lang.MarkupContent
+	}{
+		{
+			"block with comment",
+			true,
+			hcl.Pos{Line: 3, Column: 2, Byte: 32},
+			lang.Markdown("**myblock** _Block_\n\nThe block\ndocuments itself"),
+		},
+		{
+			"attribute with comment",
+			true,
+			hcl.Pos{Line: 5, Column: 4, Byte: 72},
+			lang.Markdown("**foo** _optional, number_\n\nnumber of foos"),
+		},
+		{
+			"attribute after trailing comment",
+			true,
+			hcl.Pos{Line: 6, Column: 4, Byte: 102},
+			lang.Markdown("**bar** _optional, number_"),
+		},
+		{
+			"comments disabled",
+			false,
+			hcl.Pos{Line: 5, Column: 4, Byte: 72},
+			lang.Markdown("**foo** _optional, number_"),
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Schema: bodySchema,
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+					},
+				},
+			})
+			decoderCtx := NewDecoderContext()
+			decoderCtx.HoverIncludeSourceComments = tc.includeComments
+			d.SetContext(decoderCtx)
+
+			ctx := context.Background()
+			data, err := d.HoverAtPosInPath(ctx, lang.Path{Path: dirPath}, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedContent, data.Content); diff != "" {
+				t.Fatalf("hover content mismatch: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_HoverAtPos_referenceTargetValues(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"region": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				Address: &schema.AttributeAddrSchema{
+					Steps: schema.Address{
+						schema.AttrNameStep{},
+					},
+					AsExprType: true,
+				},
+			},
+			"ref": {
+				Constraint: schema.Reference{OfType: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+	cfg := `region = "us-east-1"
+ref = region
+`
+	dirPath := t.TempDir()
+
+	testCases := []struct {
+		name            string
+		targetValues    bool
+		expectedContent lang.MarkupContent
+	}{
+		{
+			"values enabled",
+			true,
+			lang.Markdown("`region`\n_string_\n\nValue: `\"us-east-1\"`"),
+		},
+		{
+			"values disabled",
+			false,
+			lang.Markdown("`region`\n_string_"),
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			pathCtx := &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			}
+
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: pathCtx,
+				},
+			})
+			decoderCtx := NewDecoderContext()
+			decoderCtx.ReferenceTargetValues = tc.targetValues
+			d.SetContext(decoderCtx)
+
+			pd, err := d.Path(lang.Path{Path: dirPath})
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceTargets, err = pd.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceOrigins, err = pd.CollectReferenceOrigins()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			data, err := d.HoverAtPosInPath(ctx, lang.Path{Path: dirPath}, "test.tf", hcl.Pos{Line: 2, Column: 8, Byte: 28})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedContent, data.Content); diff != "" {
+				t.Fatalf("hover content mismatch: %s", diff)
+			}
+		})
+	}
+}
+
 func TestDecoder_HoverAtPos_typeDeclaration(t *testing.T) {
 	resourceLabelSchema := []*schema.LabelSchema{
 		{Name: "name", IsDepKey: true},
@@ -877,6 +1606,7 @@ func TestDecoder_HoverAtPos_typeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 10, Byte: 27},
 					End:      hcl.Pos{Line: 2, Column: 16, Byte: 33},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -892,6 +1622,7 @@ func TestDecoder_HoverAtPos_typeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 10, Byte: 27},
 					End:      hcl.Pos{Line: 2, Column: 22, Byte: 39},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 		{
@@ -909,6 +1640,7 @@ func TestDecoder_HoverAtPos_typeDeclaration(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 10, Byte: 27},
 					End:      hcl.Pos{Line: 4, Column: 5, Byte: 56},
 				},
+				Kind: lang.ValueHoverKind,
 			},
 		},
 	}
@@ -979,6 +1711,7 @@ func TestDecoder_HoverAtPos_extensions_count(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
 					End:      hcl.Pos{Line: 2, Column: 12, Byte: 33},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -1043,6 +1776,7 @@ func TestDecoder_HoverAtPos_extensions_count(t *testing.T) {
 					Start:    hcl.Pos{Line: 3, Column: 11, Byte: 44},
 					End:      hcl.Pos{Line: 3, Column: 22, Byte: 55},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -1076,6 +1810,48 @@ func TestDecoder_HoverAtPos_extensions_count(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 11, Byte: 32},
 					End:      hcl.Pos{Line: 2, Column: 12, Byte: 33},
 				},
+				Kind: lang.ValueHoverKind,
+			},
+		},
+		{
+			"explicitly declared count attribute name",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"myblock": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type", IsDepKey: true},
+							{Name: "name"},
+						},
+						Body: &schema.BodySchema{
+							Extensions: &schema.BodyExtensions{
+								Count: true,
+							},
+							Attributes: map[string]*schema.AttributeSchema{
+								"count": {
+									IsOptional:  true,
+									Constraint:  schema.LiteralType{Type: cty.String},
+									Description: lang.PlainText("Explicit count"),
+								},
+							},
+						},
+					},
+				},
+			},
+			reference.Targets{},
+			reference.Origins{},
+			`myblock "foo" "bar" {
+  count = "one"
+}
+`,
+			hcl.Pos{Line: 2, Column: 5, Byte: 24},
+			&lang.HoverData{
+				Content: lang.Markdown("**count** _optional, string_\n\nExplicit count"),
+				Range: hcl.Range{
+					Filename: "test.tf",
+					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
+					End:      hcl.Pos{Line: 2, Column: 16, Byte: 37},
+				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 	}
@@ -1157,6 +1933,7 @@ func TestDecoder_HoverAtPos_extension_for_each(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
 					End:      hcl.Pos{Line: 4, Column: 3, Byte: 42},
 				},
+				Kind: lang.AttributeHoverKind,
 			},
 		},
 		{
@@ -1259,6 +2036,7 @@ func TestDecoder_HoverAtPos_extension_for_each(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 8, Byte: 29},
 					End:      hcl.Pos{Line: 2, Column: 16, Byte: 37},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -1361,6 +2139,7 @@ func TestDecoder_HoverAtPos_extension_for_each(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 8, Byte: 29},
 					End:      hcl.Pos{Line: 2, Column: 18, Byte: 39},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 	}
@@ -1448,6 +2227,7 @@ func TestDecoder_HoverAtPos_extensions_dynamic(t *testing.T) {
 					Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
 					End:      hcl.Pos{Line: 2, Column: 10, Byte: 31},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 		{
@@ -1509,6 +2289,7 @@ func TestDecoder_HoverAtPos_extensions_dynamic(t *testing.T) {
 					Start:    hcl.Pos{Line: 5, Column: 9, Byte: 98},
 					End:      hcl.Pos{Line: 5, Column: 16, Byte: 105},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 
@@ -1580,6 +2361,7 @@ func TestDecoder_HoverAtPos_extensions_dynamic(t *testing.T) {
 					Start:    hcl.Pos{Line: 4, Column: 7, Byte: 60},
 					End:      hcl.Pos{Line: 4, Column: 14, Byte: 67},
 				},
+				Kind: lang.BlockHoverKind,
 			},
 		},
 	}
@@ -1684,6 +2466,7 @@ variable "name" {
 					Start:    hcl.Pos{Line: 3, Column: 14, Byte: 54},
 					End:      hcl.Pos{Line: 3, Column: 22, Byte: 62},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 		{
@@ -1751,6 +2534,7 @@ variable "name" {
 					Start:    hcl.Pos{Line: 3, Column: 11, Byte: 52},
 					End:      hcl.Pos{Line: 3, Column: 19, Byte: 60},
 				},
+				Kind: lang.ReferenceHoverKind,
 			},
 		},
 	}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/internal/suggest"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+)
+
+func hoverContentForUnknownAttribute(name string, bodySchema *schema.BodySchema) lang.MarkupContent {
+	value := fmt.Sprintf("**%s** _unknown attribute_", name)
+
+	if closestName, ok := closestAttributeName(name, bodySchema); ok {
+		value += fmt.Sprintf("\n\nDid you mean `%s`?", closestName)
+	}
+
+	return lang.Markdown(value)
+}
+
+// closestAttributeName returns the attribute name declared in the body
+// schema closest to the given name, as long as it is reasonably close.
+func closestAttributeName(name string, bodySchema *schema.BodySchema) (string, bool) {
+	names := make([]string, 0, len(bodySchema.Attributes))
+	for aName := range bodySchema.Attributes {
+		names = append(names, aName)
+	}
+	return suggest.ClosestName(name, names)
+}
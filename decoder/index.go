@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/decoder/internal/ast"
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Index represents reference targets, reference origins
+// and symbols collected from all files within a path.
+type Index struct {
+	// Targets is equivalent to the output of PathDecoder.CollectReferenceTargets
+	Targets reference.Targets
+
+	// Origins is equivalent to the output of PathDecoder.CollectReferenceOrigins
+	Origins reference.Origins
+
+	// Symbols represents a hierarchy of symbols within all files
+	Symbols []Symbol
+}
+
+// BuildIndex collects reference targets, reference origins
+// and symbols within the given path in a single pass over the files.
+//
+// This is more efficient than collecting targets, origins
+// and symbols separately, as each body is only decoded once.
+func (d *Decoder) BuildIndex(path lang.Path) (*Index, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.buildIndex()
+}
+
+func (d *PathDecoder) buildIndex() (*Index, error) {
+	if d.pathCtx.Schema == nil {
+		// unable to collect reference targets or origins without schema
+		return nil, &NoSchemaError{}
+	}
+
+	ctx := context.Background()
+
+	idx := &Index{
+		Targets: make(reference.Targets, 0),
+		Origins: make(reference.Origins, 0),
+		Symbols: make([]Symbol, 0),
+	}
+	impliedOrigins := make([]schema.ImpliedOrigin, 0)
+
+	for _, filename := range d.filenames() {
+		f, err := d.fileByName(filename)
+		if err != nil {
+			// skip unparseable file
+			continue
+		}
+
+		bIdx := d.indexBody(ctx, f.Body, nil, d.pathCtx.Schema)
+		idx.Targets = append(idx.Targets, bIdx.targets...)
+		idx.Origins = append(idx.Origins, bIdx.origins...)
+		idx.Symbols = append(idx.Symbols, bIdx.symbols...)
+		impliedOrigins = append(impliedOrigins, bIdx.impliedOrigins...)
+	}
+
+	idx.Targets = idx.Targets.Deduplicate()
+	idx.Targets.Sort()
+
+	idx.Origins = resolveImpliedOrigins(idx.Origins, impliedOrigins)
+	idx.Origins.Sort()
+
+	return idx, nil
+}
+
+type bodyIndex struct {
+	targets        reference.Targets
+	origins        reference.Origins
+	impliedOrigins []schema.ImpliedOrigin
+	symbols        []Symbol
+}
+
+// indexBody collects reference targets, reference origins
+// and symbols of the given body, decoding each nested body once.
+func (d *PathDecoder) indexBody(ctx context.Context, body hcl.Body, parentBlock *ast.BlockContent, bodySchema *schema.BodySchema) bodyIndex {
+	idx := bodyIndex{
+		targets:        make(reference.Targets, 0),
+		origins:        make(reference.Origins, 0),
+		impliedOrigins: make([]schema.ImpliedOrigin, 0),
+		symbols:        make([]Symbol, 0),
+	}
+
+	if body == nil {
+		return idx
+	}
+
+	if bodySchema == nil {
+		// references cannot be collected without schema
+		// but symbols of HCL bodies still can
+		idx.symbols = d.symbolsForBody(body, nil)
+		return idx
+	}
+
+	idx.impliedOrigins = append(idx.impliedOrigins, bodySchema.ImpliedOrigins...)
+	content := ast.DecodeBody(body, bodySchema)
+
+	for name, attr := range content.Attributes {
+		idx.targets = append(idx.targets, d.referenceTargetsForBodyAttribute(attr, content.RangePtr, bodySchema)...)
+		idx.origins = append(idx.origins, d.referenceOriginsForAttribute(ctx, attr, bodySchema)...)
+		idx.symbols = append(idx.symbols, d.attributeSymbol(name, attr))
+	}
+
+	for _, blk := range content.Blocks {
+		bSchema, ok := schemahelper.BlockSchema(bodySchema, blk.Type)
+		if !ok {
+			// unknown block (no schema)
+			idx.symbols = append(idx.symbols, d.blockSymbol(blk, d.symbolsForBody(blk.Body, nil)))
+			continue
+		}
+
+		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(blk.Block, bSchema)
+
+		nestedIdx := d.indexBody(ctx, blk.Body, blk, mergedSchema)
+		idx.targets = append(idx.targets, nestedIdx.targets...)
+		idx.targets = append(idx.targets, d.referenceTargetsForBlock(blk, bSchema)...)
+		idx.origins = append(idx.origins, nestedIdx.origins...)
+		idx.impliedOrigins = append(idx.impliedOrigins, nestedIdx.impliedOrigins...)
+		idx.symbols = append(idx.symbols, d.blockSymbol(blk, nestedIdx.symbols))
+	}
+
+	idx.targets = append(idx.targets, targetableBodyTargets(body, parentBlock, bodySchema)...)
+
+	sort.Sort(idx.targets)
+	sortSymbols(idx.symbols)
+
+	return idx
+}
@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var testIndexSchema = &schema.BodySchema{
+	Blocks: map[string]*schema.BlockSchema{
+		"variable": {
+			Labels: []*schema.LabelSchema{
+				{Name: "name"},
+			},
+			Address: &schema.BlockAddrSchema{
+				Steps: []schema.AddrStep{
+					schema.StaticStep{Name: "var"},
+					schema.LabelStep{Index: 0},
+				},
+				AsReference: true,
+				ScopeId:     lang.ScopeId("variable"),
+			},
+			Body: &schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"default": {
+						IsOptional: true,
+						Constraint: schema.LiteralType{Type: cty.String},
+					},
+				},
+			},
+		},
+		"resource": {
+			Labels: []*schema.LabelSchema{
+				{Name: "type"},
+				{Name: "name"},
+			},
+			Address: &schema.BlockAddrSchema{
+				Steps: []schema.AddrStep{
+					schema.LabelStep{Index: 0},
+					schema.LabelStep{Index: 1},
+				},
+				BodyAsData: true,
+				InferBody:  true,
+				ScopeId:    lang.ScopeId("resource"),
+			},
+			Body: &schema.BodySchema{
+				Extensions: &schema.BodyExtensions{
+					Count: true,
+				},
+				Attributes: map[string]*schema.AttributeSchema{
+					"name": {
+						IsOptional: true,
+						Constraint: schema.AnyExpression{OfType: cty.String},
+					},
+				},
+				Blocks: map[string]*schema.BlockSchema{
+					"setting": {
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"value": {
+									IsOptional: true,
+									Constraint: schema.AnyExpression{OfType: cty.String},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+const testIndexHclConfig = `variable "name" {
+  default = "foo"
+}
+
+resource "aws_instance" "web" {
+  count = 2
+  name  = "${var.name}-${count.index}"
+
+  setting {
+    value = var.name
+  }
+}
+
+unknown {
+  attr = "noschema"
+}
+`
+
+const testIndexJsonConfig = `{
+  "variable": {
+    "region": {
+      "default": "eu-west-1"
+    }
+  },
+  "resource": {
+    "aws_instance": {
+      "db": {
+        "name": "${var.region}"
+      }
+    }
+  }
+}`
+
+func testIndexDecoder(t testing.TB) (*Decoder, lang.Path) {
+	hclFile, diags := hclsyntax.ParseConfig([]byte(testIndexHclConfig), "test.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	jsonFile, diags := json.Parse([]byte(testIndexJsonConfig), "test.tf.json")
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	dirPath := t.TempDir()
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: testIndexSchema,
+				Files: map[string]*hcl.File{
+					"test.tf":      hclFile,
+					"test.tf.json": jsonFile,
+				},
+			},
+		},
+	})
+	d.SetContext(NewDecoderContext())
+
+	return d, lang.Path{Path: dirPath}
+}
+
+func TestDecoder_BuildIndex(t *testing.T) {
+	d, path := testIndexDecoder(t)
+	pd, err := d.Path(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTargets, err := pd.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOrigins, err := pd.CollectReferenceOrigins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedSymbols, err := pd.symbols("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := d.BuildIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(idx.Targets) == 0 || len(idx.Origins) == 0 || len(idx.Symbols) == 0 {
+		t.Fatalf("expected non-empty index, given %d targets, %d origins, %d symbols",
+			len(idx.Targets), len(idx.Origins), len(idx.Symbols))
+	}
+
+	if diff := cmp.Diff(expectedTargets, idx.Targets, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+	if diff := cmp.Diff(expectedOrigins, idx.Origins, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected origins: %s", diff)
+	}
+	if diff := cmp.Diff(expectedSymbols, idx.Symbols); diff != "" {
+		t.Fatalf("unexpected symbols: %s", diff)
+	}
+}
+
+func TestDecoder_BuildIndex_noSchema(t *testing.T) {
+	f, _ := hclsyntax.ParseConfig([]byte(testIndexHclConfig), "test.tf", hcl.InitialPos)
+
+	dirPath := t.TempDir()
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			},
+		},
+	})
+
+	_, err := d.BuildIndex(lang.Path{Path: dirPath})
+	noSchemaErr := &NoSchemaError{}
+	if !errors.As(err, &noSchemaErr) {
+		t.Fatalf("expected NoSchemaError, given: %#v", err)
+	}
+}
+
+func BenchmarkDecoder_BuildIndex(b *testing.B) {
+	d, path := testIndexDecoder(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := d.BuildIndex(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecoder_BuildIndex_separately(b *testing.B) {
+	d, path := testIndexDecoder(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pd, err := d.Path(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = pd.CollectReferenceTargets()
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = pd.CollectReferenceOrigins()
+		if err != nil {
+			b.Fatal(err)
+		}
+		_, err = pd.symbols("")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -15,6 +15,7 @@ func CountAttributeSchema() *schema.AttributeSchema {
 		Constraint: schema.AnyExpression{OfType: cty.Number},
 		Description: lang.Markdown("Total number of instances of this block.\n\n" +
 			"**Note**: A given block cannot use both `count` and `for_each`."),
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 	}
 }
 
@@ -33,5 +34,70 @@ func ForEachAttributeSchema() *schema.AttributeSchema {
 		},
 		Description: lang.Markdown("A meta-argument that accepts a map or a set of strings, and creates an instance for each item in that map or set.\n\n" +
 			"**Note**: A given block cannot use both `count` and `for_each`."),
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 	}
 }
+
+func ProviderAttributeSchema() *schema.AttributeSchema {
+	return &schema.AttributeSchema{
+		IsOptional: true,
+		Constraint: schema.Reference{OfScopeId: schema.ProviderScopeId},
+		Description: lang.Markdown("Reference to a `provider` configuration block, " +
+			"in the form `<PROVIDER NAME>.<ALIAS>`, overriding the default provider selection."),
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+	}
+}
+
+func DependsOnAttributeSchema() *schema.AttributeSchema {
+	return &schema.AttributeSchema{
+		IsOptional: true,
+		Constraint: schema.Set{
+			Elem: schema.OneOf{
+				schema.Reference{OfScopeId: schema.ResourceScopeId},
+				schema.Reference{OfScopeId: schema.ModuleScopeId},
+			},
+		},
+		Description: lang.Markdown("Set of references to hidden dependencies, " +
+			"i.e. resources or modules this block depends on, which cannot be inferred from its configuration."),
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+	}
+}
+
+// AttributeSchema returns schema of the named attribute of the given body.
+//
+// Attributes declared explicitly in the body schema take precedence
+// over attributes implied by extensions (count, for_each, provider
+// and depends_on),
+// so that enabling an extension does not shadow a legitimately
+// declared attribute of the same name. AnyAttribute is used
+// as a fallback for any other attribute.
+func AttributeSchema(bodySchema *schema.BodySchema, name string) (*schema.AttributeSchema, bool) {
+	if bodySchema == nil {
+		return nil, false
+	}
+
+	if aSchema, ok := bodySchema.Attributes[name]; ok {
+		return aSchema, true
+	}
+
+	if bodySchema.Extensions != nil {
+		if bodySchema.Extensions.Count && name == "count" {
+			return CountAttributeSchema(), true
+		}
+		if bodySchema.Extensions.ForEach && name == "for_each" {
+			return ForEachAttributeSchema(), true
+		}
+		if bodySchema.Extensions.Provider && name == "provider" {
+			return ProviderAttributeSchema(), true
+		}
+		if bodySchema.Extensions.DependsOn && name == "depends_on" {
+			return DependsOnAttributeSchema(), true
+		}
+	}
+
+	if bodySchema.AnyAttribute != nil {
+		return bodySchema.AnyAttribute, true
+	}
+
+	return nil, false
+}
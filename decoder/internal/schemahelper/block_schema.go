@@ -90,5 +90,46 @@ func MergeBlockBodySchemas(block *hcl.Block, blockSchema *schema.BlockSchema) (*
 		mergedSchema.Blocks["dynamic"] = buildDynamicBlockSchema(mergedSchema)
 	}
 
+	if mergedSchema.Extensions != nil && mergedSchema.Extensions.Lifecycle {
+		if _, exists := mergedSchema.Blocks["lifecycle"]; !exists {
+			mergedSchema.Blocks["lifecycle"] = buildLifecycleBlockSchema(mergedSchema)
+		}
+	}
+
 	return mergedSchema, result
 }
+
+// BlockSchema returns schema of the given block type within the given body,
+// where the block type may also be an alias (BlockSchema.Aliases)
+// of the declared block type.
+func BlockSchema(bodySchema *schema.BodySchema, blockType string) (*schema.BlockSchema, bool) {
+	if bodySchema == nil {
+		return nil, false
+	}
+
+	bSchema, ok := bodySchema.Blocks[CanonicalBlockType(bodySchema, blockType)]
+	return bSchema, ok
+}
+
+// CanonicalBlockType returns the block type under which the given
+// block type is declared in the body schema, resolving any alias.
+// The given block type is returned unchanged if it is not an alias.
+func CanonicalBlockType(bodySchema *schema.BodySchema, blockType string) string {
+	if bodySchema == nil {
+		return blockType
+	}
+
+	if _, ok := bodySchema.Blocks[blockType]; ok {
+		return blockType
+	}
+
+	for bType, bSchema := range bodySchema.Blocks {
+		for _, alias := range bSchema.Aliases {
+			if alias == blockType {
+				return bType
+			}
+		}
+	}
+
+	return blockType
+}
@@ -19,17 +19,19 @@ func buildDynamicBlockSchema(inputSchema *schema.BodySchema) *schema.BlockSchema
 		})] = &schema.BodySchema{
 			Blocks: map[string]*schema.BlockSchema{
 				"content": {
-					Description: lang.PlainText("The body of each generated block"),
-					MinItems:    1,
-					MaxItems:    1,
-					Body:        block.Body.Copy(),
+					Description:            lang.PlainText("The body of each generated block"),
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+					MinItems:               1,
+					MaxItems:               1,
+					Body:                   block.Body.Copy(),
 				},
 			},
 		}
 	}
 
 	return &schema.BlockSchema{
-		Description: lang.Markdown("A dynamic block to produce blocks dynamically by iterating over a given complex value"),
+		Description:            lang.Markdown("A dynamic block to produce blocks dynamically by iterating over a given complex value"),
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 		Labels: []*schema.LabelSchema{
 			{
 				Name:        "name",
@@ -45,12 +47,14 @@ func buildDynamicBlockSchema(inputSchema *schema.BodySchema) *schema.BlockSchema
 						schema.AnyExpression{OfType: cty.List(cty.DynamicPseudoType)},
 						schema.AnyExpression{OfType: cty.Set(cty.String)},
 					},
-					IsRequired:  true,
-					Description: lang.Markdown("A meta-argument that accepts a list, map or a set of strings, and creates an instance for each item in that list, map or set."),
+					IsRequired:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+					Description:            lang.Markdown("A meta-argument that accepts a list, map or a set of strings, and creates an instance for each item in that list, map or set."),
 				},
 				"iterator": {
-					Constraint: schema.LiteralType{Type: cty.String},
-					IsOptional: true,
+					Constraint:             schema.LiteralType{Type: cty.String},
+					IsOptional:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Description: lang.Markdown("The name of a temporary variable that represents the current " +
 						"element of the complex value. Defaults to the label of the dynamic block."),
 				},
@@ -58,7 +62,8 @@ func buildDynamicBlockSchema(inputSchema *schema.BodySchema) *schema.BlockSchema
 					Constraint: schema.AnyExpression{
 						OfType: cty.List(cty.String),
 					},
-					IsOptional: true,
+					IsOptional:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Description: lang.Markdown("A list of strings that specifies the block labels, " +
 						"in order, to use for each generated block."),
 				},
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemahelper
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func buildLifecycleBlockSchema(inputSchema *schema.BodySchema) *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description:            lang.Markdown("Lifecycle customizations to change default behaviour of the enclosing block"),
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+		MaxItems:               1,
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"create_before_destroy": {
+					Constraint:             schema.LiteralType{Type: cty.Bool},
+					IsOptional:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+					Description: lang.Markdown("Whether the replacement object should be created " +
+						"before the existing object is destroyed, instead of after."),
+				},
+				"prevent_destroy": {
+					Constraint:             schema.LiteralType{Type: cty.Bool},
+					IsOptional:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+					Description: lang.Markdown("Whether any plan which would destroy the object " +
+						"should be rejected with an error."),
+				},
+				"ignore_changes": {
+					Constraint: schema.OneOf{
+						schema.Keyword{
+							Keyword:     "all",
+							Description: lang.Markdown("Ignore changes to all attributes of the enclosing block"),
+						},
+						schema.Set{
+							Elem: ownAttributesConstraint(inputSchema),
+						},
+					},
+					IsOptional:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+					Description: lang.Markdown("Set of attributes of the enclosing block " +
+						"whose changes should be ignored when planning updates, or `all` to ignore all of them."),
+				},
+				"replace_triggered_by": {
+					Constraint: schema.List{
+						Elem: schema.Reference{OfScopeId: schema.ResourceScopeId},
+					},
+					IsOptional:             true,
+					SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+					Description: lang.Markdown("List of references to resources " +
+						"whose changes should trigger replacement of the enclosing block."),
+				},
+			},
+		},
+	}
+}
+
+// ownAttributesConstraint returns a constraint matching references
+// to attributes declared in the given body, which are referenced
+// without any prefix (e.g. ignore_changes = [tags, tags["Name"]]).
+func ownAttributesConstraint(bodySchema *schema.BodySchema) schema.Constraint {
+	if len(bodySchema.Attributes) == 0 {
+		return schema.AnyExpression{OfType: cty.DynamicPseudoType}
+	}
+
+	return schema.OwnAttribute{
+		Attributes: bodySchema.Attributes,
+	}
+}
@@ -30,6 +30,8 @@ func Walk(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema, w
 		blkNestingLvl = 0
 	}
 
+	schemaPath, _ := schemacontext.FromContext(ctx)
+
 	switch nodeType := node.(type) {
 	case *hclsyntax.Body:
 		bodyCtx := ctx
@@ -45,41 +47,34 @@ func Walk(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema, w
 		for _, attr := range nodeType.Attributes {
 			var attrSchema schema.Schema = nil
 			if bodySchemaOk {
-				aSchema, ok := bodySchema.Attributes[attr.Name]
-				if ok {
+				if aSchema, ok := schemahelper.AttributeSchema(bodySchema, attr.Name); ok {
 					attrSchema = aSchema
-				} else if bodySchema.AnyAttribute != nil {
-					attrSchema = bodySchema.AnyAttribute
-				}
-
-				if bodySchema.Extensions != nil && bodySchema.Extensions.Count && attr.Name == "count" {
-					attrSchema = schemahelper.CountAttributeSchema()
-				}
-
-				if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && attr.Name == "for_each" {
-					attrSchema = schemahelper.ForEachAttributeSchema()
 				}
 			}
 
-			diags = diags.Extend(Walk(bodyCtx, attr, attrSchema, w))
+			attrCtx := schemacontext.WithSchemaPath(bodyCtx, schemaPath.WithAttributeName(attr.Name))
+			diags = diags.Extend(Walk(attrCtx, attr, attrSchema, w))
 		}
 
 		for _, block := range nodeType.Blocks {
 			var blockSchema schema.Schema = nil
 			if bodySchemaOk {
-				bs, ok := bodySchema.Blocks[block.Type]
+				bs, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 				if ok {
 					blockSchema = bs
 				}
 
-				if _, ok := foundBlocks[block.Type]; !ok {
-					foundBlocks[block.Type] = 0
+				// blocks declared under an alias count towards
+				// the canonical block type
+				blockType := schemahelper.CanonicalBlockType(bodySchema, block.Type)
+				if _, ok := foundBlocks[blockType]; !ok {
+					foundBlocks[blockType] = 0
 				}
-				foundBlocks[block.Type]++
+				foundBlocks[blockType]++
 
 				if block.Type == "dynamic" {
 					if len(block.Labels) > 0 {
-						label := block.Labels[0]
+						label := schemahelper.CanonicalBlockType(bodySchema, block.Labels[0])
 						if _, ok := dynamicBlocks[label]; !ok {
 							dynamicBlocks[label] = 0
 						}
@@ -88,7 +83,8 @@ func Walk(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema, w
 				}
 			}
 
-			diags = diags.Extend(Walk(bodyCtx, block, blockSchema, w))
+			blockCtx := schemacontext.WithSchemaPath(bodyCtx, schemaPath.WithBlockType(block.Type))
+			diags = diags.Extend(Walk(blockCtx, block, blockSchema, w))
 		}
 
 		bodyCtx = schemacontext.WithFoundBlocks(bodyCtx, foundBlocks)
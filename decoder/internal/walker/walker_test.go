@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl/v2"
@@ -143,6 +144,63 @@ second {
 	}
 }
 
+func TestWalk_schemaPath(t *testing.T) {
+	ctx := context.Background()
+	src := []byte(`
+rootattr = "foo"
+first {
+  nested {
+    foo = "bar"
+  }
+}
+`)
+	file, pDiags := hclsyntax.ParseConfig(src, "test.hcl", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatalf("unexpected parser diagnostics: %s", pDiags)
+	}
+
+	rootSchema := schema.NewBodySchema()
+
+	paths := make(map[string]schemacontext.SchemaPath)
+	tw := NewTestWalker(func(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+		schemaPath, _ := schemacontext.FromContext(ctx)
+
+		switch n := node.(type) {
+		case *hclsyntax.Attribute:
+			paths["attr:"+n.Name] = schemaPath
+		case *hclsyntax.Block:
+			paths["block:"+n.Type] = schemaPath
+		}
+
+		return ctx, nil
+	})
+
+	diags := Walk(ctx, file.Body.(*hclsyntax.Body), rootSchema, tw)
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+
+	expectedPaths := map[string]schemacontext.SchemaPath{
+		"attr:rootattr": {
+			BlockTypes:    []string{},
+			AttributeName: "rootattr",
+		},
+		"block:first": {
+			BlockTypes: []string{"first"},
+		},
+		"block:nested": {
+			BlockTypes: []string{"first", "nested"},
+		},
+		"attr:foo": {
+			BlockTypes:    []string{"first", "nested"},
+			AttributeName: "foo",
+		},
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Fatalf("unexpected schema paths: %s", diff)
+	}
+}
+
 func NewTestWalker(visitFunc visitFunc) Walker {
 	return testWalker{
 		visitFunc: visitFunc,
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
@@ -65,7 +66,7 @@ func (d *PathDecoder) labelCandidatesFromDependentSchema(idx int, db map[schema.
 			}
 
 			te := lang.TextEdit{}
-			if d.PrefillRequiredFields {
+			if d.PrefillRequiredFields && bodySchema != nil {
 				snippet := generateRequiredFieldsSnippet(label.Value, bodySchema, labelSchemas, 2, 0)
 				te = lang.TextEdit{
 					NewText: label.Value,
@@ -80,14 +81,17 @@ func (d *PathDecoder) labelCandidatesFromDependentSchema(idx int, db map[schema.
 				}
 			}
 
-			candidates.List = append(candidates.List, lang.Candidate{
-				Label:        label.Value,
-				Kind:         lang.LabelCandidateKind,
-				IsDeprecated: bodySchema.IsDeprecated,
-				TextEdit:     te,
-				Detail:       bodySchema.Detail,
-				Description:  bodySchema.Description,
-			})
+			candidate := lang.Candidate{
+				Label:    label.Value,
+				Kind:     lang.LabelCandidateKind,
+				TextEdit: te,
+			}
+			if bodySchema != nil {
+				candidate.IsDeprecated = bodySchema.IsDeprecated
+				candidate.Detail = labelCandidateDetail(bodySchema)
+				candidate.Description = bodySchema.Description
+			}
+			candidates.List = append(candidates.List, candidate)
 
 			foundCandidateNames[label.Value] = true
 			count++
@@ -99,6 +103,25 @@ func (d *PathDecoder) labelCandidatesFromDependentSchema(idx int, db map[schema.
 	return candidates, nil
 }
 
+// labelCandidateDetail returns detail of the dependent body,
+// along with a preview of the number of attributes it enables,
+// so that users can tell the candidates apart.
+func labelCandidateDetail(bodySchema *schema.BodySchema) string {
+	attrCount := len(bodySchema.Attributes)
+	if attrCount == 0 {
+		return bodySchema.Detail
+	}
+
+	preview := fmt.Sprintf("%d attributes", attrCount)
+	if attrCount == 1 {
+		preview = "1 attribute"
+	}
+	if bodySchema.Detail == "" {
+		return preview
+	}
+	return fmt.Sprintf("%s (%s)", bodySchema.Detail, preview)
+}
+
 // generateRequiredFieldsSnippet returns a properly formatted snippet of all required
 // fields (attributes, blocks, etc). It handles the main stanza declaration and calls
 // `requiredFieldsSnippet` to handle recursing through the body schema
@@ -166,13 +189,12 @@ func requiredFieldsSnippet(bodySchema *schema.BodySchema, placeholder int, inden
 			continue
 		}
 
-		var snippet string
 		// We already know we want to do pre-filling at this point
 		// We could plumb through the context here, but it saves us
 		// an argument in multiple functions above.
 		ctx := schema.WithPrefillRequiredFields(context.Background(), true)
-		snippet = attr.Constraint.EmptyCompletionData(ctx, placeholder, indentCount).Snippet
-		snippetText += fmt.Sprintf("%s%s = %s", indent, attrName, snippet)
+		cData := schema.EmptyCompletionDataOf(ctx, attr.Constraint, placeholder, indentCount)
+		snippetText += fmt.Sprintf("%s%s = %s", indent, attrName, cData.Snippet)
 
 		// attrCount is used to tell if we are at the end of the list of attributes
 		// so we don't add a trailing newline. this will affect both attribute
@@ -181,7 +203,11 @@ func requiredFieldsSnippet(bodySchema *schema.BodySchema, placeholder int, inden
 		if attrCount <= reqAttr {
 			snippetText += "\n"
 		}
-		placeholder++
+		if cData.NextPlaceholder > placeholder {
+			placeholder = cData.NextPlaceholder
+		} else {
+			placeholder++
+		}
 	}
 
 	// iterate over each block, skip if not required, and print snippet
@@ -229,3 +255,214 @@ func decodeSchemaKey(key schema.SchemaKey) (schema.DependencyKeys, error) {
 	err := json.Unmarshal([]byte(key), &dk)
 	return dk, err
 }
+
+// labelCandidates returns candidates for the label at the given index,
+// either from the allowed values of the label, or from the dependent
+// schema if the label is completable.
+//
+// Names already declared by sibling blocks within the given body
+// are avoided if the block's labels are expected to be unique.
+func (d *PathDecoder) labelCandidates(idx int, body *hclsyntax.Body, bodySchema *schema.BodySchema, blockSchema *schema.BlockSchema, prefixRng, editRng hcl.Range, block *hclsyntax.Block) (lang.Candidates, error) {
+	labelSchema := blockSchema.Labels[idx]
+
+	candidates := lang.ZeroCandidates()
+	if len(labelSchema.AllowedValues) > 0 {
+		candidates = d.labelCandidatesFromAllowedValues(labelSchema, prefixRng, editRng)
+	} else if labelSchema.Completable {
+		var err error
+		candidates, err = d.labelCandidatesFromDependentSchema(idx, blockSchema.DependentBody, prefixRng, editRng, block, blockSchema.Labels)
+		if err != nil {
+			return candidates, err
+		}
+	}
+
+	if blockSchema.LabelsUnique && idx == nameLabelIndex(blockSchema) {
+		usedNames := declaredLabelValues(idx, body, bodySchema, block)
+		return d.uniqueLabelCandidates(candidates, usedNames, labelSchema, prefixRng, editRng), nil
+	}
+
+	return candidates, nil
+}
+
+// uniqueLabelCandidates removes candidates for names already in use
+// and adds a generated unique name as a convenience candidate.
+func (d *PathDecoder) uniqueLabelCandidates(candidates lang.Candidates, usedNames map[string]bool, labelSchema *schema.LabelSchema, prefixRng, editRng hcl.Range) lang.Candidates {
+	list := make([]lang.Candidate, 0, len(candidates.List))
+	for _, candidate := range candidates.List {
+		if usedNames[candidate.Label] {
+			continue
+		}
+		list = append(list, candidate)
+	}
+	candidates.List = list
+
+	prefix, _ := d.bytesFromRange(prefixRng)
+	baseName := string(prefix)
+	if baseName == "" {
+		baseName = labelSchema.Name
+	}
+	if baseName == "" {
+		return candidates
+	}
+
+	name := uniqueLabelValue(baseName, usedNames)
+	for _, candidate := range candidates.List {
+		if candidate.Label == name {
+			return candidates
+		}
+	}
+
+	candidates.List = append(candidates.List, lang.Candidate{
+		Label:  name,
+		Detail: "unique name",
+		Kind:   lang.LabelCandidateKind,
+		TextEdit: lang.TextEdit{
+			NewText: name,
+			Snippet: name,
+			Range:   editRng,
+		},
+	})
+	sort.Sort(candidates)
+
+	return candidates
+}
+
+// nameLabelIndex returns index of the label representing
+// the name of the block, i.e. the label marked as IsName,
+// or the last label if none is marked.
+func nameLabelIndex(blockSchema *schema.BlockSchema) int {
+	for i, labelSchema := range blockSchema.Labels {
+		if labelSchema.IsName {
+			return i
+		}
+	}
+	return len(blockSchema.Labels) - 1
+}
+
+// declaredLabelValues returns values of the label at the given index
+// declared by other blocks of the same type within the body,
+// which share all preceding labels with the given block.
+func declaredLabelValues(idx int, body *hclsyntax.Body, bodySchema *schema.BodySchema, block *hclsyntax.Block) map[string]bool {
+	values := make(map[string]bool, 0)
+	if len(block.Labels) < idx {
+		return values
+	}
+
+	blockType := schemahelper.CanonicalBlockType(bodySchema, block.Type)
+	for _, sibling := range body.Blocks {
+		if sibling == block || len(sibling.Labels) <= idx {
+			continue
+		}
+		if schemahelper.CanonicalBlockType(bodySchema, sibling.Type) != blockType {
+			continue
+		}
+		if !labelsEqual(sibling.Labels[:idx], block.Labels[:idx]) {
+			continue
+		}
+		values[sibling.Labels[idx]] = true
+	}
+
+	return values
+}
+
+func labelsEqual(labels, other []string) bool {
+	if len(labels) != len(other) {
+		return false
+	}
+	for i := range labels {
+		if labels[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueLabelValue returns the given name if it is not in use,
+// or the name with the lowest numeric suffix which is not in use.
+func uniqueLabelValue(name string, usedNames map[string]bool) string {
+	if !usedNames[name] {
+		return name
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s_%d", name, i)
+		if !usedNames[candidate] {
+			return candidate
+		}
+	}
+}
+
+func (d *PathDecoder) labelCandidatesFromAllowedValues(labelSchema *schema.LabelSchema, prefixRng, editRng hcl.Range) lang.Candidates {
+	candidates := lang.NewCandidates()
+	candidates.IsComplete = true
+
+	prefix, _ := d.bytesFromRange(prefixRng)
+
+	for _, value := range labelSchema.AllowedValues {
+		if len(prefix) > 0 && !strings.HasPrefix(value, string(prefix)) {
+			continue
+		}
+		if uint(len(candidates.List)) >= d.maxCandidates {
+			candidates.IsComplete = false
+			break
+		}
+
+		candidates.List = append(candidates.List, lang.Candidate{
+			Label:       value,
+			Kind:        lang.LabelCandidateKind,
+			Description: labelSchema.Description,
+			TextEdit: lang.TextEdit{
+				NewText: value,
+				Snippet: value,
+				Range:   editRng,
+			},
+		})
+	}
+
+	sort.Sort(candidates)
+
+	return candidates
+}
+
+// quotedLabelCandidates turns candidates for a label value into candidates
+// inserting the whole (quoted) label at the given range,
+// for completion of labels which are not declared yet.
+func quotedLabelCandidates(candidates lang.Candidates, editRng hcl.Range) lang.Candidates {
+	for i, c := range candidates.List {
+		quoted := fmt.Sprintf("%q", c.Label)
+		candidates.List[i].TextEdit = lang.TextEdit{
+			NewText: quoted,
+			Snippet: quoted,
+			Range:   editRng,
+		}
+	}
+	return candidates
+}
+
+// labelIndexAtPos returns index of the label of the block header
+// at the given position and whether the position is inside that label.
+//
+// If the position is in between labels (or after the last one),
+// the index is that of the label which would be inserted there.
+// False is returned if the position is outside the labels part
+// of the header.
+func labelIndexAtPos(block *hclsyntax.Block, pos hcl.Pos) (int, bool, bool) {
+	if pos.Byte <= block.TypeRange.End.Byte || pos.Byte > block.OpenBraceRange.Start.Byte {
+		return 0, false, false
+	}
+
+	for i, labelRange := range block.LabelRanges {
+		if labelRange.ContainsPos(pos) {
+			return i, true, true
+		}
+		if pos.Byte < labelRange.Start.Byte {
+			return i, false, true
+		}
+	}
+
+	if len(block.LabelRanges) > 0 && pos.Byte == block.LabelRanges[len(block.LabelRanges)-1].End.Byte {
+		// directly adjacent to the last label
+		return 0, false, false
+	}
+
+	return len(block.LabelRanges), false, true
+}
@@ -5,6 +5,7 @@ package decoder
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -80,7 +81,8 @@ func TestDecoder_CandidateAtPos_incompleteLabels(t *testing.T) {
 	expectedCandidates := lang.Candidates{
 		List: []lang.Candidate{
 			{
-				Label: "first",
+				Label:  "first",
+				Detail: "1 attribute",
 				TextEdit: lang.TextEdit{
 					Range: hcl.Range{
 						Filename: "test.tf",
@@ -108,6 +110,469 @@ func TestDecoder_CandidateAtPos_incompleteLabels(t *testing.T) {
 	}
 }
 
+func TestDecoder_CompletionAtPos_labelPositions(t *testing.T) {
+	ctx := context.Background()
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:        "type",
+						IsDepKey:    true,
+						Completable: true,
+					},
+					{
+						Name:          "name",
+						AllowedValues: []string{"secondary", "primary"},
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{Index: 0, Value: "aws_instance"},
+						},
+					}): {},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		cfg                string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"empty second label",
+			`resource "aws_instance" "" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 26, Byte: 25},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "primary",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 26, Byte: 25},
+						},
+						NewText: "primary",
+						Snippet: "primary",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+				{
+					Label: "secondary",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 26, Byte: 25},
+						},
+						NewText: "secondary",
+						Snippet: "secondary",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"partial second label",
+			`resource "aws_instance" "pr" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 28, Byte: 27},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "primary",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 26, Byte: 25},
+							End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
+						},
+						NewText: "primary",
+						Snippet: "primary",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"empty first label before second label",
+			`resource "" "primary" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 11, Byte: 10},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "aws_instance",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+							End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+						},
+						NewText: "aws_instance",
+						Snippet: "aws_instance",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"after last label",
+			`resource "aws_instance" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 25, Byte: 24},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label: "primary",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+							End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+						},
+						NewText: `"primary"`,
+						Snippet: `"primary"`,
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+				{
+					Label: "secondary",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+							End:      hcl.Pos{Line: 1, Column: 25, Byte: 24},
+						},
+						NewText: `"secondary"`,
+						Snippet: `"secondary"`,
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"between labels",
+			`resource "aws_instance"  "primary" {
+}
+`,
+			hcl.Pos{Line: 1, Column: 25, Byte: 24},
+			lang.ZeroCandidates(),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_CandidateAtPos_labelDependentBodyPreview(t *testing.T) {
+	ctx := context.Background()
+	depKey := func(value string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: value},
+			},
+		})
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:        "type",
+						IsDepKey:    true,
+						Completable: true,
+					},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					depKey("aws_instance"): {
+						Detail: "EC2 instance",
+						Attributes: map[string]*schema.AttributeSchema{
+							"ami":           {Constraint: schema.LiteralType{Type: cty.String}},
+							"instance_type": {Constraint: schema.LiteralType{Type: cty.String}},
+						},
+					},
+					depKey("aws_s3_bucket"): nil,
+					depKey("google_compute_instance"): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"machine_type": {Constraint: schema.LiteralType{Type: cty.String}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte(`resource "aws_" {
+}
+`), "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	candidates, err := d.CompletionAtPos(ctx, "test.tf", hcl.Pos{
+		Line:   1,
+		Column: 15,
+		Byte:   14,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	editRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 11, Byte: 10},
+		End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+	}
+	expectedCandidates := lang.CompleteCandidates([]lang.Candidate{
+		{
+			Label:  "aws_instance",
+			Detail: "EC2 instance (2 attributes)",
+			TextEdit: lang.TextEdit{
+				Range:   editRange,
+				NewText: "aws_instance",
+				Snippet: "aws_instance",
+			},
+			Kind: lang.LabelCandidateKind,
+		},
+		{
+			Label: "aws_s3_bucket",
+			TextEdit: lang.TextEdit{
+				Range:   editRange,
+				NewText: "aws_s3_bucket",
+				Snippet: "aws_s3_bucket",
+			},
+			Kind: lang.LabelCandidateKind,
+		},
+	})
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates: %s", diff)
+	}
+}
+
+func TestDecoder_CompletionAtPos_nestedDependentLabels(t *testing.T) {
+	depKey := func(value string) schema.SchemaKey {
+		return schema.NewSchemaKey(schema.DependencyKeys{
+			Labels: []schema.LabelDependent{
+				{Index: 0, Value: value},
+			},
+		})
+	}
+	optionBlock := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{
+				Name:        "name",
+				IsDepKey:    true,
+				Completable: true,
+			},
+		},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			depKey("x"): {
+				Attributes: map[string]*schema.AttributeSchema{
+					"x_attr": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+				},
+			},
+			depKey("y"): {
+				Attributes: map[string]*schema.AttributeSchema{
+					"y_attr": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+				},
+			},
+		},
+	}
+	settingBlock := &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{
+				Name:        "name",
+				IsDepKey:    true,
+				Completable: true,
+			},
+		},
+		DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+			depKey("alpha"): {
+				Attributes: map[string]*schema.AttributeSchema{
+					"alpha_attr": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+				},
+				Blocks: map[string]*schema.BlockSchema{
+					"option": optionBlock,
+				},
+			},
+			depKey("beta"): {
+				Attributes: map[string]*schema.AttributeSchema{
+					"beta_attr": {Constraint: schema.LiteralType{Type: cty.Number}, IsOptional: true},
+				},
+			},
+		},
+	}
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+				},
+				Body: &schema.BodySchema{
+					Blocks: map[string]*schema.BlockSchema{
+						"setting": settingBlock,
+					},
+				},
+			},
+		},
+	}
+	cfg := `resource "foo" {
+  setting "" {
+  }
+  setting "alpha" {
+    option "" {
+    }
+    option "x" {
+      
+    }
+  }
+}
+`
+
+	testCases := []struct {
+		name               string
+		pos                hcl.Pos
+		expectedCandidates lang.Candidates
+	}{
+		{
+			"first level label",
+			hcl.Pos{Line: 2, Column: 12, Byte: 28},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "alpha",
+					Detail: "1 attribute",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 12, Byte: 28},
+							End:      hcl.Pos{Line: 2, Column: 12, Byte: 28},
+						},
+						NewText: "alpha",
+						Snippet: "alpha",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+				{
+					Label:  "beta",
+					Detail: "1 attribute",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 12, Byte: 28},
+							End:      hcl.Pos{Line: 2, Column: 12, Byte: 28},
+						},
+						NewText: "beta",
+						Snippet: "beta",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"second level label",
+			hcl.Pos{Line: 5, Column: 13, Byte: 68},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "x",
+					Detail: "1 attribute",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 13, Byte: 68},
+							End:      hcl.Pos{Line: 5, Column: 13, Byte: 68},
+						},
+						NewText: "x",
+						Snippet: "x",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+				{
+					Label:  "y",
+					Detail: "1 attribute",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 13, Byte: 68},
+							End:      hcl.Pos{Line: 5, Column: 13, Byte: 68},
+						},
+						NewText: "y",
+						Snippet: "y",
+					},
+					Kind: lang.LabelCandidateKind,
+				},
+			}),
+		},
+		{
+			"second level dependent body",
+			hcl.Pos{Line: 8, Column: 7, Byte: 101},
+			lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "x_attr",
+					Detail: "optional, number",
+					TextEdit: lang.TextEdit{
+						Range: hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 7, Byte: 101},
+							End:      hcl.Pos{Line: 8, Column: 7, Byte: 101},
+						},
+						NewText: "x_attr",
+						Snippet: "x_attr = ${1:0}",
+					},
+					Kind: lang.AttributeCandidateKind,
+				},
+			}),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+			})
+
+			ctx := context.Background()
+			candidates, err := d.CompletionAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedCandidates, candidates); diff != "" {
+				t.Fatalf("unexpected candidates: %s", diff)
+			}
+		})
+	}
+}
+
 func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 	ctx := context.Background()
 	startingConfig := "resource \"\" {\n}"
@@ -170,8 +635,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "1 attribute",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -216,8 +682,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "1 attribute",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -273,8 +740,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "2 attributes",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -341,8 +809,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "2 attributes",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -415,8 +884,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "2 attributes",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -488,8 +958,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "2 attributes",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -583,8 +1054,9 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			},
 			want: lang.CompleteCandidates([]lang.Candidate{
 				{
-					Label: "aws_appmesh_route",
-					Kind:  lang.LabelCandidateKind,
+					Label:  "aws_appmesh_route",
+					Detail: "2 attributes",
+					Kind:   lang.LabelCandidateKind,
 					TextEdit: lang.TextEdit{
 						Range:   wantRange,
 						NewText: `aws_appmesh_route`,
@@ -600,6 +1072,62 @@ func TestCompletionAtPos_prefillRequiredFields(t *testing.T) {
 			}
 		}
 	}
+	${0}`,
+					},
+				},
+			}),
+		},
+		{
+			name:    "one dependent label required attributes with default snippet",
+			prefill: true,
+			schema: &schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{
+								Name:        "type",
+								IsDepKey:    true,
+								Completable: true,
+							},
+							{
+								Name: "name",
+							},
+						},
+						DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+							schema.NewSchemaKey(schema.DependencyKeys{
+								Labels: []schema.LabelDependent{
+									{Index: 0, Value: "aws_instance"},
+								},
+							}): {
+								Attributes: map[string]*schema.AttributeSchema{
+									"ami": {
+										Constraint: schema.LiteralType{
+											Type:    cty.String,
+											Snippet: `"${1:ami}-${2:00000000}"`,
+										},
+										IsRequired: true,
+									},
+									"name": {
+										Constraint: schema.LiteralType{Type: cty.String},
+										IsRequired: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: lang.CompleteCandidates([]lang.Candidate{
+				{
+					Label:  "aws_instance",
+					Detail: "2 attributes",
+					Kind:   lang.LabelCandidateKind,
+					TextEdit: lang.TextEdit{
+						Range:   wantRange,
+						NewText: `aws_instance`,
+						Snippet: `aws_instance" "${2:name}" {
+	ami = "${3:ami}-${4:00000000}"
+	name = "${5:value}"
 	${0}`,
 					},
 				},
@@ -38,7 +38,7 @@ func (d *PathDecoder) linksInBody(body *hclsyntax.Body, bodySchema *schema.BodyS
 	links := make([]lang.Link, 0)
 
 	for _, block := range body.Blocks {
-		blockSchema, ok := bodySchema.Blocks[block.Type]
+		blockSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 		if !ok {
 			// Ignore unknown block
 			continue
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// NodePathAtPos returns the chain of AST nodes containing the given
+// position, from the body of the file down to the innermost node.
+//
+// This is primarily useful for debugging and building tooling,
+// e.g. to understand why a feature behaves a certain way
+// at a particular position. Empty slice is returned if the position
+// is outside of the file body.
+func (d *Decoder) NodePathAtPos(path lang.Path, file string, pos hcl.Pos) ([]hclsyntax.Node, error) {
+	nodes := make([]hclsyntax.Node, 0)
+
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return nodes, err
+	}
+
+	f, ok := pathCtx.Files[file]
+	if !ok {
+		return nodes, &FileNotFoundError{Filename: file}
+	}
+
+	if hasNilBody(f) {
+		return nodes, &UnknownFileFormatError{Filename: file}
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nodes, &UnknownFileFormatError{Filename: file}
+	}
+
+	w := &nodePathWalker{
+		pos:   pos,
+		nodes: nodes,
+	}
+	hclsyntax.Walk(body, w)
+
+	return w.nodes, nil
+}
+
+type nodePathWalker struct {
+	pos   hcl.Pos
+	nodes []hclsyntax.Node
+
+	// depth represents the number of nodes entered
+	depth int
+}
+
+func (w *nodePathWalker) Enter(node hclsyntax.Node) hcl.Diagnostics {
+	if isGroupingNode(node) {
+		return nil
+	}
+
+	// only consider nodes whose parent is part of the path,
+	// such that the first matching sibling wins
+	if len(w.nodes) == w.depth && node.Range().ContainsPos(w.pos) {
+		w.nodes = append(w.nodes, node)
+	}
+	w.depth++
+
+	return nil
+}
+
+func (w *nodePathWalker) Exit(node hclsyntax.Node) hcl.Diagnostics {
+	if isGroupingNode(node) {
+		return nil
+	}
+	w.depth--
+
+	return nil
+}
+
+// isGroupingNode returns true for nodes which merely group other nodes
+// and do not have a meaningful range of their own.
+func isGroupingNode(node hclsyntax.Node) bool {
+	switch node.(type) {
+	case hclsyntax.Attributes, hclsyntax.Blocks:
+		return true
+	}
+	return false
+}
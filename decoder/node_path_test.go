@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+func TestNodePathAtPos(t *testing.T) {
+	dirPath := t.TempDir()
+	path := lang.Path{Path: dirPath}
+
+	cfg := `resource "aws_instance" "foo" {
+  ami = upper("foo")
+}
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+
+	testCases := []struct {
+		name          string
+		pos           hcl.Pos
+		expectedNodes []string
+	}{
+		{
+			"on block type",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			[]string{
+				"*hclsyntax.Body test.tf:1,1-4,1",
+				"*hclsyntax.Block test.tf:1,1-3,2",
+			},
+		},
+		{
+			"on function name",
+			hcl.Pos{Line: 2, Column: 10, Byte: 41},
+			[]string{
+				"*hclsyntax.Body test.tf:1,1-4,1",
+				"*hclsyntax.Block test.tf:1,1-3,2",
+				"*hclsyntax.Body test.tf:1,31-3,2",
+				"*hclsyntax.Attribute test.tf:2,3-21",
+				"*hclsyntax.FunctionCallExpr test.tf:2,9-21",
+			},
+		},
+		{
+			"on function argument",
+			hcl.Pos{Line: 2, Column: 17, Byte: 48},
+			[]string{
+				"*hclsyntax.Body test.tf:1,1-4,1",
+				"*hclsyntax.Block test.tf:1,1-3,2",
+				"*hclsyntax.Body test.tf:1,31-3,2",
+				"*hclsyntax.Attribute test.tf:2,3-21",
+				"*hclsyntax.FunctionCallExpr test.tf:2,9-21",
+				"*hclsyntax.TemplateExpr test.tf:2,15-20",
+				"*hclsyntax.LiteralValueExpr test.tf:2,16-19",
+			},
+		},
+		{
+			"outside of body",
+			hcl.Pos{Line: 10, Column: 1, Byte: 200},
+			[]string{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.name), func(t *testing.T) {
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+					},
+				},
+			})
+
+			nodes, err := d.NodePathAtPos(path, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nodeStrings := make([]string, len(nodes))
+			for i, node := range nodes {
+				nodeStrings[i] = fmt.Sprintf("%T %s", node, node.Range())
+			}
+
+			if diff := cmp.Diff(tc.expectedNodes, nodeStrings); diff != "" {
+				t.Fatalf("unexpected nodes: %s", diff)
+			}
+		})
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl-lang/validator"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 )
 
 // PathContext represents any context relevant to the lang.Path
@@ -22,6 +25,74 @@ type PathContext struct {
 	Files            map[string]*hcl.File
 	Functions        map[string]schema.FunctionSignature
 	Validators       []validator.Validator
+
+	// Version (if non-zero) identifies the state of files and schema
+	// within the path and is used as a cache key for decoder-level
+	// caches, such as semantic tokens or function signatures.
+	//
+	// Callers must bump Version whenever any part of the context
+	// (files, schema, references, functions etc.) changes, otherwise
+	// stale results may be returned. Zero Version disables caching.
+	Version uint64
+
+	// CtyFunctions (if not nil) represents functions implemented
+	// via go-cty, whose signatures are derived via
+	// schema.FunctionSignatureFromCty for completion, hover,
+	// signature help and reference origin collection.
+	//
+	// Functions takes precedence over CtyFunctions when both
+	// contain a function of the same name.
+	CtyFunctions map[string]function.Function
+
+	// CandidateHook (if not nil) allows adjusting or filtering
+	// of the final completion candidates, e.g. to customize ordering.
+	//
+	// The hook has access to path, filename, pos and (if resolved)
+	// the constraint and schema path of the completed attribute via context:
+	//
+	//	cons, ok := decoder.CompletionConstraintFromContext(ctx)
+	//	schemaPath, ok := schemacontext.FromContext(ctx)
+	CandidateHook CandidateHookFunc
+
+	// TokenizeUnknownAttributes enables semantic tokens for names
+	// of attributes which are not declared in the schema,
+	// so that editors can highlight them as attributes.
+	// Values of such attributes are not tokenized.
+	TokenizeUnknownAttributes bool
+}
+
+// CandidateHookFunc is the function signature for PathContext.CandidateHook
+type CandidateHookFunc func(ctx context.Context, candidates []lang.Candidate) []lang.Candidate
+
+// functionSignature returns signature of the named function
+// from either Functions or CtyFunctions.
+func (pathCtx *PathContext) functionSignature(name string) (schema.FunctionSignature, bool) {
+	if sig, ok := pathCtx.Functions[name]; ok {
+		return normalizedFunctionSignature(sig), true
+	}
+	if f, ok := pathCtx.CtyFunctions[name]; ok {
+		return schema.FunctionSignatureFromCty(f), true
+	}
+	return schema.FunctionSignature{}, false
+}
+
+// functionSignatures returns signatures of all functions
+// from both Functions and CtyFunctions.
+func (pathCtx *PathContext) functionSignatures() map[string]schema.FunctionSignature {
+	signatures := schema.FunctionSignaturesFromCty(pathCtx.CtyFunctions)
+	for name, sig := range pathCtx.Functions {
+		signatures[name] = normalizedFunctionSignature(sig)
+	}
+	return signatures
+}
+
+// normalizedFunctionSignature treats undeclared return type
+// as one which is not known statically, i.e. dynamic.
+func normalizedFunctionSignature(sig schema.FunctionSignature) schema.FunctionSignature {
+	if sig.ReturnType == cty.NilType {
+		sig.ReturnType = cty.DynamicPseudoType
+	}
+	return sig
 }
 
 type pathCtxKey struct{}
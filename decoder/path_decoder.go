@@ -4,6 +4,7 @@
 package decoder
 
 import (
+	"context"
 	"sort"
 
 	"github.com/hashicorp/hcl-lang/lang"
@@ -23,22 +24,66 @@ type PathDecoder struct {
 	// with required attributes and blocks
 	// TODO: Move under DecoderContext
 	PrefillRequiredFields bool
+
+	functionSigs *functionSignaturesCache
 }
 
 func (d *Decoder) Path(path lang.Path) (*PathDecoder, error) {
 	pathCtx, err := d.pathReader.PathContext(path)
 
-	return &PathDecoder{
+	pd := &PathDecoder{
 		path:          path,
 		pathCtx:       pathCtx,
 		decoderCtx:    d.ctx,
 		maxCandidates: 100,
-	}, err
+	}
+	if pathCtx != nil {
+		pd.functionSigs = d.functionSignaturesCacheFor(path, pathCtx.Version)
+	}
+
+	return pd, err
+}
+
+// DecodableFiles returns names of files within the given path
+// which have native HCL syntax and can therefore be decoded
+// for the purposes of e.g. semantic tokens.
+//
+// JSON files are excluded, as these are classified
+// as unknown file format by SemanticTokensInFile.
+func (d *Decoder) DecodableFiles(path lang.Path) ([]string, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if pd.pathCtx.Schema == nil {
+		return nil, &NoSchemaError{}
+	}
+
+	files := make([]string, 0)
+	for _, filename := range pd.filenames() {
+		f, err := pd.fileByName(filename)
+		if err != nil {
+			continue
+		}
+		if _, isHcl := f.Body.(*hclsyntax.Body); isHcl {
+			files = append(files, filename)
+		}
+	}
+
+	return files, nil
+}
+
+// pathContext enriches the given context with the path reader
+// and the context of the given path decoder
+func (d *Decoder) pathContext(ctx context.Context, pd *PathDecoder) context.Context {
+	ctx = withPathContext(ctx, pd.pathCtx)
+	return withPathReader(ctx, d.pathReader)
 }
 
 func (d *PathDecoder) bytesForFile(file string) ([]byte, error) {
 	f, ok := d.pathCtx.Files[file]
-	if !ok {
+	if !ok || f == nil {
 		return nil, &FileNotFoundError{Filename: file}
 	}
 
@@ -72,9 +117,22 @@ func (d *PathDecoder) fileByName(name string) (*hcl.File, error) {
 	if !ok {
 		return nil, &FileNotFoundError{Filename: name}
 	}
+	if hasNilBody(f) {
+		return nil, &UnknownFileFormatError{Filename: name}
+	}
 	return f, nil
 }
 
+// hasNilBody returns true if the given file or its body is nil,
+// i.e. the file cannot be decoded (e.g. due to a failed parse)
+func hasNilBody(f *hcl.File) bool {
+	if f == nil || f.Body == nil {
+		return true
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	return ok && body == nil
+}
+
 func (d *PathDecoder) bodyForFileAndPos(name string, f *hcl.File, pos hcl.Pos) (*hclsyntax.Body, error) {
 	body, isHcl := f.Body.(*hclsyntax.Body)
 	if !isHcl {
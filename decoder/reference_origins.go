@@ -61,6 +61,51 @@ func (d *Decoder) ReferenceOriginsTargetingPos(path lang.Path, file string, pos
 	return origins
 }
 
+// UnresolvedOrigins returns reference origins within the given path
+// which do not match any reference target, such as references
+// to undeclared variables.
+//
+// Origins matching targets of unknown (dynamic) type loosely
+// are considered resolved.
+func (d *Decoder) UnresolvedOrigins(path lang.Path) (reference.Origins, error) {
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return nil, err
+	}
+
+	origins := make(reference.Origins, 0)
+	for _, origin := range pathCtx.ReferenceOrigins {
+		targetCtx := pathCtx
+
+		if _, ok := origin.(reference.DirectOrigin); ok {
+			// direct origins point to a known range
+			continue
+		}
+		if pathOrigin, ok := origin.(reference.PathOrigin); ok {
+			ctx, err := d.pathReader.PathContext(pathOrigin.TargetPath)
+			if err != nil {
+				// target path is not known, so we cannot tell
+				continue
+			}
+			targetCtx = ctx
+		}
+
+		matchableOrigin, ok := origin.(reference.MatchableOrigin)
+		if !ok {
+			continue
+		}
+		if _, ok := targetCtx.ReferenceTargets.Match(matchableOrigin); ok {
+			continue
+		}
+
+		origins = append(origins, origin.Copy())
+	}
+
+	origins.Sort()
+
+	return origins, nil
+}
+
 func (d *PathDecoder) CollectReferenceOrigins() (reference.Origins, error) {
 	refOrigins := make(reference.Origins, 0)
 	impliedOrigins := make([]schema.ImpliedOrigin, 0)
@@ -83,6 +128,16 @@ func (d *PathDecoder) CollectReferenceOrigins() (reference.Origins, error) {
 		impliedOrigins = append(impliedOrigins, ios...)
 	}
 
+	refOrigins = resolveImpliedOrigins(refOrigins, impliedOrigins)
+
+	refOrigins.Sort()
+
+	return refOrigins, nil
+}
+
+// resolveImpliedOrigins returns the given origins along with path origins
+// for any local origins matching the given implied origins.
+func resolveImpliedOrigins(refOrigins reference.Origins, impliedOrigins []schema.ImpliedOrigin) reference.Origins {
 	for _, impliedOrigin := range impliedOrigins {
 		for _, origin := range refOrigins {
 			localOrigin, ok := origin.(reference.LocalOrigin)
@@ -103,14 +158,7 @@ func (d *PathDecoder) CollectReferenceOrigins() (reference.Origins, error) {
 		}
 	}
 
-	sort.SliceStable(refOrigins, func(i, j int) bool {
-		if refOrigins[i].OriginRange().Filename != refOrigins[j].OriginRange().Filename {
-			return refOrigins[i].OriginRange().Filename < refOrigins[j].OriginRange().Filename
-		}
-		return refOrigins[i].OriginRange().Start.Byte < refOrigins[j].OriginRange().Start.Byte
-	})
-
-	return refOrigins, nil
+	return refOrigins
 }
 
 func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.BodySchema) (reference.Origins, []schema.ImpliedOrigin) {
@@ -127,61 +175,12 @@ func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.B
 	content := ast.DecodeBody(body, bodySchema)
 
 	for _, attr := range content.Attributes {
-		var aSchema *schema.AttributeSchema
-		if bodySchema.Extensions != nil && bodySchema.Extensions.Count && attr.Name == "count" {
-			aSchema = schemahelper.CountAttributeSchema()
-		} else if bodySchema.Extensions != nil && bodySchema.Extensions.ForEach && attr.Name == "for_each" {
-			aSchema = schemahelper.ForEachAttributeSchema()
-		} else {
-			var ok bool
-			aSchema, ok = bodySchema.Attributes[attr.Name]
-			if !ok {
-				if bodySchema.AnyAttribute == nil {
-					// skip unknown attribute
-					continue
-				}
-				aSchema = bodySchema.AnyAttribute
-			}
-		}
-
-		if aSchema.OriginForTarget != nil {
-			targetAddr, ok := resolveAttributeAddress(attr, aSchema.OriginForTarget.Address)
-			if ok {
-				origins = append(origins, reference.PathOrigin{
-					Range:      attr.NameRange,
-					TargetAddr: targetAddr,
-					TargetPath: aSchema.OriginForTarget.Path,
-					Constraints: reference.OriginConstraints{
-						{
-							OfScopeId: aSchema.OriginForTarget.Constraints.ScopeId,
-							OfType:    aSchema.OriginForTarget.Constraints.Type,
-						},
-					},
-				})
-			}
-		}
-
-		if aSchema.IsDepKey && bodySchema.Targets != nil {
-			origins = append(origins, reference.DirectOrigin{
-				Range:       attr.Expr.Range(),
-				TargetPath:  bodySchema.Targets.Path,
-				TargetRange: bodySchema.Targets.Range,
-			})
-		}
-
-		allowSelfRefs := false
-		if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
-			allowSelfRefs = true
-		}
-		expr := d.newExpression(attr.Expr, aSchema.Constraint)
-		if eType, ok := expr.(ReferenceOriginsExpression); ok {
-			origins = append(origins, eType.ReferenceOrigins(ctx, allowSelfRefs)...)
-		}
+		origins = append(origins, d.referenceOriginsForAttribute(ctx, attr, bodySchema)...)
 	}
 
 	for _, block := range content.Blocks {
 		if block.Body != nil {
-			bSchema, ok := bodySchema.Blocks[block.Type]
+			bSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 			if !ok {
 				// skip unknown blocks
 				continue
@@ -196,3 +195,51 @@ func (d *PathDecoder) referenceOriginsInBody(body hcl.Body, bodySchema *schema.B
 
 	return origins, impliedOrigins
 }
+
+// referenceOriginsForAttribute returns origins of the given attribute
+// within a body of the given schema.
+func (d *PathDecoder) referenceOriginsForAttribute(ctx context.Context, attr *hcl.Attribute, bodySchema *schema.BodySchema) reference.Origins {
+	origins := make(reference.Origins, 0)
+
+	aSchema, ok := schemahelper.AttributeSchema(bodySchema, attr.Name)
+	if !ok {
+		// skip unknown attribute
+		return origins
+	}
+
+	if aSchema.OriginForTarget != nil {
+		targetAddr, ok := resolveAttributeAddress(attr, aSchema.OriginForTarget.Address)
+		if ok {
+			origins = append(origins, reference.PathOrigin{
+				Range:      attr.NameRange,
+				TargetAddr: targetAddr,
+				TargetPath: aSchema.OriginForTarget.Path,
+				Constraints: reference.OriginConstraints{
+					{
+						OfScopeId: aSchema.OriginForTarget.Constraints.ScopeId,
+						OfType:    aSchema.OriginForTarget.Constraints.Type,
+					},
+				},
+			})
+		}
+	}
+
+	if aSchema.IsDepKey && bodySchema.Targets != nil {
+		origins = append(origins, reference.DirectOrigin{
+			Range:       attr.Expr.Range(),
+			TargetPath:  bodySchema.Targets.Path,
+			TargetRange: bodySchema.Targets.Range,
+		})
+	}
+
+	allowSelfRefs := false
+	if bodySchema.Extensions != nil && bodySchema.Extensions.SelfRefs {
+		allowSelfRefs = true
+	}
+	expr := d.newExpression(attr.Expr, aSchema.Constraint)
+	if eType, ok := expr.(ReferenceOriginsExpression); ok {
+		origins = append(origins, eType.ReferenceOrigins(ctx, allowSelfRefs)...)
+	}
+
+	return origins
+}
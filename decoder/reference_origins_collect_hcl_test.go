@@ -925,6 +925,76 @@ tup = [ var.three ]
 				},
 			},
 		},
+		{
+			"depends_on extension",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type"},
+							{Name: "name"},
+						},
+						Body: &schema.BodySchema{
+							Extensions: &schema.BodyExtensions{
+								DependsOn: true,
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" "foo" {
+  depends_on = [aws_instance.bar, module.baz]
+}
+`,
+			reference.Origins{
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "aws_instance"},
+						lang.AttrStep{Name: "bar"},
+					},
+					Constraints: reference.OriginConstraints{
+						{OfScopeId: schema.ResourceScopeId},
+						{OfScopeId: schema.ModuleScopeId},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   2,
+							Column: 17,
+							Byte:   48,
+						},
+						End: hcl.Pos{
+							Line:   2,
+							Column: 33,
+							Byte:   64,
+						},
+					},
+				},
+				reference.LocalOrigin{
+					Addr: lang.Address{
+						lang.RootStep{Name: "module"},
+						lang.AttrStep{Name: "baz"},
+					},
+					Constraints: reference.OriginConstraints{
+						{OfScopeId: schema.ResourceScopeId},
+						{OfScopeId: schema.ModuleScopeId},
+					},
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   2,
+							Column: 35,
+							Byte:   66,
+						},
+						End: hcl.Pos{
+							Line:   2,
+							Column: 45,
+							Byte:   76,
+						},
+					},
+				},
+			},
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%2d-%s", i, tc.name), func(t *testing.T) {
@@ -763,3 +763,71 @@ func TestReferenceOriginsTargetingPos(t *testing.T) {
 		})
 	}
 }
+
+func TestUnresolvedOrigins(t *testing.T) {
+	dirPath := t.TempDir()
+
+	localOrigin := func(filename string, startByte int, steps ...string) reference.LocalOrigin {
+		addr := lang.Address{lang.RootStep{Name: steps[0]}}
+		length := len(steps[0])
+		for _, step := range steps[1:] {
+			addr = append(addr, lang.AttrStep{Name: step})
+			length += len(step) + 1
+		}
+		return reference.LocalOrigin{
+			Addr: addr,
+			Range: hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: 1, Column: startByte + 1, Byte: startByte},
+				End:      hcl.Pos{Line: 1, Column: startByte + length + 1, Byte: startByte + length},
+			},
+			Constraints: reference.OriginConstraints{
+				{OfType: cty.String},
+			},
+		}
+	}
+
+	pathCtx := &PathContext{
+		ReferenceOrigins: reference.Origins{
+			localOrigin("b.tf", 7, "var", "missing"),
+			localOrigin("a.tf", 30, "var", "other"),
+			localOrigin("a.tf", 7, "var", "str"),
+			localOrigin("a.tf", 20, "var", "any", "foo"),
+		},
+		ReferenceTargets: reference.Targets{
+			{
+				Addr: lang.Address{
+					lang.RootStep{Name: "var"},
+					lang.AttrStep{Name: "str"},
+				},
+				Type: cty.String,
+			},
+			{
+				Addr: lang.Address{
+					lang.RootStep{Name: "var"},
+					lang.AttrStep{Name: "any"},
+				},
+				Type: cty.DynamicPseudoType,
+			},
+		},
+	}
+
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: pathCtx,
+		},
+	})
+
+	origins, err := d.UnresolvedOrigins(lang.Path{Path: dirPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedOrigins := reference.Origins{
+		localOrigin("a.tf", 30, "var", "other"),
+		localOrigin("b.tf", 7, "var", "missing"),
+	}
+	if diff := cmp.Diff(expectedOrigins, origins, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("mismatch of unresolved origins: %s", diff)
+	}
+}
@@ -14,4 +14,8 @@ type ReferenceTarget struct {
 	Path        lang.Path
 	Range       hcl.Range
 	DefRangePtr *hcl.Range
+
+	// TypeDefRangePtr represents a range where the type
+	// of the target is declared (if any)
+	TypeDefRangePtr *hcl.Range
 }
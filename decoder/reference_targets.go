@@ -70,10 +70,11 @@ func (d *Decoder) ReferenceTargetsForOriginAtPos(path lang.Path, file string, po
 				continue
 			}
 			matchingTargets = append(matchingTargets, &ReferenceTarget{
-				OriginRange: origin.OriginRange(),
-				Path:        targetPath,
-				Range:       *target.RangePtr,
-				DefRangePtr: target.DefRangePtr,
+				OriginRange:     origin.OriginRange(),
+				Path:            targetPath,
+				Range:           *target.RangePtr,
+				DefRangePtr:     target.DefRangePtr,
+				TypeDefRangePtr: target.TypeDefRangePtr,
 			})
 		}
 	}
@@ -81,6 +82,39 @@ func (d *Decoder) ReferenceTargetsForOriginAtPos(path lang.Path, file string, po
 	return matchingTargets, nil
 }
 
+// TypeDefinitionAtPos returns targets of the reference at the given position
+// where Range represents the declaration of the target's type,
+// e.g. type = string in a variable block.
+//
+// Definition range (or the whole range) of the target is used
+// if the type is not explicitly declared.
+func (d *Decoder) TypeDefinitionAtPos(path lang.Path, file string, pos hcl.Pos) (ReferenceTargets, error) {
+	targets, err := d.ReferenceTargetsForOriginAtPos(path, file, pos)
+	if err != nil {
+		return targets, err
+	}
+
+	typeDefTargets := make(ReferenceTargets, 0)
+	for _, target := range targets {
+		rng := target.Range
+		if target.TypeDefRangePtr != nil {
+			rng = *target.TypeDefRangePtr
+		} else if target.DefRangePtr != nil {
+			rng = *target.DefRangePtr
+		}
+
+		typeDefTargets = append(typeDefTargets, &ReferenceTarget{
+			OriginRange:     target.OriginRange,
+			Path:            target.Path,
+			Range:           rng,
+			DefRangePtr:     target.DefRangePtr,
+			TypeDefRangePtr: target.TypeDefRangePtr,
+		})
+	}
+
+	return typeDefTargets, nil
+}
+
 func (d *PathDecoder) CollectReferenceTargets() (reference.Targets, error) {
 	if d.pathCtx.Schema == nil {
 		// unable to collect reference targets without schema
@@ -98,6 +132,11 @@ func (d *PathDecoder) CollectReferenceTargets() (reference.Targets, error) {
 		refs = append(refs, d.decodeReferenceTargetsForBody(f.Body, nil, d.pathCtx.Schema)...)
 	}
 
+	// the same target may be declared more than once,
+	// e.g. when it is declared in more than one file
+	refs = refs.Deduplicate()
+	refs.Sort()
+
 	return refs, nil
 }
 
@@ -111,30 +150,11 @@ func (d *PathDecoder) decodeReferenceTargetsForBody(body hcl.Body, parentBlock *
 	content := ast.DecodeBody(body, bodySchema)
 
 	for _, attr := range content.Attributes {
-		if bodySchema.Extensions != nil {
-			if bodySchema.Extensions.Count && attr.Name == "count" && content.RangePtr != nil {
-				refs = append(refs, countIndexReferenceTarget(attr, *content.RangePtr))
-				continue
-			}
-			if bodySchema.Extensions.ForEach && attr.Name == "for_each" && content.RangePtr != nil {
-				refs = append(refs, forEachReferenceTargets(attr, *content.RangePtr)...)
-				continue
-			}
-		}
-		attrSchema, ok := bodySchema.Attributes[attr.Name]
-		if !ok {
-			if bodySchema.AnyAttribute == nil {
-				// unknown attribute (no schema)
-				continue
-			}
-			attrSchema = bodySchema.AnyAttribute
-		}
-
-		refs = append(refs, d.decodeReferenceTargetsForAttribute(attr, attrSchema)...)
+		refs = append(refs, d.referenceTargetsForBodyAttribute(attr, content.RangePtr, bodySchema)...)
 	}
 
 	for _, blk := range content.Blocks {
-		bSchema, ok := bodySchema.Blocks[blk.Type]
+		bSchema, ok := schemahelper.BlockSchema(bodySchema, blk.Type)
 		if !ok {
 			// unknown block (no schema)
 			continue
@@ -142,111 +162,163 @@ func (d *PathDecoder) decodeReferenceTargetsForBody(body hcl.Body, parentBlock *
 
 		mergedSchema, _ := schemahelper.MergeBlockBodySchemas(blk.Block, bSchema)
 
-		iRefs := d.decodeReferenceTargetsForBody(blk.Body, blk, mergedSchema)
-		refs = append(refs, iRefs...)
+		refs = append(refs, d.decodeReferenceTargetsForBody(blk.Body, blk, mergedSchema)...)
+		refs = append(refs, d.referenceTargetsForBlock(blk, bSchema)...)
+	}
 
-		addr, ok := resolveBlockAddress(blk.Block, bSchema)
-		if !ok {
-			// skip unresolvable address
-			continue
-		}
+	refs = append(refs, targetableBodyTargets(body, parentBlock, bodySchema)...)
 
-		if bSchema.Address.AsReference {
-			ref := reference.Target{
-				Addr:        addr,
-				ScopeId:     bSchema.Address.ScopeId,
-				DefRangePtr: blk.DefRange.Ptr(),
-				RangePtr:    blk.Range.Ptr(),
-				Name:        bSchema.Address.FriendlyName,
+	sort.Sort(refs)
+
+	return refs
+}
+
+// referenceTargetsForBodyAttribute returns targets of the given attribute
+// within a body of the given schema, including targets implied
+// by extensions of that body (count.index, each.*).
+func (d *PathDecoder) referenceTargetsForBodyAttribute(attr *hcl.Attribute, bodyRangePtr *hcl.Range, bodySchema *schema.BodySchema) reference.Targets {
+	refs := make(reference.Targets, 0)
+
+	// explicitly declared attributes are collected as targets below,
+	// while references implied by extensions (count.index, each.*)
+	// remain available regardless
+	_, isDeclared := bodySchema.Attributes[attr.Name]
+	if bodySchema.Extensions != nil {
+		if bodySchema.Extensions.Count && attr.Name == "count" && bodyRangePtr != nil {
+			refs = append(refs, countIndexReferenceTarget(attr, *bodyRangePtr))
+			if !isDeclared {
+				return refs
+			}
+		}
+		if bodySchema.Extensions.ForEach && attr.Name == "for_each" && bodyRangePtr != nil {
+			refs = append(refs, forEachReferenceTargets(attr, *bodyRangePtr)...)
+			if !isDeclared {
+				return refs
 			}
-			refs = append(refs, ref)
+		}
+	}
+	attrSchema, ok := bodySchema.Attributes[attr.Name]
+	if !ok {
+		if bodySchema.AnyAttribute == nil {
+			// unknown attribute (no schema)
+			return refs
+		}
+		attrSchema = bodySchema.AnyAttribute
+	}
+
+	refs = append(refs, d.decodeReferenceTargetsForAttribute(attr, attrSchema)...)
+
+	return refs
+}
+
+// referenceTargetsForBlock returns targets addressing the given block itself,
+// i.e. excluding any targets declared within its body.
+func (d *PathDecoder) referenceTargetsForBlock(blk *ast.BlockContent, bSchema *schema.BlockSchema) reference.Targets {
+	refs := make(reference.Targets, 0)
+
+	addr, ok := resolveBlockAddress(blk.Block, bSchema)
+	if !ok {
+		// skip unresolvable address
+		return refs
+	}
+
+	if bSchema.Address.AsReference {
+		ref := reference.Target{
+			Addr:        addr,
+			ScopeId:     bSchema.Address.ScopeId,
+			DefRangePtr: blk.DefRange.Ptr(),
+			RangePtr:    blk.Range.Ptr(),
+			Name:        bSchema.Address.FriendlyName,
+		}
+		refs = append(refs, ref)
+	}
+
+	if bSchema.Address.AsTypeOf != nil {
+		refs = append(refs, referenceAsTypeOf(blk.Block, blk.Range.Ptr(), bSchema, addr)...)
+	}
+
+	var bodyRef reference.Target
+
+	if bSchema.Address.BodyAsData {
+		bodyRef = reference.Target{
+			Addr:        addr,
+			ScopeId:     bSchema.Address.ScopeId,
+			DefRangePtr: blk.DefRange.Ptr(),
+			RangePtr:    blk.Range.Ptr(),
+		}
+
+		if bSchema.Body != nil {
+			bodyRef.Description = bSchema.Body.Description
 		}
 
-		if bSchema.Address.AsTypeOf != nil {
-			refs = append(refs, referenceAsTypeOf(blk.Block, blk.Range.Ptr(), bSchema, addr)...)
+		if bSchema.Address.InferBody && bSchema.Body != nil {
+			var localAddr lang.Address
+			if bSchema.Address.BodySelfRef {
+				localAddr = lang.Address{
+					lang.RootStep{Name: "self"},
+				}
+				bodyRef.TargetableFromRangePtr = blk.Range.Ptr()
+			}
+			bodyRef.NestedTargets = append(bodyRef.NestedTargets,
+				d.collectInferredReferenceTargetsForBody(addr, bSchema.Address, blk.Body, bSchema.Body, nil, localAddr)...)
 		}
 
-		var bodyRef reference.Target
+		bodyRef.Type = bodyToDataType(bSchema.Type, bSchema.Body)
+
+		refs = append(refs, bodyRef)
+	}
 
-		if bSchema.Address.BodyAsData {
+	if bSchema.Address.DependentBodyAsData {
+		if !bSchema.Address.BodyAsData {
 			bodyRef = reference.Target{
 				Addr:        addr,
 				ScopeId:     bSchema.Address.ScopeId,
 				DefRangePtr: blk.DefRange.Ptr(),
 				RangePtr:    blk.Range.Ptr(),
 			}
+		}
 
-			if bSchema.Body != nil {
-				bodyRef.Description = bSchema.Body.Description
+		depSchema, _, result := schemahelper.NewBlockSchema(bSchema).DependentBodySchema(blk.Block)
+		if result == schemahelper.LookupSuccessful {
+			fullSchema := depSchema
+			if bSchema.Address.BodyAsData {
+				mergedSchema, _ := schemahelper.MergeBlockBodySchemas(blk.Block, bSchema)
+				bodyRef.NestedTargets = make(reference.Targets, 0)
+				fullSchema = mergedSchema
 			}
 
-			if bSchema.Address.InferBody && bSchema.Body != nil {
-				var localAddr lang.Address
-				if bSchema.Address.BodySelfRef {
-					localAddr = lang.Address{
+			bodyRef.Type = bodyToDataType(bSchema.Type, fullSchema)
+
+			if bSchema.Address.InferDependentBody && len(bSchema.DependentBody) > 0 {
+				if bSchema.Address.DependentBodySelfRef {
+					bodyRef.LocalAddr = lang.Address{
 						lang.RootStep{Name: "self"},
 					}
 					bodyRef.TargetableFromRangePtr = blk.Range.Ptr()
+				} else {
+					bodyRef.LocalAddr = lang.Address{}
 				}
+
 				bodyRef.NestedTargets = append(bodyRef.NestedTargets,
-					d.collectInferredReferenceTargetsForBody(addr, bSchema.Address, blk.Body, bSchema.Body, nil, localAddr)...)
+					d.collectInferredReferenceTargetsForBody(addr, bSchema.Address, blk.Body, fullSchema, nil, bodyRef.LocalAddr)...)
 			}
 
-			bodyRef.Type = bodyToDataType(bSchema.Type, bSchema.Body)
-
-			refs = append(refs, bodyRef)
-		}
-
-		if bSchema.Address.DependentBodyAsData {
 			if !bSchema.Address.BodyAsData {
-				bodyRef = reference.Target{
-					Addr:        addr,
-					ScopeId:     bSchema.Address.ScopeId,
-					DefRangePtr: blk.DefRange.Ptr(),
-					RangePtr:    blk.Range.Ptr(),
-				}
-			}
-
-			depSchema, _, result := schemahelper.NewBlockSchema(bSchema).DependentBodySchema(blk.Block)
-			if result == schemahelper.LookupSuccessful {
-				fullSchema := depSchema
-				if bSchema.Address.BodyAsData {
-					mergedSchema, _ := schemahelper.MergeBlockBodySchemas(blk.Block, bSchema)
-					bodyRef.NestedTargets = make(reference.Targets, 0)
-					fullSchema = mergedSchema
-				}
-
-				bodyRef.Type = bodyToDataType(bSchema.Type, fullSchema)
-
-				if bSchema.Address.InferDependentBody && len(bSchema.DependentBody) > 0 {
-					if bSchema.Address.DependentBodySelfRef {
-						bodyRef.LocalAddr = lang.Address{
-							lang.RootStep{Name: "self"},
-						}
-						bodyRef.TargetableFromRangePtr = blk.Range.Ptr()
-					} else {
-						bodyRef.LocalAddr = lang.Address{}
-					}
-
-					bodyRef.NestedTargets = append(bodyRef.NestedTargets,
-						d.collectInferredReferenceTargetsForBody(addr, bSchema.Address, blk.Body, fullSchema, nil, bodyRef.LocalAddr)...)
-				}
-
-				if !bSchema.Address.BodyAsData {
-					refs = append(refs, bodyRef)
-				}
+				refs = append(refs, bodyRef)
 			}
 		}
-
-		sort.Sort(bodyRef.NestedTargets)
 	}
 
+	sort.Sort(bodyRef.NestedTargets)
+
+	return refs
+}
+
+func targetableBodyTargets(body hcl.Body, parentBlock *ast.BlockContent, bodySchema *schema.BodySchema) reference.Targets {
+	refs := make(reference.Targets, 0)
 	for _, tb := range bodySchema.TargetableAs {
 		refs = append(refs, decodeTargetableBody(body, parentBlock, tb))
 	}
-
-	sort.Sort(refs)
-
 	return refs
 }
 
@@ -273,7 +345,7 @@ func decodeTargetableBody(body hcl.Body, parentBlock *ast.BlockContent, tt *sche
 func (d *PathDecoder) decodeReferenceTargetsForAttribute(attr *hcl.Attribute, attrSchema *schema.AttributeSchema) reference.Targets {
 	refs := make(reference.Targets, 0)
 
-	ctx := context.Background()
+	ctx := d.referenceTargetsContext()
 
 	expr := d.newExpression(attr.Expr, attrSchema.Constraint)
 	if eType, ok := expr.(ReferenceTargetsExpression); ok {
@@ -311,6 +383,23 @@ func (d *PathDecoder) decodeReferenceTargetsForAttribute(attr *hcl.Attribute, at
 	return refs
 }
 
+// referenceTargetsContext returns context for collecting reference
+// targets of expressions, reflecting the decoder context.
+func (d *PathDecoder) referenceTargetsContext() context.Context {
+	return withReferenceTargetValues(context.Background(), d.decoderCtx.ReferenceTargetValues)
+}
+
+type referenceTargetValuesKey struct{}
+
+func withReferenceTargetValues(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, referenceTargetValuesKey{}, enabled)
+}
+
+func referenceTargetValuesFromContext(ctx context.Context) bool {
+	enabled, _ := ctx.Value(referenceTargetValuesKey{}).(bool)
+	return enabled
+}
+
 func referenceAsTypeOf(block *hcl.Block, rngPtr *hcl.Range, bSchema *schema.BlockSchema, addr lang.Address) reference.Targets {
 	ref := reference.Target{
 		Addr:        addr,
@@ -336,6 +425,7 @@ func referenceAsTypeOf(block *hcl.Block, rngPtr *hcl.Range, bSchema *schema.Bloc
 			return reference.Targets{ref}
 		}
 		ref.Type = typeDecl
+		ref.TypeDefRangePtr = attrs[bSchema.Address.AsTypeOf.AttributeExpr].Range.Ptr()
 	}
 
 	return reference.Targets{ref}
@@ -457,7 +547,7 @@ func (d *PathDecoder) collectInferredReferenceTargetsForBody(addr lang.Address,
 		}
 		expr, ok := newExpression(d.pathCtx, attrExpr, aSchema.Constraint).(ReferenceTargetsExpression)
 		if ok {
-			ctx := context.Background()
+			ctx := d.referenceTargetsContext()
 			refs = append(refs, expr.ReferenceTargets(ctx, targetCtx)...)
 		}
 	}
@@ -676,21 +766,22 @@ func blocksTypesWithSchema(body hcl.Body, bodySchema *schema.BodySchema) blockTy
 	content := ast.DecodeBody(body, bodySchema)
 
 	for _, block := range content.Blocks {
-		bSchema, ok := bodySchema.Blocks[block.Type]
+		bSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 		if !ok {
 			// skip unknown block
 			continue
 		}
 
-		_, ok = blockTypes[block.Type]
+		blockType := schemahelper.CanonicalBlockType(bodySchema, block.Type)
+		_, ok = blockTypes[blockType]
 		if !ok {
-			blockTypes[block.Type] = &blockCollection{
+			blockTypes[blockType] = &blockCollection{
 				Schema: bSchema,
 				Blocks: make([]*ast.BlockContent, 0),
 			}
 		}
 
-		blockTypes[block.Type].Blocks = append(blockTypes[block.Type].Blocks, block)
+		blockTypes[blockType].Blocks = append(blockTypes[blockType].Blocks, block)
 	}
 
 	return blockTypes
@@ -773,6 +864,13 @@ func resolveBlockAddress(block *hcl.Block, blockSchema *schema.BlockSchema) (lan
 				return lang.Address{}, false
 			}
 			stepName = block.Labels[step.Index]
+		case schema.NameStep:
+			name, ok := blockName(block, blockSchema)
+			if !ok {
+				// name not present
+				return lang.Address{}, false
+			}
+			stepName = name
 		case schema.AttrValueStep:
 			content := ast.DecodeBody(block.Body, blockSchema.Body)
 
@@ -818,3 +916,40 @@ func resolveBlockAddress(block *hcl.Block, blockSchema *schema.BlockSchema) (lan
 
 	return address, true
 }
+
+// blockName returns name of the given block as declared
+// by the label or attribute marked as IsName in the schema
+func blockName(block *hcl.Block, blockSchema *schema.BlockSchema) (string, bool) {
+	for i, labelSchema := range blockSchema.Labels {
+		if !labelSchema.IsName {
+			continue
+		}
+		if i >= len(block.Labels) {
+			return "", false
+		}
+		return block.Labels[i], true
+	}
+
+	if blockSchema.Body == nil {
+		return "", false
+	}
+
+	content := ast.DecodeBody(block.Body, blockSchema.Body)
+	for name, attrSchema := range blockSchema.Body.Attributes {
+		if !attrSchema.IsName {
+			continue
+		}
+
+		attr, ok := content.Attributes[name]
+		if !ok || attr.Expr == nil {
+			return "", false
+		}
+		val, _ := attr.Expr.Value(nil)
+		if !val.IsWhollyKnown() || val.IsNull() || val.Type() != cty.String {
+			return "", false
+		}
+		return val.AsString(), true
+	}
+
+	return "", false
+}
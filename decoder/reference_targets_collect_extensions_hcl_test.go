@@ -1159,6 +1159,83 @@ func TestCollectReferenceTargets_extension_hcl(t *testing.T) {
 				},
 			},
 		},
+		{
+			"count.index collected alongside explicitly declared count attribute",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"resource": {
+						Labels: []*schema.LabelSchema{
+							{Name: "type"},
+							{Name: "name"},
+						},
+						Body: &schema.BodySchema{
+							Extensions: &schema.BodyExtensions{
+								Count: true,
+							},
+							Attributes: map[string]*schema.AttributeSchema{
+								"count": {
+									IsOptional: true,
+									Constraint: schema.LiteralType{Type: cty.Number},
+									Address: &schema.AttributeAddrSchema{
+										Steps: schema.Address{
+											schema.StaticStep{Name: "meta"},
+											schema.AttrNameStep{},
+										},
+										AsReference: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			`resource "aws_instance" "blah" {
+  count = 2
+}
+`,
+			reference.Targets{
+				{
+					LocalAddr: lang.Address{
+						lang.RootStep{Name: "count"},
+						lang.AttrStep{Name: "index"},
+					},
+					TargetableFromRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 32, Byte: 31},
+						End:      hcl.Pos{Line: 3, Column: 2, Byte: 46},
+					},
+					Type:        cty.Number,
+					Description: lang.Markdown("The distinct index number (starting with 0) corresponding to the instance"),
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 35},
+						End:      hcl.Pos{Line: 2, Column: 12, Byte: 44},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 35},
+						End:      hcl.Pos{Line: 2, Column: 8, Byte: 40},
+					},
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "meta"},
+						lang.AttrStep{Name: "count"},
+					},
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 35},
+						End:      hcl.Pos{Line: 2, Column: 12, Byte: 44},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 35},
+						End:      hcl.Pos{Line: 2, Column: 8, Byte: 40},
+					},
+					NestedTargets: reference.Targets{},
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -1183,3 +1260,63 @@ func TestCollectReferenceTargets_extension_hcl(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectReferenceTargets_extension_hcl_multipleBlocks(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Count:   true,
+						ForEach: true,
+					},
+				},
+			},
+		},
+	}
+	cfg := `resource "aws_instance" "foo" {
+  count = 1
+}
+resource "aws_instance" "bar" {
+  count = 2
+}
+resource "aws_instance" "baz" {
+  for_each = {}
+}
+resource "aws_instance" "qux" {
+  for_each = {}
+}
+`
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	refs, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each block declares its own local targets,
+	// which must not be deduplicated across blocks
+	counts := make(map[string]int, 0)
+	for _, ref := range refs {
+		counts[ref.LocalAddr.String()]++
+	}
+	expectedCounts := map[string]int{
+		"count.index": 2,
+		"each.key":    2,
+		"each.value":  2,
+	}
+	if diff := cmp.Diff(expectedCounts, counts); diff != "" {
+		t.Fatalf("unexpected local targets: %s", diff)
+	}
+}
@@ -3671,6 +3671,159 @@ provider "test" {
 				},
 			},
 		},
+		{
+			"block with label name in address",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"variable": {
+						Labels: []*schema.LabelSchema{
+							{Name: "name", IsName: true},
+						},
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.StaticStep{Name: "var"},
+								schema.NameStep{},
+							},
+							ScopeId:     lang.ScopeId("variable"),
+							AsReference: true,
+						},
+					},
+				},
+			},
+			`variable "foo" {
+}
+`,
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+					},
+					ScopeId: lang.ScopeId("variable"),
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   1,
+							Column: 1,
+							Byte:   0,
+						},
+						End: hcl.Pos{
+							Line:   2,
+							Column: 2,
+							Byte:   18,
+						},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   1,
+							Column: 1,
+							Byte:   0,
+						},
+						End: hcl.Pos{
+							Line:   1,
+							Column: 15,
+							Byte:   14,
+						},
+					},
+				},
+			},
+		},
+		{
+			"block with attribute name in address",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"policy": {
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.StaticStep{Name: "policy"},
+								schema.NameStep{},
+							},
+							ScopeId:     lang.ScopeId("policy"),
+							AsReference: true,
+						},
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"name": {
+									IsRequired: true,
+									IsName:     true,
+									Constraint: schema.LiteralType{Type: cty.String},
+								},
+							},
+						},
+					},
+				},
+			},
+			`policy {
+  name = "admin"
+}
+`,
+			reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "policy"},
+						lang.AttrStep{Name: "admin"},
+					},
+					ScopeId: lang.ScopeId("policy"),
+					RangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   1,
+							Column: 1,
+							Byte:   0,
+						},
+						End: hcl.Pos{
+							Line:   3,
+							Column: 2,
+							Byte:   27,
+						},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   1,
+							Column: 1,
+							Byte:   0,
+						},
+						End: hcl.Pos{
+							Line:   1,
+							Column: 7,
+							Byte:   6,
+						},
+					},
+				},
+			},
+		},
+		{
+			"block with missing name attribute",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"policy": {
+						Address: &schema.BlockAddrSchema{
+							Steps: []schema.AddrStep{
+								schema.StaticStep{Name: "policy"},
+								schema.NameStep{},
+							},
+							ScopeId:     lang.ScopeId("policy"),
+							AsReference: true,
+						},
+						Body: &schema.BodySchema{
+							Attributes: map[string]*schema.AttributeSchema{
+								"name": {
+									IsRequired: true,
+									IsName:     true,
+									Constraint: schema.LiteralType{Type: cty.String},
+								},
+							},
+						},
+					},
+				},
+			},
+			`policy {
+}
+`,
+			reference.Targets{},
+		},
 		{
 			"block as data type per attribute - undeclared",
 			&schema.BodySchema{
@@ -3794,6 +3947,19 @@ provider "test" {
 							Byte:   15,
 						},
 					},
+					TypeDefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   2,
+							Column: 3,
+							Byte:   20,
+						},
+						End: hcl.Pos{
+							Line:   2,
+							Column: 21,
+							Byte:   38,
+						},
+					},
 				},
 			},
 		},
@@ -3939,6 +4105,19 @@ provider "test" {
 							Byte:   15,
 						},
 					},
+					TypeDefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start: hcl.Pos{
+							Line:   2,
+							Column: 3,
+							Byte:   20,
+						},
+						End: hcl.Pos{
+							Line:   2,
+							Column: 19,
+							Byte:   36,
+						},
+					},
 				},
 			},
 		},
@@ -3693,6 +3693,19 @@ func TestCollectReferenceTargets_json(t *testing.T) {
 							Byte:   31,
 						},
 					},
+					TypeDefRangePtr: &hcl.Range{
+						Filename: "test.tf.json",
+						Start: hcl.Pos{
+							Line:   4,
+							Column: 7,
+							Byte:   38,
+						},
+						End: hcl.Pos{
+							Line:   4,
+							Column: 28,
+							Byte:   59,
+						},
+					},
 				},
 			},
 		},
@@ -3844,6 +3857,19 @@ func TestCollectReferenceTargets_json(t *testing.T) {
 							Byte:   31,
 						},
 					},
+					TypeDefRangePtr: &hcl.Range{
+						Filename: "test.tf.json",
+						Start: hcl.Pos{
+							Line:   4,
+							Column: 7,
+							Byte:   38,
+						},
+						End: hcl.Pos{
+							Line:   4,
+							Column: 26,
+							Byte:   57,
+						},
+					},
 				},
 			},
 		},
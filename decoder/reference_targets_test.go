@@ -12,7 +12,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty-debug/ctydebug"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -30,6 +32,180 @@ func TestCollectReferenceTargets_noSchema(t *testing.T) {
 	}
 }
 
+func TestCollectReferenceTargets_stableOrder(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					AsReference: true,
+				},
+			},
+		},
+	}
+	files := map[string]string{
+		"a.tf": `resource "zed" "first" {}
+resource "bar" "second" {}
+`,
+		"b.tf": `resource "bar" "first" {}
+resource "foo" "first" {}
+`,
+	}
+	hclFiles := make(map[string]*hcl.File, len(files))
+	for filename, src := range files {
+		f, pDiags := hclsyntax.ParseConfig([]byte(src), filename, hcl.InitialPos)
+		if len(pDiags) > 0 {
+			t.Fatal(pDiags)
+		}
+		hclFiles[filename] = f
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files:  hclFiles,
+	})
+
+	expectedAddrs := []string{
+		"bar.first",
+		"bar.second",
+		"foo.first",
+		"zed.first",
+	}
+
+	var firstTargets reference.Targets
+	for i := 0; i < 10; i++ {
+		targets, err := d.CollectReferenceTargets()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		addrs := make([]string, len(targets))
+		for i, target := range targets {
+			addrs[i] = target.Addr.String()
+		}
+		if diff := cmp.Diff(expectedAddrs, addrs); diff != "" {
+			t.Fatalf("unexpected order of targets: %s", diff)
+		}
+
+		if firstTargets == nil {
+			firstTargets = targets
+			continue
+		}
+		if diff := cmp.Diff(firstTargets, targets, ctydebug.CmpOptions); diff != "" {
+			t.Fatalf("targets differ across runs: %s", diff)
+		}
+	}
+}
+
+func TestCollectReferenceTargets_duplicateTargets(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					AsReference: true,
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"name": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// two different files declare the same target
+	f, pDiags := hclsyntax.ParseConfig([]byte(`resource "aws_instance" "app" {
+  name = "app"
+}
+`), "main.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	overrideFile, pDiags := hclsyntax.ParseConfig([]byte(`
+resource "aws_instance" "app" {
+  name = "override"
+}
+`), "override.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+	otherFile, pDiags := hclsyntax.ParseConfig([]byte(`resource "aws_instance" "db" {}
+`), "other.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"main.tf":     f,
+			"override.tf": overrideFile,
+			"other.tf":    otherFile,
+		},
+	})
+
+	targets, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTargets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "app"},
+			},
+			RangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 3, Column: 2, Byte: 48},
+			},
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 30, Byte: 29},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "aws_instance"},
+				lang.AttrStep{Name: "db"},
+			},
+			RangePtr: &hcl.Range{
+				Filename: "other.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 32, Byte: 31},
+			},
+			DefRangePtr: &hcl.Range{
+				Filename: "other.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 29, Byte: 28},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedTargets, targets, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+}
+
 func TestReferenceTargetForOriginAtPos(t *testing.T) {
 	dirPath := t.TempDir()
 
@@ -352,3 +528,240 @@ func TestReferenceTargetForOriginAtPos(t *testing.T) {
 		})
 	}
 }
+
+func TestReferenceTargetForOriginAtPos_collectionElements(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				Address: &schema.AttributeAddrSchema{
+					Steps: schema.Address{
+						schema.AttrNameStep{},
+					},
+					AsExprType: true,
+				},
+			},
+			"names": {
+				Constraint: schema.List{
+					Elem: schema.Reference{OfType: cty.String},
+				},
+				IsOptional: true,
+			},
+			"nested": {
+				Constraint: schema.List{
+					Elem: schema.List{
+						Elem: schema.Reference{OfType: cty.String},
+					},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+	cfg := `name = "foo"
+names = [name]
+nested = [[name]]
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	dirPath := t.TempDir()
+	path := lang.Path{Path: dirPath}
+	pathCtx := &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	}
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: pathCtx,
+		},
+	})
+	d.SetContext(NewDecoderContext())
+
+	pd, err := d.Path(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathCtx.ReferenceTargets, err = pd.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathCtx.ReferenceOrigins, err = pd.CollectReferenceOrigins()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name            string
+		pos             hcl.Pos
+		expectedTargets ReferenceTargets
+	}{
+		{
+			"list element",
+			hcl.Pos{Line: 2, Column: 10, Byte: 22},
+			ReferenceTargets{
+				&ReferenceTarget{
+					OriginRange: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 10, Byte: 22},
+						End:      hcl.Pos{Line: 2, Column: 14, Byte: 26},
+					},
+					Path: path,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+					},
+				},
+			},
+		},
+		{
+			"nested list element",
+			hcl.Pos{Line: 3, Column: 12, Byte: 39},
+			ReferenceTargets{
+				&ReferenceTarget{
+					OriginRange: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12, Byte: 39},
+						End:      hcl.Pos{Line: 3, Column: 16, Byte: 43},
+					},
+					Path: path,
+					Range: hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			targets, err := d.ReferenceTargetsForOriginAtPos(path, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expectedTargets, targets); diff != "" {
+				t.Fatalf("unexpected targets: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTypeDefinitionAtPos(t *testing.T) {
+	dirPath := t.TempDir()
+	originRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+		End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+	}
+	targetRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 2, Column: 1, Byte: 10},
+		End:      hcl.Pos{Line: 4, Column: 2, Byte: 40},
+	}
+	defRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 2, Column: 1, Byte: 10},
+		End:      hcl.Pos{Line: 2, Column: 8, Byte: 17},
+	}
+	typeDefRange := hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 3, Column: 3, Byte: 21},
+		End:      hcl.Pos{Line: 3, Column: 16, Byte: 34},
+	}
+
+	testCases := []struct {
+		name            string
+		target          reference.Target
+		expectedTargets ReferenceTargets
+	}{
+		{
+			"target with type definition",
+			reference.Target{
+				Addr: lang.Address{
+					lang.RootStep{Name: "one"},
+				},
+				Type:            cty.String,
+				RangePtr:        targetRange.Ptr(),
+				DefRangePtr:     defRange.Ptr(),
+				TypeDefRangePtr: typeDefRange.Ptr(),
+			},
+			ReferenceTargets{
+				{
+					OriginRange:     originRange,
+					Path:            lang.Path{Path: dirPath},
+					Range:           typeDefRange,
+					DefRangePtr:     defRange.Ptr(),
+					TypeDefRangePtr: typeDefRange.Ptr(),
+				},
+			},
+		},
+		{
+			"target without type definition",
+			reference.Target{
+				Addr: lang.Address{
+					lang.RootStep{Name: "one"},
+				},
+				Type:        cty.String,
+				RangePtr:    targetRange.Ptr(),
+				DefRangePtr: defRange.Ptr(),
+			},
+			ReferenceTargets{
+				{
+					OriginRange: originRange,
+					Path:        lang.Path{Path: dirPath},
+					Range:       defRange,
+					DefRangePtr: defRange.Ptr(),
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.name), func(t *testing.T) {
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						ReferenceOrigins: reference.Origins{
+							reference.LocalOrigin{
+								Addr: lang.Address{
+									lang.RootStep{Name: "one"},
+								},
+								Constraints: reference.OriginConstraints{
+									{OfType: cty.String},
+								},
+								Range: originRange,
+							},
+						},
+						ReferenceTargets: reference.Targets{tc.target},
+					},
+				},
+			})
+
+			targets, err := d.TypeDefinitionAtPos(lang.Path{Path: dirPath}, "test.tf", hcl.InitialPos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedTargets, targets); diff != "" {
+				t.Fatalf("mismatched targets: %s", diff)
+			}
+		})
+	}
+}
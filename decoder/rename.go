@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Rename returns text edits (grouped by filename) which rename
+// the reference target under the cursor to newName, i.e. its definition
+// (block label or attribute name) and all origins referencing it
+// (or any of its nested targets) across files of the given path.
+//
+// The cursor may be placed either on the renamed step of a reference
+// origin, or on the name of the definition. Only that single step
+// is rewritten, e.g. renaming app in aws_instance.app.id
+// leaves aws_instance and id intact.
+//
+// RenameConflictError is returned if a target of the new name
+// is already declared.
+func (d *Decoder) Rename(path lang.Path, file string, pos hcl.Pos, newName string) (map[string][]lang.TextEdit, error) {
+	if !hclsyntax.ValidIdentifier(newName) {
+		return nil, fmt.Errorf("invalid name %q", newName)
+	}
+
+	pathCtx, err := d.pathReader.PathContext(path)
+	if err != nil {
+		return nil, err
+	}
+
+	target, ok := renameTargetAtPos(pathCtx, file, pos)
+	if !ok {
+		return nil, &PositionalError{
+			Filename: file,
+			Pos:      pos,
+			Msg:      "no renameable reference found",
+		}
+	}
+
+	stepIdx := len(target.Addr) - 1
+	oldName, ok := addressStepName(target.Addr[stepIdx])
+	if !ok {
+		return nil, &PositionalError{
+			Filename: file,
+			Pos:      pos,
+			Msg:      fmt.Sprintf("%s cannot be renamed", target.Addr),
+		}
+	}
+	if oldName == newName {
+		return map[string][]lang.TextEdit{}, nil
+	}
+
+	newAddr := target.Addr.Copy()
+	newAddr[stepIdx] = renamedAddressStep(newAddr[stepIdx], newName)
+	if _, ok := pathCtx.ReferenceTargets.ResolveAddress(newAddr, false); ok {
+		return nil, &RenameConflictError{Address: newAddr}
+	}
+
+	defRng, defText, ok := definitionNameRange(pathCtx, target, oldName)
+	if !ok {
+		return nil, &PositionalError{
+			Filename: file,
+			Pos:      pos,
+			Msg:      fmt.Sprintf("definition of %s not found", target.Addr),
+		}
+	}
+
+	edits := make(map[string][]lang.TextEdit, 0)
+	seen := make(map[hcl.Range]bool, 0)
+	addEdit := func(rng hcl.Range, newText string) {
+		if seen[rng] {
+			return
+		}
+		seen[rng] = true
+		edits[rng.Filename] = append(edits[rng.Filename], lang.TextEdit{
+			Range:   rng,
+			NewText: newText,
+			Snippet: newText,
+		})
+	}
+
+	addEdit(defRng, fmt.Sprintf(defText, newName))
+
+	for _, origin := range pathCtx.ReferenceOrigins.Match(path, *target, path) {
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+		if !isAddressPrefixOf(target.Addr, localOrigin.Addr) {
+			// e.g. origins matching via local address (self.*)
+			continue
+		}
+		stepRng, stepText, ok := originStepRange(pathCtx, localOrigin, stepIdx)
+		if !ok {
+			continue
+		}
+		addEdit(stepRng, fmt.Sprintf(stepText, newName))
+	}
+
+	for filename := range edits {
+		sort.SliceStable(edits[filename], func(i, j int) bool {
+			return edits[filename][i].Range.Start.Byte < edits[filename][j].Range.Start.Byte
+		})
+	}
+
+	return edits, nil
+}
+
+// renameTargetAtPos returns the target whose address ends with
+// the origin step under the cursor, or whose definition name
+// is under the cursor.
+func renameTargetAtPos(pathCtx *PathContext, file string, pos hcl.Pos) (*reference.Target, bool) {
+	if origins, ok := pathCtx.ReferenceOrigins.AtPos(file, pos); ok {
+		for _, origin := range origins {
+			localOrigin, ok := origin.(reference.LocalOrigin)
+			if !ok {
+				continue
+			}
+			traversal, ok := originTraversal(pathCtx, localOrigin)
+			if !ok {
+				continue
+			}
+			for i, traverser := range traversal {
+				if i >= len(localOrigin.Addr) {
+					break
+				}
+				if !traverser.SourceRange().ContainsPos(pos) {
+					continue
+				}
+				target, ok := pathCtx.ReferenceTargets.ResolveAddress(localOrigin.Addr.FirstSteps(uint(i+1)), false)
+				if ok && len(target.Addr) == i+1 {
+					return target, true
+				}
+			}
+		}
+		return nil, false
+	}
+
+	targets, ok := pathCtx.ReferenceTargets.InnermostAtPos(file, pos)
+	if !ok {
+		return nil, false
+	}
+	for i, target := range targets {
+		if len(target.Addr) == 0 {
+			continue
+		}
+		name, ok := addressStepName(target.Addr[len(target.Addr)-1])
+		if !ok {
+			continue
+		}
+		rng, _, ok := definitionNameRange(pathCtx, &targets[i], name)
+		if ok && rng.ContainsPos(pos) {
+			return &targets[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// definitionNameRange returns the range of the name (label or attribute
+// name) within the definition of the given target, along with a format
+// string for the new text replacing it.
+func definitionNameRange(pathCtx *PathContext, target *reference.Target, name string) (hcl.Range, string, bool) {
+	if target.DefRangePtr == nil {
+		return hcl.Range{}, "", false
+	}
+	defRng := *target.DefRangePtr
+
+	f, ok := pathCtx.Files[defRng.Filename]
+	if !ok {
+		return hcl.Range{}, "", false
+	}
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return hcl.Range{}, "", false
+	}
+
+	return nameRangeInBody(body, defRng, name)
+}
+
+func nameRangeInBody(body *hclsyntax.Body, defRng hcl.Range, name string) (hcl.Range, string, bool) {
+	for attrName, attr := range body.Attributes {
+		if attr.NameRange == defRng && attrName == name {
+			return attr.NameRange, "%s", true
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if block.DefRange() == defRng {
+			// the address usually ends with the last label
+			for i := len(block.Labels) - 1; i >= 0; i-- {
+				if block.Labels[i] == name {
+					return block.LabelRanges[i], "%q", true
+				}
+			}
+			return hcl.Range{}, "", false
+		}
+		if block.Body != nil && block.Body.Range().ContainsPos(defRng.Start) {
+			if rng, text, ok := nameRangeInBody(block.Body, defRng, name); ok {
+				return rng, text, true
+			}
+		}
+	}
+
+	return hcl.Range{}, "", false
+}
+
+// originStepRange returns the range of the given step of the origin
+// traversal, excluding any leading dot, along with a format string
+// for the new text replacing it.
+func originStepRange(pathCtx *PathContext, origin reference.LocalOrigin, stepIdx int) (hcl.Range, string, bool) {
+	traversal, ok := originTraversal(pathCtx, origin)
+	if !ok || stepIdx >= len(traversal) {
+		return hcl.Range{}, "", false
+	}
+
+	rng := traversal[stepIdx].SourceRange()
+	switch t := traversal[stepIdx].(type) {
+	case hcl.TraverseRoot:
+		return rng, "%s", true
+	case hcl.TraverseAttr:
+		// skip the leading dot
+		rng.Start.Byte++
+		rng.Start.Column++
+		return rng, "%s", true
+	case hcl.TraverseIndex:
+		if t.Key.Type() != cty.String {
+			return hcl.Range{}, "", false
+		}
+		return rng, "[%q]", true
+	}
+
+	return hcl.Range{}, "", false
+}
+
+func originTraversal(pathCtx *PathContext, origin reference.LocalOrigin) (hcl.Traversal, bool) {
+	f, ok := pathCtx.Files[origin.Range.Filename]
+	if !ok {
+		return nil, false
+	}
+	if origin.Range.End.Byte > len(f.Bytes) || origin.Range.Start.Byte > origin.Range.End.Byte {
+		return nil, false
+	}
+
+	src := f.Bytes[origin.Range.Start.Byte:origin.Range.End.Byte]
+	traversal, diags := hclsyntax.ParseTraversalAbs(src, origin.Range.Filename, origin.Range.Start)
+	if diags.HasErrors() {
+		return nil, false
+	}
+	return traversal, true
+}
+
+func addressStepName(step lang.AddressStep) (string, bool) {
+	switch s := step.(type) {
+	case lang.RootStep:
+		return s.Name, true
+	case lang.AttrStep:
+		return s.Name, true
+	}
+	return "", false
+}
+
+func renamedAddressStep(step lang.AddressStep, name string) lang.AddressStep {
+	if _, ok := step.(lang.RootStep); ok {
+		return lang.RootStep{Name: name}
+	}
+	return lang.AttrStep{Name: name}
+}
+
+func isAddressPrefixOf(prefix, addr lang.Address) bool {
+	if len(prefix) == 0 || len(prefix) > len(addr) {
+		return false
+	}
+	return addr.FirstSteps(uint(len(prefix))).Equals(prefix)
+}
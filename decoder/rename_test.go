@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDecoder_Rename(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
+					},
+					AsReference: true,
+					ScopeId:     lang.ScopeId("resource"),
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ref": {
+							Constraint: schema.Reference{OfScopeId: lang.ScopeId("resource")},
+							IsOptional: true,
+						},
+						"region_ref": {
+							Constraint: schema.Reference{OfType: cty.String},
+							IsOptional: true,
+						},
+					},
+				},
+			},
+			"locals": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"region": {
+							Constraint: schema.LiteralType{Type: cty.String},
+							IsOptional: true,
+							Address: &schema.AttributeAddrSchema{
+								Steps: []schema.AddrStep{
+									schema.StaticStep{Name: "local"},
+									schema.AttrNameStep{},
+								},
+								AsExprType: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	files := map[string]string{
+		"a.tf": `resource "aws_instance" "app" {
+}
+resource "aws_instance" "db" {
+  ref = aws_instance.app
+}
+locals {
+  region = "eu"
+}
+`,
+		"b.tf": `resource "aws_eip" "ip" {
+  ref = aws_instance.app
+  region_ref = local.region
+}
+`,
+	}
+
+	appEdits := map[string][]lang.TextEdit{
+		"a.tf": {
+			{
+				Range: hcl.Range{
+					Filename: "a.tf",
+					Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+					End:      hcl.Pos{Line: 1, Column: 30, Byte: 29},
+				},
+				NewText: `"web"`,
+				Snippet: `"web"`,
+			},
+			{
+				Range: hcl.Range{
+					Filename: "a.tf",
+					Start:    hcl.Pos{Line: 4, Column: 22, Byte: 86},
+					End:      hcl.Pos{Line: 4, Column: 25, Byte: 89},
+				},
+				NewText: "web",
+				Snippet: "web",
+			},
+		},
+		"b.tf": {
+			{
+				Range: hcl.Range{
+					Filename: "b.tf",
+					Start:    hcl.Pos{Line: 2, Column: 22, Byte: 47},
+					End:      hcl.Pos{Line: 2, Column: 25, Byte: 50},
+				},
+				NewText: "web",
+				Snippet: "web",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		file          string
+		pos           hcl.Pos
+		newName       string
+		expectedEdits map[string][]lang.TextEdit
+		expectedErr   error
+	}{
+		{
+			"block from origin in another file",
+			"b.tf",
+			hcl.Pos{Line: 2, Column: 23, Byte: 48},
+			"web",
+			appEdits,
+			nil,
+		},
+		{
+			"block from definition label",
+			"a.tf",
+			hcl.Pos{Line: 1, Column: 27, Byte: 26},
+			"web",
+			appEdits,
+			nil,
+		},
+		{
+			"attribute from origin",
+			"b.tf",
+			hcl.Pos{Line: 3, Column: 24, Byte: 74},
+			"zone",
+			map[string][]lang.TextEdit{
+				"a.tf": {
+					{
+						Range: hcl.Range{
+							Filename: "a.tf",
+							Start:    hcl.Pos{Line: 7, Column: 3, Byte: 103},
+							End:      hcl.Pos{Line: 7, Column: 9, Byte: 109},
+						},
+						NewText: "zone",
+						Snippet: "zone",
+					},
+				},
+				"b.tf": {
+					{
+						Range: hcl.Range{
+							Filename: "b.tf",
+							Start:    hcl.Pos{Line: 3, Column: 22, Byte: 72},
+							End:      hcl.Pos{Line: 3, Column: 28, Byte: 78},
+						},
+						NewText: "zone",
+						Snippet: "zone",
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"non-renameable step of origin",
+			"b.tf",
+			hcl.Pos{Line: 2, Column: 12, Byte: 37},
+			"web",
+			nil,
+			&PositionalError{
+				Filename: "b.tf",
+				Pos:      hcl.Pos{Line: 2, Column: 12, Byte: 37},
+				Msg:      "no renameable reference found",
+			},
+		},
+		{
+			"conflicting name",
+			"b.tf",
+			hcl.Pos{Line: 2, Column: 23, Byte: 48},
+			"db",
+			nil,
+			&RenameConflictError{
+				Address: lang.Address{
+					lang.RootStep{Name: "aws_instance"},
+					lang.AttrStep{Name: "db"},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			hclFiles := make(map[string]*hcl.File, len(files))
+			for filename, src := range files {
+				f, pDiags := hclsyntax.ParseConfig([]byte(src), filename, hcl.InitialPos)
+				if len(pDiags) > 0 {
+					t.Fatal(pDiags)
+				}
+				hclFiles[filename] = f
+			}
+			pathCtx := &PathContext{
+				Schema: bodySchema,
+				Files:  hclFiles,
+			}
+
+			dirPath := t.TempDir()
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: pathCtx,
+				},
+			})
+			path := lang.Path{Path: dirPath}
+			pd, err := d.Path(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceTargets, err = pd.CollectReferenceTargets()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceOrigins, err = pd.CollectReferenceOrigins()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			edits, err := d.Rename(path, tc.file, tc.pos, tc.newName)
+			if tc.expectedErr != nil {
+				if err == nil {
+					t.Fatalf("expected error: %s", tc.expectedErr)
+				}
+				if diff := cmp.Diff(tc.expectedErr.Error(), err.Error()); diff != "" {
+					t.Fatalf("unexpected error: %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedEdits, edits); diff != "" {
+				t.Fatalf("unexpected edits: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_Rename_invalidName(t *testing.T) {
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{},
+	})
+
+	_, err := d.Rename(lang.Path{Path: t.TempDir()}, "test.tf", hcl.InitialPos, "1invalid")
+	if err == nil {
+		t.Fatal("expected error for invalid name")
+	}
+	var conflictErr *RenameConflictError
+	if errors.As(err, &conflictErr) {
+		t.Fatalf("unexpected conflict error: %s", err)
+	}
+}
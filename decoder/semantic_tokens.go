@@ -14,8 +14,60 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+// SemanticTokensInFileInPath returns a sequence of semantic tokens
+// within the config file in the given path.
+//
+// Unlike PathDecoder.SemanticTokensInFile, the path reader is made available
+// via context, which allows resolution of references into other paths.
+func (d *Decoder) SemanticTokensInFileInPath(ctx context.Context, path lang.Path, filename string) ([]lang.SemanticToken, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	version := pd.pathCtx.Version
+	if tokens, ok := d.cachedTokens(path, filename, version); ok {
+		return tokens, nil
+	}
+
+	tokens, err := pd.SemanticTokensInFile(d.pathContext(ctx, pd), filename)
+	if err != nil {
+		return nil, err
+	}
+	d.cacheTokens(path, filename, version, tokens)
+
+	return tokens, nil
+}
+
+// SemanticTokensForExpression returns a sequence of semantic tokens
+// for the given expression interpreted per the given constraint,
+// without the need for the expression to be part of any path.
+//
+// Token ranges are relative to the file the expression was parsed from.
+// References are not highlighted, as no reference origins
+// or targets are available outside of a path.
+func (d *Decoder) SemanticTokensForExpression(ctx context.Context, expr hclsyntax.Expression, cons schema.Constraint) []lang.SemanticToken {
+	if expr == nil || cons == nil {
+		return []lang.SemanticToken{}
+	}
+
+	tokens := NewExpression(nil, expr, cons).SemanticTokens(ctx)
+	if tokens == nil {
+		return []lang.SemanticToken{}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Range.Start.Byte < tokens[j].Range.Start.Byte
+	})
+
+	return tokens
+}
+
 // SemanticTokensInFile returns a sequence of semantic tokens
 // within the config file.
+//
+// Files with syntax errors are decoded as far as the parser recovered,
+// so tokens are still provided for any well-formed blocks.
 func (d *PathDecoder) SemanticTokensInFile(ctx context.Context, filename string) ([]lang.SemanticToken, error) {
 	f, err := d.fileByName(filename)
 	if err != nil {
@@ -46,6 +98,59 @@ func (d *PathDecoder) SemanticTokensInFile(ctx context.Context, filename string)
 	return tokens, nil
 }
 
+// SemanticTokensForBlockAtPosInPath returns a sequence of semantic tokens
+// of the top-level block enclosing the given position
+// within the config file in the given path.
+//
+// Unlike PathDecoder.SemanticTokensForBlockAtPos, the path reader is made
+// available via context, which allows resolution of references into other paths.
+func (d *Decoder) SemanticTokensForBlockAtPosInPath(ctx context.Context, path lang.Path, filename string, pos hcl.Pos) ([]lang.SemanticToken, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return pd.SemanticTokensForBlockAtPos(d.pathContext(ctx, pd), filename, pos)
+}
+
+// SemanticTokensForBlockAtPos returns a sequence of semantic tokens
+// of the top-level block enclosing the given position, including
+// any nested content, which allows targeted refresh of tokens.
+//
+// The tokens match the corresponding subset of SemanticTokensInFile.
+// Empty slice is returned if the position is not inside any block.
+func (d *PathDecoder) SemanticTokensForBlockAtPos(ctx context.Context, filename string, pos hcl.Pos) ([]lang.SemanticToken, error) {
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := d.bodyForFileAndPos(filename, f, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.pathCtx.Schema == nil {
+		return []lang.SemanticToken{}, nil
+	}
+
+	block := outermostBlockAtPos(body, pos)
+	if block == nil {
+		return []lang.SemanticToken{}, nil
+	}
+
+	blockBody := &hclsyntax.Body{
+		Blocks: hclsyntax.Blocks{block},
+	}
+	tokens := d.tokensForBody(ctx, blockBody, d.pathCtx.Schema, []lang.SemanticTokenModifier{})
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Range.Start.Byte < tokens[j].Range.Start.Byte
+	})
+
+	return tokens, nil
+}
+
 func (d *PathDecoder) tokensForBody(ctx context.Context, body *hclsyntax.Body, bodySchema *schema.BodySchema, parentModifiers []lang.SemanticTokenModifier) []lang.SemanticToken {
 	tokens := make([]lang.SemanticToken, 0)
 
@@ -54,19 +159,17 @@ func (d *PathDecoder) tokensForBody(ctx context.Context, body *hclsyntax.Body, b
 	}
 
 	for name, attr := range body.Attributes {
-		attrSchema, ok := bodySchema.Attributes[name]
+		attrSchema, ok := schemahelper.AttributeSchema(bodySchema, name)
 		if !ok {
-			if bodySchema.Extensions != nil && name == "count" && bodySchema.Extensions.Count {
-				attrSchema = schemahelper.CountAttributeSchema()
-			} else if bodySchema.Extensions != nil && name == "for_each" && bodySchema.Extensions.ForEach {
-				attrSchema = schemahelper.ForEachAttributeSchema()
-			} else {
-				if bodySchema.AnyAttribute == nil {
-					// unknown attribute
-					continue
-				}
-				attrSchema = bodySchema.AnyAttribute
+			// unknown attribute
+			if d.pathCtx.TokenizeUnknownAttributes {
+				tokens = append(tokens, lang.SemanticToken{
+					Type:      lang.TokenAttrName,
+					Modifiers: append([]lang.SemanticTokenModifier{}, parentModifiers...),
+					Range:     attr.NameRange,
+				})
 			}
+			continue
 		}
 
 		attrModifiers := make([]lang.SemanticTokenModifier, 0)
@@ -79,11 +182,20 @@ func (d *PathDecoder) tokensForBody(ctx context.Context, body *hclsyntax.Body, b
 			Range:     attr.NameRange,
 		})
 
+		if isNullExpression(attr.Expr) {
+			tokens = append(tokens, lang.SemanticToken{
+				Type:      lang.TokenNull,
+				Modifiers: lang.SemanticTokenModifiers{},
+				Range:     attr.Expr.Range(),
+			})
+			continue
+		}
+
 		tokens = append(tokens, d.newExpression(attr.Expr, attrSchema.Constraint).SemanticTokens(ctx)...)
 	}
 
 	for _, block := range body.Blocks {
-		blockSchema, hasDepSchema := bodySchema.Blocks[block.Type]
+		blockSchema, hasDepSchema := schemahelper.BlockSchema(bodySchema, block.Type)
 		if !hasDepSchema {
 			// unknown block
 			continue
@@ -122,13 +234,27 @@ func (d *PathDecoder) tokensForBody(ctx context.Context, body *hclsyntax.Body, b
 		if block.Body != nil {
 			mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), blockSchema)
 
-			tokens = append(tokens, d.tokensForBody(ctx, block.Body, mergedSchema, blockModifiers)...)
+			tokens = append(tokens, d.tokensForBody(ctx, block.Body, mergedSchema, inheritableModifiers(blockModifiers))...)
 		}
 	}
 
 	return tokens
 }
 
+// inheritableModifiers returns modifiers to be inherited by tokens
+// within a nested body, i.e. all except the synthetic modifier,
+// since the content of synthetic blocks (such as dynamic blocks)
+// is declared against the schema directly.
+func inheritableModifiers(modifiers []lang.SemanticTokenModifier) []lang.SemanticTokenModifier {
+	inherited := make([]lang.SemanticTokenModifier, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		if modifier != lang.TokenModifierSynthetic {
+			inherited = append(inherited, modifier)
+		}
+	}
+	return inherited
+}
+
 func isPrimitiveTypeDeclaration(kw string) bool {
 	switch kw {
 	case "bool":
@@ -20,6 +20,71 @@ import (
 	"github.com/zclconf/go-cty/cty"
 )
 
+func TestDecoder_SemanticTokensForExpression(t *testing.T) {
+	expr, diags := hclsyntax.ParseExpression([]byte(`{
+  foo = 42
+  bar = true
+}`), "expr.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	cons := schema.Object{
+		Attributes: schema.ObjectAttributes{
+			"foo": {
+				Constraint: schema.LiteralType{Type: cty.Number},
+			},
+			"bar": {
+				Constraint: schema.LiteralType{Type: cty.Bool},
+			},
+		},
+	}
+
+	d := NewDecoder(&testPathReader{})
+	tokens := d.SemanticTokensForExpression(context.Background(), expr, cons)
+
+	expectedTokens := []lang.SemanticToken{
+		{
+			Type:      lang.TokenObjectKey,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "expr.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 4},
+				End:      hcl.Pos{Line: 2, Column: 6, Byte: 7},
+			},
+		},
+		{
+			Type:      lang.TokenNumber,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "expr.tf",
+				Start:    hcl.Pos{Line: 2, Column: 9, Byte: 10},
+				End:      hcl.Pos{Line: 2, Column: 11, Byte: 12},
+			},
+		},
+		{
+			Type:      lang.TokenObjectKey,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "expr.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+				End:      hcl.Pos{Line: 3, Column: 6, Byte: 18},
+			},
+		},
+		{
+			Type:      lang.TokenBool,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "expr.tf",
+				Start:    hcl.Pos{Line: 3, Column: 9, Byte: 21},
+				End:      hcl.Pos{Line: 3, Column: 13, Byte: 25},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
 func TestDecoder_SemanticTokensInFile_emptyBody(t *testing.T) {
 	f := &hcl.File{
 		Body: hcl.EmptyBody(),
@@ -320,36 +385,89 @@ resource "vault_auth_backend" "blah" {
 	}
 }
 
-func TestDecoder_SemanticTokensInFile_dependentSchema(t *testing.T) {
+func TestDecoder_SemanticTokensInFile_null(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsNullable: true,
+			},
+		},
+	}
+
+	testCfg := []byte(`attr = null
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{
+			Type:      lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+			},
+		},
+		{
+			Type:      lang.TokenNull,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_partialParse(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
 			"resource": {
 				Labels: []*schema.LabelSchema{
-					{
-						Name:     "type",
-						IsDepKey: true,
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{
-							lang.TokenModifierDependent,
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
 						},
 					},
+				},
+			},
+			"variable": {
+				Labels: []*schema.LabelSchema{
 					{Name: "name"},
 				},
-				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
-					schema.NewSchemaKey(schema.DependencyKeys{
-						Labels: []schema.LabelDependent{
-							{
-								Index: 0,
-								Value: "aws_instance",
-							},
-						},
-					}): {
-						Attributes: map[string]*schema.AttributeSchema{
-							"instance_type": {
-								Constraint: schema.LiteralType{Type: cty.String},
-							},
-							"deprecated": {
-								Constraint: schema.LiteralType{Type: cty.Bool},
-							},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"default": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
 						},
 					},
 				},
@@ -357,18 +475,18 @@ func TestDecoder_SemanticTokensInFile_dependentSchema(t *testing.T) {
 		},
 	}
 
-	testCfg := []byte(`resource "vault_auth_backend" "alpha" {
-  default_lease_ttl_seconds = 1
+	testCfg := []byte(`resource "aws_instance" "web" {
+  ami = 
 }
-resource "aws_instance" "beta" {
-  instance_type = "t2.micro"
-  deprecated = true
+
+variable "name" {
+  default = "foo"
 }
 `)
 
 	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
-	if len(pDiags) > 0 {
-		t.Fatal(pDiags)
+	if !pDiags.HasErrors() {
+		t.Fatal("expected parser to report malformed block")
 	}
 
 	d := testPathDecoder(t, &PathContext{
@@ -379,184 +497,1193 @@ resource "aws_instance" "beta" {
 	})
 
 	ctx := context.Background()
-
 	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	expectedTokens := []lang.SemanticToken{
-		{ // resource
+		{
 			Type:      lang.TokenBlockType,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   1,
-					Column: 1,
-					Byte:   0,
-				},
-				End: hcl.Pos{
-					Line:   1,
-					Column: 9,
-					Byte:   8,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			},
 		},
-		{ // "vault_auth_backend"
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.TokenModifierDependent,
-			},
+		{
+			Type:      lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   1,
-					Column: 10,
-					Byte:   9,
-				},
-				End: hcl.Pos{
-					Line:   1,
-					Column: 30,
-					Byte:   29,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			},
 		},
-		{ // "alpha"
+		{
 			Type:      lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   1,
-					Column: 31,
-					Byte:   30,
-				},
-				End: hcl.Pos{
-					Line:   1,
-					Column: 38,
-					Byte:   37,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+				End:      hcl.Pos{Line: 1, Column: 30, Byte: 29},
 			},
 		},
-		{ // resource
-			Type:      lang.TokenBlockType,
+		{
+			Type:      lang.TokenAttrName,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 1,
-					Byte:   74,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 9,
-					Byte:   82,
-				},
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+				End:      hcl.Pos{Line: 2, Column: 6, Byte: 37},
 			},
 		},
-		{ // "aws_instance"
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.TokenModifierDependent,
-			},
+		{
+			Type:      lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 10,
-					Byte:   83,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 24,
-					Byte:   97,
-				},
+				Start:    hcl.Pos{Line: 5, Column: 1, Byte: 44},
+				End:      hcl.Pos{Line: 5, Column: 9, Byte: 52},
 			},
 		},
-		{ // "beta"
+		{
 			Type:      lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 25,
-					Byte:   98,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 31,
-					Byte:   104,
-				},
+				Start:    hcl.Pos{Line: 5, Column: 10, Byte: 53},
+				End:      hcl.Pos{Line: 5, Column: 16, Byte: 59},
 			},
 		},
-		{ // instance_type
+		{
 			Type:      lang.TokenAttrName,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   5,
-					Column: 3,
-					Byte:   109,
-				},
-				End: hcl.Pos{
-					Line:   5,
-					Column: 16,
-					Byte:   122,
-				},
+				Start:    hcl.Pos{Line: 6, Column: 3, Byte: 64},
+				End:      hcl.Pos{Line: 6, Column: 10, Byte: 71},
 			},
 		},
-		{ // "t2.micro"
+		{
 			Type:      lang.TokenString,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   5,
-					Column: 19,
-					Byte:   125,
+				Start:    hcl.Pos{Line: 6, Column: 13, Byte: 74},
+				End:      hcl.Pos{Line: 6, Column: 18, Byte: 79},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensForBlockAtPos(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"name": {
+				IsOptional: true,
+				Constraint: schema.LiteralType{Type: cty.String},
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"variable": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
 				},
-				End: hcl.Pos{
-					Line:   5,
-					Column: 29,
-					Byte:   135,
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.StaticStep{Name: "var"},
+						schema.LabelStep{Index: 0},
+					},
+					AsReference: true,
+					ScopeId:     lang.ScopeId("variable"),
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"default": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
+						},
+					},
 				},
 			},
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"ami": {
+							IsOptional: true,
+							Constraint: schema.Reference{OfScopeId: lang.ScopeId("variable")},
+						},
+					},
+					Blocks: map[string]*schema.BlockSchema{
+						"setting": {
+							Body: &schema.BodySchema{
+								Attributes: map[string]*schema.AttributeSchema{
+									"value": {
+										IsOptional: true,
+										Constraint: schema.Reference{OfScopeId: lang.ScopeId("variable")},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCfg := []byte(`name = "root"
+
+variable "name" {
+  default = "foo"
+}
+
+resource "aws_instance" "web" {
+  ami = var.name
+
+  setting {
+    value = var.name
+  }
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+	targets, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origins, err := d.CollectReferenceOrigins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d = testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: targets,
+		ReferenceOrigins: origins,
+	})
+
+	ctx := context.Background()
+	fileTokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		pos           hcl.Pos
+		expectedRange *hcl.Range
+	}{
+		{
+			"top-level attribute",
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			nil,
+		},
+		{
+			"block type",
+			hcl.Pos{Line: 3, Column: 1, Byte: 15},
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 1, Byte: 15},
+				End:      hcl.Pos{Line: 5, Column: 2, Byte: 52},
+			},
+		},
+		{
+			"block body",
+			hcl.Pos{Line: 4, Column: 5, Byte: 37},
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 1, Byte: 15},
+				End:      hcl.Pos{Line: 5, Column: 2, Byte: 52},
+			},
+		},
+		{
+			"block label",
+			hcl.Pos{Line: 7, Column: 26, Byte: 79},
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 1, Byte: 53},
+				End:      hcl.Pos{Line: 13, Column: 2, Byte: 142},
+			},
+		},
+		{
+			"nested block body",
+			hcl.Pos{Line: 11, Column: 7, Byte: 122},
+			&hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 1, Byte: 53},
+				End:      hcl.Pos{Line: 13, Column: 2, Byte: 142},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			tokens, err := d.SemanticTokensForBlockAtPos(ctx, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedTokens := []lang.SemanticToken{}
+			if tc.expectedRange != nil {
+				for _, token := range fileTokens {
+					if tc.expectedRange.Overlaps(token.Range) {
+						expectedTokens = append(expectedTokens, token)
+					}
+				}
+				if len(expectedTokens) == 0 {
+					t.Fatal("expected non-empty subset of file tokens")
+				}
+			}
+
+			if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+				t.Fatalf("unexpected tokens: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_blockAlias(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"myblock": {
+				Aliases: []string{"legacyblock"},
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"attr": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCfg := []byte(`legacyblock "foo" {
+  attr = 42
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{
+			Type:      lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			},
+		},
+		{
+			Type:      lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 13, Byte: 12},
+				End:      hcl.Pos{Line: 1, Column: 18, Byte: 17},
+			},
+		},
+		{
+			Type:      lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 22},
+				End:      hcl.Pos{Line: 2, Column: 7, Byte: 26},
+			},
+		},
+		{
+			Type:      lang.TokenNumber,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 10, Byte: 29},
+				End:      hcl.Pos{Line: 2, Column: 12, Byte: 31},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_unknownAttributes(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.LiteralType{Type: cty.String},
+			},
+		},
+	}
+
+	testCfg := []byte(`attr = "foo"
+unknown = var.foo
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		TokenizeUnknownAttributes: true,
+	})
+
+	ctx := context.Background()
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{
+			Type:      lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 5, Byte: 4},
+			},
+		},
+		{
+			Type:      lang.TokenString,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+			},
+		},
+		{
+			Type:      lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 13},
+				End:      hcl.Pos{Line: 2, Column: 8, Byte: 20},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expectedTokens, tokens); diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_dependentSchema(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{
+						Name:     "type",
+						IsDepKey: true,
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{
+							lang.TokenModifierDependent,
+						},
+					},
+					{Name: "name"},
+				},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{
+								Index: 0,
+								Value: "aws_instance",
+							},
+						},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"instance_type": {
+								Constraint: schema.LiteralType{Type: cty.String},
+							},
+							"deprecated": {
+								Constraint: schema.LiteralType{Type: cty.Bool},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCfg := []byte(`resource "vault_auth_backend" "alpha" {
+  default_lease_ttl_seconds = 1
+}
+resource "aws_instance" "beta" {
+  instance_type = "t2.micro"
+  deprecated = true
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{ // resource
+			Type:      lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   1,
+					Column: 1,
+					Byte:   0,
+				},
+				End: hcl.Pos{
+					Line:   1,
+					Column: 9,
+					Byte:   8,
+				},
+			},
+		},
+		{ // "vault_auth_backend"
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.TokenModifierDependent,
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   1,
+					Column: 10,
+					Byte:   9,
+				},
+				End: hcl.Pos{
+					Line:   1,
+					Column: 30,
+					Byte:   29,
+				},
+			},
+		},
+		{ // "alpha"
+			Type:      lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   1,
+					Column: 31,
+					Byte:   30,
+				},
+				End: hcl.Pos{
+					Line:   1,
+					Column: 38,
+					Byte:   37,
+				},
+			},
+		},
+		{ // resource
+			Type:      lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 1,
+					Byte:   74,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 9,
+					Byte:   82,
+				},
+			},
+		},
+		{ // "aws_instance"
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.TokenModifierDependent,
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 10,
+					Byte:   83,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 24,
+					Byte:   97,
+				},
+			},
+		},
+		{ // "beta"
+			Type:      lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 25,
+					Byte:   98,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 31,
+					Byte:   104,
+				},
+			},
+		},
+		{ // instance_type
+			Type:      lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   5,
+					Column: 3,
+					Byte:   109,
+				},
+				End: hcl.Pos{
+					Line:   5,
+					Column: 16,
+					Byte:   122,
+				},
+			},
+		},
+		{ // "t2.micro"
+			Type:      lang.TokenString,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   5,
+					Column: 19,
+					Byte:   125,
+				},
+				End: hcl.Pos{
+					Line:   5,
+					Column: 29,
+					Byte:   135,
+				},
+			},
+		},
+		{ // deprecated
+			Type:      lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   6,
+					Column: 3,
+					Byte:   138,
+				},
+				End: hcl.Pos{
+					Line:   6,
+					Column: 13,
+					Byte:   148,
+				},
+			},
+		},
+		{ // true
+			Type:      lang.TokenBool,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   6,
+					Column: 16,
+					Byte:   151,
+				},
+				End: hcl.Pos{
+					Line:   6,
+					Column: 20,
+					Byte:   155,
+				},
+			},
+		},
+	}
+
+	diff := cmp.Diff(expectedTokens, tokens)
+	if diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_customModifiers(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"module": {
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{"module"},
+				Labels: []*schema.LabelSchema{
+					{
+						Name:                   "name",
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{"name"},
+					},
+				},
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
+						"source": {
+							Constraint:             schema.LiteralType{Type: cty.String},
+							IsDeprecated:           true,
+							SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
+						},
+					},
+				},
+			},
+			"resource": {
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{"resource"},
+				Labels: []*schema.LabelSchema{
+					{
+						Name:                   "type",
+						IsDepKey:               true,
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{"type", lang.TokenModifierDependent},
+					},
+					{
+						Name:                   "name",
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{"name"},
+					},
+				},
+				Body: &schema.BodySchema{
+					Blocks: map[string]*schema.BlockSchema{
+						"provisioner": {
+							SemanticTokenModifiers: lang.SemanticTokenModifiers{"provisioner"},
+							Labels: []*schema.LabelSchema{
+								{
+									Name:                   "type",
+									SemanticTokenModifiers: lang.SemanticTokenModifiers{"type"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testCfg := []byte(`module "ref" {
+  source = "./sub"
+  count  = 1
+}
+resource "vault_auth_backend" "blah" {
+  provisioner "inner" {
+  	test = 42
+  }
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{ // module
+			Type: lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("module"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   1,
+					Column: 1,
+					Byte:   0,
+				},
+				End: hcl.Pos{
+					Line:   1,
+					Column: 7,
+					Byte:   6,
+				},
+			},
+		},
+		{ // "ref"
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("module"),
+				lang.SemanticTokenModifier("name"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   1,
+					Column: 8,
+					Byte:   7,
+				},
+				End: hcl.Pos{
+					Line:   1,
+					Column: 13,
+					Byte:   12,
+				},
+			},
+		},
+		{ // source
+			Type: lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("module"),
+				lang.TokenModifierDependent,
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   2,
+					Column: 3,
+					Byte:   17,
+				},
+				End: hcl.Pos{
+					Line:   2,
+					Column: 9,
+					Byte:   23,
+				},
+			},
+		},
+		{ // "./sub"
+			Type:      lang.TokenString,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   2,
+					Column: 12,
+					Byte:   26,
+				},
+				End: hcl.Pos{
+					Line:   2,
+					Column: 19,
+					Byte:   33,
+				},
+			},
+		},
+		{ // count
+			Type: lang.TokenAttrName,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("module"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   3,
+					Column: 3,
+					Byte:   36,
+				},
+				End: hcl.Pos{
+					Line:   3,
+					Column: 8,
+					Byte:   41,
+				},
+			},
+		},
+		{ // 1
+			Type:      lang.TokenNumber,
+			Modifiers: []lang.SemanticTokenModifier{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   3,
+					Column: 12,
+					Byte:   45,
+				},
+				End: hcl.Pos{
+					Line:   3,
+					Column: 13,
+					Byte:   46,
+				},
+			},
+		},
+		{ // resource
+			Type: lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("resource"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   5,
+					Column: 1,
+					Byte:   49,
+				},
+				End: hcl.Pos{
+					Line:   5,
+					Column: 9,
+					Byte:   57,
+				},
+			},
+		},
+		{ // vault_auth_backend
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("resource"),
+				lang.SemanticTokenModifier("type"),
+				lang.TokenModifierDependent,
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   5,
+					Column: 10,
+					Byte:   58,
+				},
+				End: hcl.Pos{
+					Line:   5,
+					Column: 30,
+					Byte:   78,
+				},
+			},
+		},
+		{ // blah
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("resource"),
+				lang.SemanticTokenModifier("name"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   5,
+					Column: 31,
+					Byte:   79,
+				},
+				End: hcl.Pos{
+					Line:   5,
+					Column: 37,
+					Byte:   85,
+				},
+			},
+		},
+		{ // provisioner
+			Type: lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("resource"),
+				lang.SemanticTokenModifier("provisioner"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 6, Column: 3, Byte: 90},
+				End:      hcl.Pos{Line: 6, Column: 14, Byte: 101},
+			},
 		},
-		{ // deprecated
-			Type:      lang.TokenAttrName,
+		{ // "inner"
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.SemanticTokenModifier("resource"),
+				lang.SemanticTokenModifier("provisioner"),
+				lang.SemanticTokenModifier("type"),
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 6, Column: 15, Byte: 102},
+				End:      hcl.Pos{Line: 6, Column: 22, Byte: 109},
+			},
+		},
+	}
+
+	diff := cmp.Diff(expectedTokens, tokens)
+	if diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_extensions_basic(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Count: true,
+					},
+					Attributes: map[string]*schema.AttributeSchema{
+						"cpu_core_count": {
+							Constraint: schema.OneOf{
+								schema.Reference{OfType: cty.Number},
+								schema.LiteralType{Type: cty.Number},
+							},
+							IsOptional: true,
+						},
+					},
+				},
+				Labels: []*schema.LabelSchema{
+					{
+						Name:     "type",
+						IsDepKey: true,
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{
+							lang.TokenModifierDependent,
+						},
+					},
+					{Name: "name"},
+				},
+			},
+		},
+	}
+
+	testCfg := []byte(`
+resource "aws_instance" "app_server" {
+  count          = 1
+  cpu_core_count = count.index
+}
+`)
+
+	refTargets := reference.Targets{
+		{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: "count"},
+				lang.AttrStep{Name: "index"},
+			},
+			Type:        cty.Number,
+			Description: lang.PlainText("The distinct index number (starting with 0) corresponding to the instance"),
+		},
+	}
+	refOrigins := reference.Origins{
+		reference.LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "count"},
+				lang.AttrStep{Name: "index"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 20,
+					Byte:   80,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 31,
+					Byte:   91,
+				},
+			},
+		},
+	}
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: refTargets,
+		ReferenceOrigins: refOrigins,
+	})
+
+	ctx := context.Background()
+
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
+		{ // resource
+			Type:      lang.TokenBlockType,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   6,
-					Column: 3,
-					Byte:   138,
+					Line:   2,
+					Column: 1,
+					Byte:   1,
 				},
 				End: hcl.Pos{
-					Line:   6,
-					Column: 13,
-					Byte:   148,
+					Line:   2,
+					Column: 9,
+					Byte:   9,
 				},
 			},
 		},
-		{ // true
-			Type:      lang.TokenBool,
+		{ // aws_instance
+			Type: lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{
+				lang.TokenModifierDependent,
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   2,
+					Column: 10,
+					Byte:   10,
+				},
+				End: hcl.Pos{
+					Line:   2,
+					Column: 24,
+					Byte:   24,
+				},
+			},
+		},
+		{ // app_server
+			Type:      lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   6,
-					Column: 16,
-					Byte:   151,
+					Line:   2,
+					Column: 25,
+					Byte:   25,
 				},
 				End: hcl.Pos{
-					Line:   6,
+					Line:   2,
+					Column: 37,
+					Byte:   37,
+				},
+			},
+		},
+		{ // count
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   3,
+					Column: 3,
+					Byte:   42,
+				},
+				End: hcl.Pos{
+					Line:   3,
+					Column: 8,
+					Byte:   47,
+				},
+			},
+		},
+		{ // 1
+			Type:      lang.TokenNumber,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   3,
 					Column: 20,
-					Byte:   155,
+					Byte:   59,
+				},
+				End: hcl.Pos{
+					Line:   3,
+					Column: 21,
+					Byte:   60,
+				},
+			},
+		},
+		{ // cpu_core_count
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 3,
+					Byte:   63,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 17,
+					Byte:   77,
+				},
+			},
+		},
+		{ // count
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 20,
+					Byte:   80,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 25,
+					Byte:   85,
+				},
+			},
+		},
+		{ // index
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 26,
+					Byte:   86,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 31,
+					Byte:   91,
 				},
 			},
 		},
@@ -567,72 +1694,89 @@ resource "aws_instance" "beta" {
 		t.Fatalf("unexpected tokens: %s", diff)
 	}
 }
-
-func TestDecoder_SemanticTokensInFile_customModifiers(t *testing.T) {
-	bodySchema := &schema.BodySchema{
-		Blocks: map[string]*schema.BlockSchema{
-			"module": {
-				SemanticTokenModifiers: lang.SemanticTokenModifiers{"module"},
-				Labels: []*schema.LabelSchema{
-					{
-						Name:                   "name",
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{"name"},
+
+func TestDecoder_SemanticTokensInFile_expression_extensions_depSchema(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Count: true,
 					},
 				},
-				Body: &schema.BodySchema{
-					Attributes: map[string]*schema.AttributeSchema{
-						"count": {
-							Constraint: schema.LiteralType{Type: cty.Number},
+				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
+					schema.NewSchemaKey(schema.DependencyKeys{
+						Labels: []schema.LabelDependent{
+							{
+								Index: 0,
+								Value: "aws_instance",
+							},
 						},
-						"source": {
-							Constraint:             schema.LiteralType{Type: cty.String},
-							IsDeprecated:           true,
-							SemanticTokenModifiers: lang.SemanticTokenModifiers{lang.TokenModifierDependent},
+					}): {
+						Attributes: map[string]*schema.AttributeSchema{
+							"cpu_core_count": {
+								Constraint: schema.OneOf{
+									schema.Reference{OfType: cty.Number},
+									schema.LiteralType{Type: cty.Number},
+								},
+								IsOptional: true,
+							},
 						},
 					},
 				},
-			},
-			"resource": {
-				SemanticTokenModifiers: lang.SemanticTokenModifiers{"resource"},
 				Labels: []*schema.LabelSchema{
 					{
-						Name:                   "type",
-						IsDepKey:               true,
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{"type", lang.TokenModifierDependent},
-					},
-					{
-						Name:                   "name",
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{"name"},
-					},
-				},
-				Body: &schema.BodySchema{
-					Blocks: map[string]*schema.BlockSchema{
-						"provisioner": {
-							SemanticTokenModifiers: lang.SemanticTokenModifiers{"provisioner"},
-							Labels: []*schema.LabelSchema{
-								{
-									Name:                   "type",
-									SemanticTokenModifiers: lang.SemanticTokenModifiers{"type"},
-								},
-							},
+						Name:     "type",
+						IsDepKey: true,
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{
+							lang.TokenModifierDependent,
 						},
 					},
+					{Name: "name"},
 				},
 			},
 		},
 	}
 
-	testCfg := []byte(`module "ref" {
-  source = "./sub"
-  count  = 1
-}
-resource "vault_auth_backend" "blah" {
-  provisioner "inner" {
-  	test = 42
-  }
+	testCfg := []byte(`
+resource "aws_instance" "app_server" {
+  count          = 1
+  cpu_core_count = count.index
 }
 `)
 
+	refTargets := reference.Targets{
+		{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: "count"},
+				lang.AttrStep{Name: "index"},
+			},
+			Type:        cty.Number,
+			Description: lang.PlainText("The distinct index number (starting with 0) corresponding to the instance"),
+		},
+	}
+	refOrigins := reference.Origins{
+		reference.LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "count"},
+				lang.AttrStep{Name: "index"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 20,
+					Byte:   80,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 31,
+					Byte:   91,
+				},
+			},
+		},
+	}
+
 	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
 	if len(pDiags) > 0 {
 		t.Fatal(pDiags)
@@ -643,6 +1787,8 @@ resource "vault_auth_backend" "blah" {
 		Files: map[string]*hcl.File{
 			"test.tf": f,
 		},
+		ReferenceTargets: refTargets,
+		ReferenceOrigins: refOrigins,
 	})
 
 	ctx := context.Background()
@@ -653,201 +1799,274 @@ resource "vault_auth_backend" "blah" {
 	}
 
 	expectedTokens := []lang.SemanticToken{
-		{ // module
-			Type: lang.TokenBlockType,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("module"),
-			},
+		{ // resource
+			Type:      lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   1,
+					Line:   2,
 					Column: 1,
-					Byte:   0,
+					Byte:   1,
 				},
 				End: hcl.Pos{
-					Line:   1,
-					Column: 7,
-					Byte:   6,
+					Line:   2,
+					Column: 9,
+					Byte:   9,
 				},
 			},
 		},
-		{ // "ref"
+		{ // aws_instance
 			Type: lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("module"),
-				lang.SemanticTokenModifier("name"),
-			},
-			Range: hcl.Range{
-				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   1,
-					Column: 8,
-					Byte:   7,
-				},
-				End: hcl.Pos{
-					Line:   1,
-					Column: 13,
-					Byte:   12,
-				},
-			},
-		},
-		{ // source
-			Type: lang.TokenAttrName,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("module"),
 				lang.TokenModifierDependent,
 			},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 3,
-					Byte:   17,
+					Column: 10,
+					Byte:   10,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 9,
-					Byte:   23,
+					Column: 24,
+					Byte:   24,
 				},
 			},
 		},
-		{ // "./sub"
-			Type:      lang.TokenString,
+		{ // app_server
+			Type:      lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 12,
-					Byte:   26,
+					Column: 25,
+					Byte:   25,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 19,
-					Byte:   33,
+					Column: 37,
+					Byte:   37,
 				},
 			},
 		},
 		{ // count
-			Type: lang.TokenAttrName,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("module"),
-			},
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   3,
 					Column: 3,
-					Byte:   36,
+					Byte:   42,
 				},
 				End: hcl.Pos{
 					Line:   3,
 					Column: 8,
-					Byte:   41,
+					Byte:   47,
 				},
 			},
 		},
 		{ // 1
 			Type:      lang.TokenNumber,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   3,
-					Column: 12,
-					Byte:   45,
+					Column: 20,
+					Byte:   59,
 				},
 				End: hcl.Pos{
 					Line:   3,
-					Column: 13,
-					Byte:   46,
+					Column: 21,
+					Byte:   60,
+				},
+			},
+		},
+		{ // cpu_core_count
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 3,
+					Byte:   63,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 17,
+					Byte:   77,
+				},
+			},
+		},
+		{ // count
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 20,
+					Byte:   80,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 25,
+					Byte:   85,
+				},
+			},
+		},
+		{ // index
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   4,
+					Column: 26,
+					Byte:   86,
+				},
+				End: hcl.Pos{
+					Line:   4,
+					Column: 31,
+					Byte:   91,
+				},
+			},
+		},
+	}
+
+	diff := cmp.Diff(expectedTokens, tokens)
+	if diff != "" {
+		t.Fatalf("unexpected tokens: %s", diff)
+	}
+}
+
+func TestDecoder_SemanticTokensInFile_extensions_countUndeclared(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Count: true,
+					},
+					Attributes: map[string]*schema.AttributeSchema{
+						"cpu_count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
+					},
+				},
+				Labels: []*schema.LabelSchema{
+					{
+						Name:     "type",
+						IsDepKey: true,
+						SemanticTokenModifiers: lang.SemanticTokenModifiers{
+							lang.TokenModifierDependent,
+						},
+					},
+					{Name: "name"},
 				},
 			},
 		},
+	}
+
+	testCfg := []byte(`
+resource "vault_auth_backend" "blah" {
+  cpu_count = count.index
+}
+`)
+
+	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+	})
+
+	ctx := context.Background()
+
+	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTokens := []lang.SemanticToken{
 		{ // resource
-			Type: lang.TokenBlockType,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("resource"),
-			},
+			Type:      lang.TokenBlockType,
+			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   5,
+					Line:   2,
 					Column: 1,
-					Byte:   49,
+					Byte:   1,
 				},
 				End: hcl.Pos{
-					Line:   5,
+					Line:   2,
 					Column: 9,
-					Byte:   57,
+					Byte:   9,
 				},
 			},
 		},
 		{ // vault_auth_backend
 			Type: lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("resource"),
-				lang.SemanticTokenModifier("type"),
 				lang.TokenModifierDependent,
 			},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   5,
+					Line:   2,
 					Column: 10,
-					Byte:   58,
+					Byte:   10,
 				},
 				End: hcl.Pos{
-					Line:   5,
+					Line:   2,
 					Column: 30,
-					Byte:   78,
+					Byte:   30,
 				},
 			},
 		},
 		{ // blah
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("resource"),
-				lang.SemanticTokenModifier("name"),
-			},
+			Type:      lang.TokenBlockLabel,
+			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   5,
+					Line:   2,
 					Column: 31,
-					Byte:   79,
+					Byte:   31,
 				},
 				End: hcl.Pos{
-					Line:   5,
+					Line:   2,
 					Column: 37,
-					Byte:   85,
+					Byte:   37,
 				},
 			},
 		},
-		{ // provisioner
-			Type: lang.TokenBlockType,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("resource"),
-				lang.SemanticTokenModifier("provisioner"),
-			},
-			Range: hcl.Range{
-				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 6, Column: 3, Byte: 90},
-				End:      hcl.Pos{Line: 6, Column: 14, Byte: 101},
-			},
-		},
-		{ // "inner"
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.SemanticTokenModifier("resource"),
-				lang.SemanticTokenModifier("provisioner"),
-				lang.SemanticTokenModifier("type"),
-			},
+		{ // cpu_count
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 6, Column: 15, Byte: 102},
-				End:      hcl.Pos{Line: 6, Column: 22, Byte: 109},
+				Start: hcl.Pos{
+					Line:   3,
+					Column: 3,
+					Byte:   42,
+				},
+				End: hcl.Pos{
+					Line:   3,
+					Column: 12,
+					Byte:   51,
+				},
 			},
 		},
 	}
@@ -858,7 +2077,7 @@ resource "vault_auth_backend" "blah" {
 	}
 }
 
-func TestDecoder_SemanticTokensInFile_extensions_basic(t *testing.T) {
+func TestDecoder_SemanticTokensInFile_extensions_countIndexInSubBlock(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
 			"resource": {
@@ -867,12 +2086,19 @@ func TestDecoder_SemanticTokensInFile_extensions_basic(t *testing.T) {
 						Count: true,
 					},
 					Attributes: map[string]*schema.AttributeSchema{
-						"cpu_core_count": {
-							Constraint: schema.OneOf{
-								schema.Reference{OfType: cty.Number},
-								schema.LiteralType{Type: cty.Number},
+						"count": {
+							Constraint: schema.LiteralType{Type: cty.Number},
+						},
+					},
+					Blocks: map[string]*schema.BlockSchema{
+						"block": {
+							Body: &schema.BodySchema{
+								Attributes: map[string]*schema.AttributeSchema{
+									"attr": {
+										Constraint: schema.Reference{OfType: cty.Number},
+									},
+								},
 							},
-							IsOptional: true,
 						},
 					},
 				},
@@ -891,9 +2117,11 @@ func TestDecoder_SemanticTokensInFile_extensions_basic(t *testing.T) {
 	}
 
 	testCfg := []byte(`
-resource "aws_instance" "app_server" {
-  count          = 1
-  cpu_core_count = count.index
+resource "foobar" "name" {
+	count = 1
+	block {
+		attr = count.index
+	}
 }
 `)
 
@@ -916,14 +2144,14 @@ resource "aws_instance" "app_server" {
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   4,
-					Column: 20,
-					Byte:   80,
+					Line:   5,
+					Column: 10,
+					Byte:   57,
 				},
 				End: hcl.Pos{
-					Line:   4,
-					Column: 31,
-					Byte:   91,
+					Line:   5,
+					Column: 21,
+					Byte:   68,
 				},
 			},
 		},
@@ -968,7 +2196,7 @@ resource "aws_instance" "app_server" {
 				},
 			},
 		},
-		{ // aws_instance
+		{ // foobar
 			Type: lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{
 				lang.TokenModifierDependent,
@@ -982,25 +2210,25 @@ resource "aws_instance" "app_server" {
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 24,
-					Byte:   24,
+					Column: 18,
+					Byte:   18,
 				},
 			},
 		},
-		{ // app_server
+		{ // name
 			Type:      lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   2,
-					Column: 25,
-					Byte:   25,
+					Column: 19,
+					Byte:   19,
 				},
 				End: hcl.Pos{
 					Line:   2,
-					Column: 37,
-					Byte:   37,
+					Column: 25,
+					Byte:   25,
 				},
 			},
 		},
@@ -1011,81 +2239,98 @@ resource "aws_instance" "app_server" {
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   3,
-					Column: 3,
-					Byte:   42,
+					Column: 2,
+					Byte:   29,
 				},
 				End: hcl.Pos{
 					Line:   3,
-					Column: 8,
-					Byte:   47,
+					Column: 7,
+					Byte:   34,
 				},
 			},
 		},
-		{ // 1
+		{ // 1 number
 			Type:      lang.TokenNumber,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   3,
-					Column: 20,
-					Byte:   59,
+					Column: 10,
+					Byte:   37,
 				},
 				End: hcl.Pos{
 					Line:   3,
-					Column: 21,
-					Byte:   60,
+					Column: 11,
+					Byte:   38,
 				},
 			},
 		},
-		{ // cpu_core_count
-			Type:      lang.TokenAttrName,
+		{ // block
+			Type:      lang.TokenBlockType,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
 					Line:   4,
-					Column: 3,
-					Byte:   63,
+					Column: 2,
+					Byte:   40,
 				},
 				End: hcl.Pos{
 					Line:   4,
-					Column: 17,
-					Byte:   77,
+					Column: 7,
+					Byte:   45,
+				},
+			},
+		},
+		{ // attr
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start: hcl.Pos{
+					Line:   5,
+					Column: 3,
+					Byte:   50,
+				},
+				End: hcl.Pos{
+					Line:   5,
+					Column: 7,
+					Byte:   54,
 				},
 			},
 		},
 		{ // count
 			Type:      lang.TokenReferenceStep,
-			Modifiers: lang.SemanticTokenModifiers{},
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   4,
-					Column: 20,
-					Byte:   80,
+					Line:   5,
+					Column: 10,
+					Byte:   57,
 				},
 				End: hcl.Pos{
-					Line:   4,
-					Column: 25,
-					Byte:   85,
+					Line:   5,
+					Column: 15,
+					Byte:   62,
 				},
 			},
 		},
 		{ // index
 			Type:      lang.TokenReferenceStep,
-			Modifiers: lang.SemanticTokenModifiers{},
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 			Range: hcl.Range{
 				Filename: "test.tf",
 				Start: hcl.Pos{
-					Line:   4,
-					Column: 26,
-					Byte:   86,
+					Line:   5,
+					Column: 16,
+					Byte:   63,
 				},
 				End: hcl.Pos{
-					Line:   4,
-					Column: 31,
-					Byte:   91,
+					Line:   5,
+					Column: 21,
+					Byte:   68,
 				},
 			},
 		},
@@ -1097,32 +2342,20 @@ resource "aws_instance" "app_server" {
 	}
 }
 
-func TestDecoder_SemanticTokensInFile_expression_extensions_depSchema(t *testing.T) {
+func TestDecoder_SemanticTokensInFile_extensions_for_each(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
-			"resource": {
-				Body: &schema.BodySchema{
-					Extensions: &schema.BodyExtensions{
-						Count: true,
-					},
-				},
-				DependentBody: map[schema.SchemaKey]*schema.BodySchema{
-					schema.NewSchemaKey(schema.DependencyKeys{
-						Labels: []schema.LabelDependent{
-							{
-								Index: 0,
-								Value: "aws_instance",
-							},
+			"resource": {
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						ForEach: true,
+					},
+					Attributes: map[string]*schema.AttributeSchema{
+						"thing": {
+							Constraint: schema.Reference{OfType: cty.String},
 						},
-					}): {
-						Attributes: map[string]*schema.AttributeSchema{
-							"cpu_core_count": {
-								Constraint: schema.OneOf{
-									schema.Reference{OfType: cty.Number},
-									schema.LiteralType{Type: cty.Number},
-								},
-								IsOptional: true,
-							},
+						"thing_other": {
+							Constraint: schema.Reference{OfType: cty.DynamicPseudoType},
 						},
 					},
 				},
@@ -1141,44 +2374,15 @@ func TestDecoder_SemanticTokensInFile_expression_extensions_depSchema(t *testing
 	}
 
 	testCfg := []byte(`
-resource "aws_instance" "app_server" {
-  count          = 1
-  cpu_core_count = count.index
+resource "foobar" "name" {
+	for_each = {
+		a_group = "eastus"
+	}
+	thing = each.key
+	thing_other = each.value
 }
 `)
 
-	refTargets := reference.Targets{
-		{
-			LocalAddr: lang.Address{
-				lang.RootStep{Name: "count"},
-				lang.AttrStep{Name: "index"},
-			},
-			Type:        cty.Number,
-			Description: lang.PlainText("The distinct index number (starting with 0) corresponding to the instance"),
-		},
-	}
-	refOrigins := reference.Origins{
-		reference.LocalOrigin{
-			Addr: lang.Address{
-				lang.RootStep{Name: "count"},
-				lang.AttrStep{Name: "index"},
-			},
-			Range: hcl.Range{
-				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 20,
-					Byte:   80,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 31,
-					Byte:   91,
-				},
-			},
-		},
-	}
-
 	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
 	if len(pDiags) > 0 {
 		t.Fatal(pDiags)
@@ -1189,8 +2393,24 @@ resource "aws_instance" "app_server" {
 		Files: map[string]*hcl.File{
 			"test.tf": f,
 		},
-		ReferenceTargets: refTargets,
-		ReferenceOrigins: refOrigins,
+	})
+
+	targets, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origins, err := d.CollectReferenceOrigins()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: targets,
+		ReferenceOrigins: origins,
 	})
 
 	ctx := context.Background()
@@ -1206,137 +2426,111 @@ resource "aws_instance" "app_server" {
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 1,
-					Byte:   1,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 9,
-					Byte:   9,
-				},
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+				End:      hcl.Pos{Line: 2, Column: 9, Byte: 9},
 			},
 		},
-		{ // aws_instance
+		{ // foobar
 			Type: lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{
 				lang.TokenModifierDependent,
 			},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 10,
-					Byte:   10,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 24,
-					Byte:   24,
-				},
+				Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+				End:      hcl.Pos{Line: 2, Column: 18, Byte: 18},
 			},
 		},
-		{ // app_server
+		{ // name
 			Type:      lang.TokenBlockLabel,
 			Modifiers: []lang.SemanticTokenModifier{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 25,
-					Byte:   25,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 37,
-					Byte:   37,
-				},
+				Start:    hcl.Pos{Line: 2, Column: 19, Byte: 19},
+				End:      hcl.Pos{Line: 2, Column: 25, Byte: 25},
 			},
 		},
-		{ // count
+		{ // for_each
 			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 2, Byte: 29},
+				End:      hcl.Pos{Line: 3, Column: 10, Byte: 37},
+			},
+		},
+		// a_group
+		{
+			Type:      lang.TokenMapKey,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   3,
-					Column: 3,
-					Byte:   42,
-				},
-				End: hcl.Pos{
-					Line:   3,
-					Column: 8,
-					Byte:   47,
-				},
+				Start:    hcl.Pos{Line: 4, Column: 3, Byte: 44},
+				End:      hcl.Pos{Line: 4, Column: 10, Byte: 51},
 			},
 		},
-		{ // 1
-			Type:      lang.TokenNumber,
+		// "eastus"
+		{
+			Type:      lang.TokenString,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   3,
-					Column: 20,
-					Byte:   59,
-				},
-				End: hcl.Pos{
-					Line:   3,
-					Column: 21,
-					Byte:   60,
-				},
+				Start:    hcl.Pos{Line: 4, Column: 13, Byte: 54},
+				End:      hcl.Pos{Line: 4, Column: 21, Byte: 62},
 			},
 		},
-		{ // cpu_core_count
+		{ // thing
 			Type:      lang.TokenAttrName,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 3,
-					Byte:   63,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 17,
-					Byte:   77,
-				},
+				Start:    hcl.Pos{Line: 6, Column: 2, Byte: 67},
+				End:      hcl.Pos{Line: 6, Column: 7, Byte: 72},
 			},
 		},
-		{ // count
+		{ // each
 			Type:      lang.TokenReferenceStep,
-			Modifiers: lang.SemanticTokenModifiers{},
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 20,
-					Byte:   80,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 25,
-					Byte:   85,
-				},
+				Start:    hcl.Pos{Line: 6, Column: 10, Byte: 75},
+				End:      hcl.Pos{Line: 6, Column: 14, Byte: 79},
 			},
 		},
-		{ // index
+		{ // key
 			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 6, Column: 15, Byte: 80},
+				End:      hcl.Pos{Line: 6, Column: 18, Byte: 83},
+			},
+		},
+		{ // thing_other
+			Type:      lang.TokenAttrName,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 26,
-					Byte:   86,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 31,
-					Byte:   91,
-				},
+				Start:    hcl.Pos{Line: 7, Column: 2, Byte: 85},
+				End:      hcl.Pos{Line: 7, Column: 13, Byte: 96},
+			},
+		},
+		{ // each
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 16, Byte: 99},
+				End:      hcl.Pos{Line: 7, Column: 20, Byte: 103},
+			},
+		},
+		{ // value
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 21, Byte: 104},
+				End:      hcl.Pos{Line: 7, Column: 26, Byte: 109},
 			},
 		},
 	}
@@ -1347,37 +2541,61 @@ resource "aws_instance" "app_server" {
 	}
 }
 
-func TestDecoder_SemanticTokensInFile_extensions_countUndeclared(t *testing.T) {
+func TestDecoder_SemanticTokensInFile_extensions_provider(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
-			"resource": {
-				Body: &schema.BodySchema{
-					Extensions: &schema.BodyExtensions{
-						Count: true,
+			"provider": {
+				Labels: []*schema.LabelSchema{
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.AttrValueStep{Name: "alias"},
 					},
+					AsReference: true,
+					ScopeId:     schema.ProviderScopeId,
+				},
+				Body: &schema.BodySchema{
 					Attributes: map[string]*schema.AttributeSchema{
-						"cpu_count": {
-							Constraint: schema.LiteralType{Type: cty.Number},
+						"alias": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
 						},
 					},
 				},
+			},
+			"resource": {
 				Labels: []*schema.LabelSchema{
-					{
-						Name:     "type",
-						IsDepKey: true,
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{
-							lang.TokenModifierDependent,
-						},
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						Provider: true,
 					},
+				},
+			},
+			"data": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
 					{Name: "name"},
 				},
+				Body: &schema.BodySchema{},
 			},
 		},
 	}
 
-	testCfg := []byte(`
-resource "vault_auth_backend" "blah" {
-  cpu_count = count.index
+	testCfg := []byte(`provider "aws" {
+  alias = "west"
+}
+
+resource "aws_instance" "foo" {
+  provider = aws.west
+}
+
+data "aws_ami" "bar" {
+  provider = aws.west
 }
 `)
 
@@ -1393,6 +2611,24 @@ resource "vault_auth_backend" "blah" {
 		},
 	})
 
+	targets, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origins, err := d.CollectReferenceOrigins()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: targets,
+		ReferenceOrigins: origins,
+	})
+
 	ctx := context.Background()
 
 	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
@@ -1401,74 +2637,121 @@ resource "vault_auth_backend" "blah" {
 	}
 
 	expectedTokens := []lang.SemanticToken{
-		{ // resource
+		{ // provider
 			Type:      lang.TokenBlockType,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 1,
-					Byte:   1,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 9,
-					Byte:   9,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			},
 		},
-		{ // vault_auth_backend
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.TokenModifierDependent,
+		{ // aws
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
 			},
+		},
+		{ // alias
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 10,
-					Byte:   10,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 30,
-					Byte:   30,
-				},
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 19},
+				End:      hcl.Pos{Line: 2, Column: 8, Byte: 24},
 			},
 		},
-		{ // blah
+		{ // "west"
+			Type:      lang.TokenString,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 11, Byte: 27},
+				End:      hcl.Pos{Line: 2, Column: 17, Byte: 33},
+			},
+		},
+		{ // resource
+			Type:      lang.TokenBlockType,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 1, Byte: 37},
+				End:      hcl.Pos{Line: 5, Column: 9, Byte: 45},
+			},
+		},
+		{ // aws_instance
 			Type:      lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 31,
-					Byte:   31,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 37,
-					Byte:   37,
-				},
+				Start:    hcl.Pos{Line: 5, Column: 10, Byte: 46},
+				End:      hcl.Pos{Line: 5, Column: 24, Byte: 60},
 			},
 		},
-		{ // cpu_count
+		{ // foo
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 5, Column: 25, Byte: 61},
+				End:      hcl.Pos{Line: 5, Column: 30, Byte: 66},
+			},
+		},
+		{ // provider
 			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 6, Column: 3, Byte: 71},
+				End:      hcl.Pos{Line: 6, Column: 11, Byte: 79},
+			},
+		},
+		{ // aws
+			Type:      lang.TokenReferenceStep,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   3,
-					Column: 3,
-					Byte:   42,
-				},
-				End: hcl.Pos{
-					Line:   3,
-					Column: 12,
-					Byte:   51,
-				},
+				Start:    hcl.Pos{Line: 6, Column: 14, Byte: 82},
+				End:      hcl.Pos{Line: 6, Column: 17, Byte: 85},
+			},
+		},
+		{ // west
+			Type:      lang.TokenReferenceStep,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 6, Column: 18, Byte: 86},
+				End:      hcl.Pos{Line: 6, Column: 22, Byte: 90},
+			},
+		},
+		{ // data
+			Type:      lang.TokenBlockType,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 9, Column: 1, Byte: 94},
+				End:      hcl.Pos{Line: 9, Column: 5, Byte: 98},
+			},
+		},
+		{ // aws_ami
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 9, Column: 6, Byte: 99},
+				End:      hcl.Pos{Line: 9, Column: 15, Byte: 108},
+			},
+		},
+		{ // bar
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 9, Column: 16, Byte: 109},
+				End:      hcl.Pos{Line: 9, Column: 21, Byte: 114},
 			},
 		},
 	}
@@ -1479,85 +2762,38 @@ resource "vault_auth_backend" "blah" {
 	}
 }
 
-func TestDecoder_SemanticTokensInFile_extensions_countIndexInSubBlock(t *testing.T) {
+func TestDecoder_SemanticTokensInFile_extensions_dependsOn(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
 			"resource": {
-				Body: &schema.BodySchema{
-					Extensions: &schema.BodyExtensions{
-						Count: true,
-					},
-					Attributes: map[string]*schema.AttributeSchema{
-						"count": {
-							Constraint: schema.LiteralType{Type: cty.Number},
-						},
-					},
-					Blocks: map[string]*schema.BlockSchema{
-						"block": {
-							Body: &schema.BodySchema{
-								Attributes: map[string]*schema.AttributeSchema{
-									"attr": {
-										Constraint: schema.Reference{OfType: cty.Number},
-									},
-								},
-							},
-						},
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
+				Address: &schema.BlockAddrSchema{
+					Steps: []schema.AddrStep{
+						schema.LabelStep{Index: 0},
+						schema.LabelStep{Index: 1},
 					},
+					AsReference: true,
+					ScopeId:     schema.ResourceScopeId,
 				},
-				Labels: []*schema.LabelSchema{
-					{
-						Name:     "type",
-						IsDepKey: true,
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{
-							lang.TokenModifierDependent,
-						},
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						DependsOn: true,
 					},
-					{Name: "name"},
 				},
 			},
 		},
 	}
 
-	testCfg := []byte(`
-resource "foobar" "name" {
-	count = 1
-	block {
-		attr = count.index
-	}
+	testCfg := []byte(`resource "aws_instance" "bar" {
 }
-`)
 
-	refTargets := reference.Targets{
-		{
-			LocalAddr: lang.Address{
-				lang.RootStep{Name: "count"},
-				lang.AttrStep{Name: "index"},
-			},
-			Type:        cty.Number,
-			Description: lang.PlainText("The distinct index number (starting with 0) corresponding to the instance"),
-		},
-	}
-	refOrigins := reference.Origins{
-		reference.LocalOrigin{
-			Addr: lang.Address{
-				lang.RootStep{Name: "count"},
-				lang.AttrStep{Name: "index"},
-			},
-			Range: hcl.Range{
-				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   5,
-					Column: 10,
-					Byte:   57,
-				},
-				End: hcl.Pos{
-					Line:   5,
-					Column: 21,
-					Byte:   68,
-				},
-			},
-		},
-	}
+resource "aws_instance" "foo" {
+  depends_on = [aws_instance.bar]
+}
+`)
 
 	f, pDiags := hclsyntax.ParseConfig(testCfg, "test.tf", hcl.InitialPos)
 	if len(pDiags) > 0 {
@@ -1569,8 +2805,24 @@ resource "foobar" "name" {
 		Files: map[string]*hcl.File{
 			"test.tf": f,
 		},
-		ReferenceTargets: refTargets,
-		ReferenceOrigins: refOrigins,
+	})
+
+	targets, err := d.CollectReferenceTargets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	origins, err := d.CollectReferenceOrigins()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d = testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		ReferenceTargets: targets,
+		ReferenceOrigins: origins,
 	})
 
 	ctx := context.Background()
@@ -1583,157 +2835,83 @@ resource "foobar" "name" {
 	expectedTokens := []lang.SemanticToken{
 		{ // resource
 			Type:      lang.TokenBlockType,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 1,
-					Byte:   1,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 9,
-					Byte:   9,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			},
 		},
-		{ // foobar
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.TokenModifierDependent,
-			},
+		{ // aws_instance
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 10,
-					Byte:   10,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 18,
-					Byte:   18,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			},
 		},
-		{ // name
+		{ // bar
 			Type:      lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   2,
-					Column: 19,
-					Byte:   19,
-				},
-				End: hcl.Pos{
-					Line:   2,
-					Column: 25,
-					Byte:   25,
-				},
+				Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+				End:      hcl.Pos{Line: 1, Column: 30, Byte: 29},
 			},
 		},
-		{ // count
-			Type:      lang.TokenAttrName,
+		{ // resource
+			Type:      lang.TokenBlockType,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   3,
-					Column: 2,
-					Byte:   29,
-				},
-				End: hcl.Pos{
-					Line:   3,
-					Column: 7,
-					Byte:   34,
-				},
+				Start:    hcl.Pos{Line: 4, Column: 1, Byte: 35},
+				End:      hcl.Pos{Line: 4, Column: 9, Byte: 43},
 			},
 		},
-		{ // 1 number
-			Type:      lang.TokenNumber,
+		{ // aws_instance
+			Type:      lang.TokenBlockLabel,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   3,
-					Column: 10,
-					Byte:   37,
-				},
-				End: hcl.Pos{
-					Line:   3,
-					Column: 11,
-					Byte:   38,
-				},
+				Start:    hcl.Pos{Line: 4, Column: 10, Byte: 44},
+				End:      hcl.Pos{Line: 4, Column: 24, Byte: 58},
 			},
 		},
-		{ // block
-			Type:      lang.TokenBlockType,
+		{ // foo
+			Type:      lang.TokenBlockLabel,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   4,
-					Column: 2,
-					Byte:   40,
-				},
-				End: hcl.Pos{
-					Line:   4,
-					Column: 7,
-					Byte:   45,
-				},
+				Start:    hcl.Pos{Line: 4, Column: 25, Byte: 59},
+				End:      hcl.Pos{Line: 4, Column: 30, Byte: 64},
 			},
 		},
-		{ // attr
+		{ // depends_on
 			Type:      lang.TokenAttrName,
-			Modifiers: lang.SemanticTokenModifiers{},
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   5,
-					Column: 3,
-					Byte:   50,
-				},
-				End: hcl.Pos{
-					Line:   5,
-					Column: 7,
-					Byte:   54,
-				},
+				Start:    hcl.Pos{Line: 5, Column: 3, Byte: 69},
+				End:      hcl.Pos{Line: 5, Column: 13, Byte: 79},
 			},
 		},
-		{ // count
+		{ // aws_instance
 			Type:      lang.TokenReferenceStep,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   5,
-					Column: 10,
-					Byte:   57,
-				},
-				End: hcl.Pos{
-					Line:   5,
-					Column: 15,
-					Byte:   62,
-				},
+				Start:    hcl.Pos{Line: 5, Column: 17, Byte: 83},
+				End:      hcl.Pos{Line: 5, Column: 29, Byte: 95},
 			},
 		},
-		{ // index
+		{ // bar
 			Type:      lang.TokenReferenceStep,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start: hcl.Pos{
-					Line:   5,
-					Column: 16,
-					Byte:   63,
-				},
-				End: hcl.Pos{
-					Line:   5,
-					Column: 21,
-					Byte:   68,
-				},
+				Start:    hcl.Pos{Line: 5, Column: 30, Byte: 96},
+				End:      hcl.Pos{Line: 5, Column: 33, Byte: 99},
 			},
 		},
 	}
@@ -1744,44 +2922,49 @@ resource "foobar" "name" {
 	}
 }
 
-func TestDecoder_SemanticTokensInFile_extensions_for_each(t *testing.T) {
+func TestDecoder_SemanticTokensInFile_extensions_lifecycle(t *testing.T) {
 	bodySchema := &schema.BodySchema{
 		Blocks: map[string]*schema.BlockSchema{
 			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name"},
+				},
 				Body: &schema.BodySchema{
 					Extensions: &schema.BodyExtensions{
-						ForEach: true,
+						Lifecycle: true,
 					},
 					Attributes: map[string]*schema.AttributeSchema{
-						"thing": {
-							Constraint: schema.Reference{OfType: cty.String},
-						},
-						"thing_other": {
-							Constraint: schema.Reference{OfType: cty.DynamicPseudoType},
+						"ami": {
+							IsOptional: true,
+							Constraint: schema.LiteralType{Type: cty.String},
 						},
-					},
-				},
-				Labels: []*schema.LabelSchema{
-					{
-						Name:     "type",
-						IsDepKey: true,
-						SemanticTokenModifiers: lang.SemanticTokenModifiers{
-							lang.TokenModifierDependent,
+						"tags": {
+							IsOptional: true,
+							Constraint: schema.Map{Elem: schema.LiteralType{Type: cty.String}},
 						},
 					},
-					{Name: "name"},
 				},
 			},
 		},
 	}
 
-	testCfg := []byte(`
-resource "foobar" "name" {
-	for_each = {
-		a_group = "eastus"
-	}
-	thing = each.key
-	thing_other = each.value
+	testCfg := []byte(`resource "aws_instance" "foo" {
+  lifecycle {
+    ignore_changes = [ami, tags]
+  }
+}
+
+resource "aws_instance" "bar" {
+  lifecycle {
+    ignore_changes = all
+  }
+}
+
+resource "aws_instance" "baz" {
+  lifecycle {
+    ignore_changes = [tags["Name"]]
+  }
 }
 `)
 
@@ -1797,24 +2980,6 @@ resource "foobar" "name" {
 		},
 	})
 
-	targets, err := d.CollectReferenceTargets()
-	if err != nil {
-		t.Fatal(err)
-	}
-	origins, err := d.CollectReferenceOrigins()
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	d = testPathDecoder(t, &PathContext{
-		Schema: bodySchema,
-		Files: map[string]*hcl.File{
-			"test.tf": f,
-		},
-		ReferenceTargets: targets,
-		ReferenceOrigins: origins,
-	})
-
 	ctx := context.Background()
 
 	tokens, err := d.SemanticTokensInFile(ctx, "test.tf")
@@ -1825,114 +2990,182 @@ resource "foobar" "name" {
 	expectedTokens := []lang.SemanticToken{
 		{ // resource
 			Type:      lang.TokenBlockType,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
-				End:      hcl.Pos{Line: 2, Column: 9, Byte: 9},
+				Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+				End:      hcl.Pos{Line: 1, Column: 9, Byte: 8},
 			},
 		},
-		{ // foobar
-			Type: lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{
-				lang.TokenModifierDependent,
-			},
+		{ // aws_instance
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
-				End:      hcl.Pos{Line: 2, Column: 18, Byte: 18},
+				Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+				End:      hcl.Pos{Line: 1, Column: 24, Byte: 23},
 			},
 		},
-		{ // name
+		{ // foo
 			Type:      lang.TokenBlockLabel,
-			Modifiers: []lang.SemanticTokenModifier{},
+			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 2, Column: 19, Byte: 19},
-				End:      hcl.Pos{Line: 2, Column: 25, Byte: 25},
+				Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+				End:      hcl.Pos{Line: 1, Column: 30, Byte: 29},
 			},
 		},
-		{ // for_each
+		{ // lifecycle
+			Type:      lang.TokenBlockType,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 3, Byte: 34},
+				End:      hcl.Pos{Line: 2, Column: 12, Byte: 43},
+			},
+		},
+		{ // ignore_changes
 			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 5, Byte: 50},
+				End:      hcl.Pos{Line: 3, Column: 19, Byte: 64},
+			},
+		},
+		{ // ami
+			Type:      lang.TokenReferenceStep,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 3, Column: 2, Byte: 29},
-				End:      hcl.Pos{Line: 3, Column: 10, Byte: 37},
+				Start:    hcl.Pos{Line: 3, Column: 23, Byte: 68},
+				End:      hcl.Pos{Line: 3, Column: 26, Byte: 71},
 			},
 		},
-		// a_group
-		{
-			Type:      lang.TokenMapKey,
+		{ // tags
+			Type:      lang.TokenReferenceStep,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 4, Column: 3, Byte: 44},
-				End:      hcl.Pos{Line: 4, Column: 10, Byte: 51},
+				Start:    hcl.Pos{Line: 3, Column: 28, Byte: 73},
+				End:      hcl.Pos{Line: 3, Column: 32, Byte: 77},
 			},
 		},
-		// "eastus"
-		{
-			Type:      lang.TokenString,
+		{ // resource
+			Type:      lang.TokenBlockType,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 4, Column: 13, Byte: 54},
-				End:      hcl.Pos{Line: 4, Column: 21, Byte: 62},
+				Start:    hcl.Pos{Line: 7, Column: 1, Byte: 86},
+				End:      hcl.Pos{Line: 7, Column: 9, Byte: 94},
 			},
 		},
-		{ // thing
+		{ // aws_instance
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 10, Byte: 95},
+				End:      hcl.Pos{Line: 7, Column: 24, Byte: 109},
+			},
+		},
+		{ // bar
+			Type:      lang.TokenBlockLabel,
+			Modifiers: lang.SemanticTokenModifiers{},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 7, Column: 25, Byte: 110},
+				End:      hcl.Pos{Line: 7, Column: 30, Byte: 115},
+			},
+		},
+		{ // lifecycle
+			Type:      lang.TokenBlockType,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 8, Column: 3, Byte: 120},
+				End:      hcl.Pos{Line: 8, Column: 12, Byte: 129},
+			},
+		},
+		{ // ignore_changes
 			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 9, Column: 5, Byte: 136},
+				End:      hcl.Pos{Line: 9, Column: 19, Byte: 150},
+			},
+		},
+		{ // all
+			Type:      lang.TokenKeyword,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 6, Column: 2, Byte: 67},
-				End:      hcl.Pos{Line: 6, Column: 7, Byte: 72},
+				Start:    hcl.Pos{Line: 9, Column: 22, Byte: 153},
+				End:      hcl.Pos{Line: 9, Column: 25, Byte: 156},
 			},
 		},
-		{ // each
-			Type:      lang.TokenReferenceStep,
+		{ // resource
+			Type:      lang.TokenBlockType,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 6, Column: 10, Byte: 75},
-				End:      hcl.Pos{Line: 6, Column: 14, Byte: 79},
+				Start:    hcl.Pos{Line: 13, Column: 1, Byte: 164},
+				End:      hcl.Pos{Line: 13, Column: 9, Byte: 172},
 			},
 		},
-		{ // key
-			Type:      lang.TokenReferenceStep,
+		{ // aws_instance
+			Type:      lang.TokenBlockLabel,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 6, Column: 15, Byte: 80},
-				End:      hcl.Pos{Line: 6, Column: 18, Byte: 83},
+				Start:    hcl.Pos{Line: 13, Column: 10, Byte: 173},
+				End:      hcl.Pos{Line: 13, Column: 24, Byte: 187},
 			},
 		},
-		{ // thing_other
-			Type:      lang.TokenAttrName,
+		{ // baz
+			Type:      lang.TokenBlockLabel,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 7, Column: 2, Byte: 85},
-				End:      hcl.Pos{Line: 7, Column: 13, Byte: 96},
+				Start:    hcl.Pos{Line: 13, Column: 25, Byte: 188},
+				End:      hcl.Pos{Line: 13, Column: 30, Byte: 193},
 			},
 		},
-		{ // each
+		{ // lifecycle
+			Type:      lang.TokenBlockType,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 14, Column: 3, Byte: 198},
+				End:      hcl.Pos{Line: 14, Column: 12, Byte: 207},
+			},
+		},
+		{ // ignore_changes
+			Type:      lang.TokenAttrName,
+			Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 15, Column: 5, Byte: 214},
+				End:      hcl.Pos{Line: 15, Column: 19, Byte: 228},
+			},
+		},
+		{ // tags
 			Type:      lang.TokenReferenceStep,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 7, Column: 16, Byte: 99},
-				End:      hcl.Pos{Line: 7, Column: 20, Byte: 103},
+				Start:    hcl.Pos{Line: 15, Column: 23, Byte: 232},
+				End:      hcl.Pos{Line: 15, Column: 27, Byte: 236},
 			},
 		},
-		{ // value
-			Type:      lang.TokenReferenceStep,
+		{ // "Name"
+			Type:      lang.TokenMapKey,
 			Modifiers: lang.SemanticTokenModifiers{},
 			Range: hcl.Range{
 				Filename: "test.tf",
-				Start:    hcl.Pos{Line: 7, Column: 21, Byte: 104},
-				End:      hcl.Pos{Line: 7, Column: 26, Byte: 109},
+				Start:    hcl.Pos{Line: 15, Column: 28, Byte: 237},
+				End:      hcl.Pos{Line: 15, Column: 34, Byte: 243},
 			},
 		},
 	}
@@ -2024,7 +3257,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // dynamic
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
@@ -2033,7 +3266,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "setting"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 32},
@@ -2042,7 +3275,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // content
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 3, Column: 5, Byte: 48},
@@ -2135,7 +3368,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // dynamic
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 2, Column: 3, Byte: 24},
@@ -2144,7 +3377,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "setting"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 2, Column: 11, Byte: 32},
@@ -2153,7 +3386,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // content
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 3, Column: 5, Byte: 48},
@@ -2162,7 +3395,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // dynamic
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 4, Column: 7, Byte: 64},
@@ -2171,7 +3404,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "setting"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 4, Column: 15, Byte: 72},
@@ -2180,7 +3413,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // content
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 5, Column: 9, Byte: 88},
@@ -2296,7 +3529,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // dynamic
 					Type:      lang.TokenBlockType,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 4, Column: 7, Byte: 50},
@@ -2305,7 +3538,7 @@ func TestDecoder_SemanticTokensInFile_extensions_dynamic(t *testing.T) {
 				},
 				{ // "bar"
 					Type:      lang.TokenBlockLabel,
-					Modifiers: lang.SemanticTokenModifiers{},
+					Modifiers: lang.SemanticTokenModifiers{lang.TokenModifierSynthetic},
 					Range: hcl.Range{
 						Filename: "test.tf",
 						Start:    hcl.Pos{Line: 4, Column: 15, Byte: 58},
@@ -38,7 +38,7 @@ func (d *PathDecoder) SignatureAtPos(filename string, pos hcl.Pos) (*lang.Functi
 			return nil // No function call expression
 		}
 
-		f, ok := d.pathCtx.Functions[fNode.Name]
+		f, ok := d.pathCtx.functionSignature(fNode.Name)
 		if !ok {
 			return nil // Unknown function
 		}
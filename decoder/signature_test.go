@@ -499,6 +499,121 @@ func TestSignatureAtPos(t *testing.T) {
 
 }
 
+func TestSignatureAtPos_ctyFunctions(t *testing.T) {
+	ctyFunctions := map[string]function.Function{
+		"foo": function.New(&function.Spec{
+			Description: "`foo` description",
+			Params: []function.Parameter{
+				{
+					Name:        "input",
+					Type:        cty.String,
+					Description: "`input` description",
+				},
+			},
+			VarParam: &function.Parameter{
+				Name:        "vinput",
+				Type:        cty.Number,
+				Description: "`vinput` description",
+			},
+			Type: function.StaticReturnType(cty.String),
+		}),
+		"bar": function.New(&function.Spec{
+			Params: []function.Parameter{
+				{
+					Name: "input",
+					Type: cty.DynamicPseudoType,
+				},
+			},
+			Type: func(args []cty.Value) (cty.Type, error) {
+				return args[0].Type(), nil
+			},
+		}).WithNewDescriptions("`bar` description", []string{"`input` description"}),
+	}
+
+	testCases := []struct {
+		testName          string
+		functions         map[string]schema.FunctionSignature
+		cfg               string
+		pos               hcl.Pos
+		expectedSignature *lang.FunctionSignature
+	}{
+		{
+			"variadic function",
+			nil,
+			`x = foo("a", )`,
+			hcl.Pos{Line: 1, Column: 12, Byte: 13},
+			&lang.FunctionSignature{
+				Name:        "foo(input string, …vinput number) string",
+				Description: lang.Markdown("`foo` description"),
+				Parameters: []lang.FunctionParameter{
+					{
+						Name:        "input",
+						Description: lang.Markdown("`input` description"),
+					},
+					{
+						Name:        "vinput",
+						Description: lang.Markdown("`vinput` description"),
+					},
+				},
+				ActiveParameter: 1,
+			},
+		},
+		{
+			"dynamic return type with new descriptions",
+			nil,
+			`x = bar()`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.FunctionSignature{
+				Name:        "bar(input dynamic) dynamic",
+				Description: lang.Markdown("`bar` description"),
+				Parameters: []lang.FunctionParameter{
+					{
+						Name:        "input",
+						Description: lang.Markdown("`input` description"),
+					},
+				},
+			},
+		},
+		{
+			"function signature takes precedence",
+			map[string]schema.FunctionSignature{
+				"foo": {
+					ReturnType:  cty.Number,
+					Description: "overridden `foo` description",
+				},
+			},
+			`x = foo()`,
+			hcl.Pos{Line: 1, Column: 9, Byte: 8},
+			&lang.FunctionSignature{
+				Name:        "foo() number",
+				Description: lang.Markdown("overridden `foo` description"),
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Functions:    tc.functions,
+				CtyFunctions: ctyFunctions,
+			})
+
+			signature, err := d.SignatureAtPos("test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedSignature, signature); diff != "" {
+				t.Fatalf("unexpected signature: %s", diff)
+			}
+		})
+	}
+}
+
 func TestSignatureAtPos_json(t *testing.T) {
 	f, pDiags := json.Parse([]byte(`{
 		"attribute": "${abs(-1)}"
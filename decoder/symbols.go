@@ -101,19 +101,13 @@ func (d *PathDecoder) symbolsForBody(body hcl.Body, bodySchema *schema.BodySchem
 	content := ast.DecodeBody(body, bodySchema)
 
 	for name, attr := range content.Attributes {
-		symbols = append(symbols, &AttributeSymbol{
-			AttrName:      name,
-			ExprKind:      symbolExprKind(attr.Expr),
-			path:          d.path,
-			rng:           attr.Range,
-			nestedSymbols: d.nestedSymbolsForExpr(attr.Expr),
-		})
+		symbols = append(symbols, d.attributeSymbol(name, attr))
 	}
 
 	for _, block := range content.Blocks {
 		var bSchema *schema.BodySchema
 		if bodySchema != nil {
-			bs, ok := bodySchema.Blocks[block.Type]
+			bs, ok := schemahelper.BlockSchema(bodySchema, block.Type)
 			if ok {
 				bSchema = bs.Body
 				mergedSchema, _ := schemahelper.MergeBlockBodySchemas(block.Block, bs)
@@ -121,20 +115,38 @@ func (d *PathDecoder) symbolsForBody(body hcl.Body, bodySchema *schema.BodySchem
 			}
 		}
 
-		symbols = append(symbols, &BlockSymbol{
-			Type:          block.Type,
-			Labels:        block.Labels,
-			path:          d.path,
-			rng:           block.Range,
-			nestedSymbols: d.symbolsForBody(block.Body, bSchema),
-		})
+		symbols = append(symbols, d.blockSymbol(block, d.symbolsForBody(block.Body, bSchema)))
 	}
 
+	sortSymbols(symbols)
+
+	return symbols
+}
+
+func (d *PathDecoder) attributeSymbol(name string, attr *hcl.Attribute) Symbol {
+	return &AttributeSymbol{
+		AttrName:      name,
+		ExprKind:      symbolExprKind(attr.Expr),
+		path:          d.path,
+		rng:           attr.Range,
+		nestedSymbols: d.nestedSymbolsForExpr(attr.Expr),
+	}
+}
+
+func (d *PathDecoder) blockSymbol(block *ast.BlockContent, nestedSymbols []Symbol) Symbol {
+	return &BlockSymbol{
+		Type:          block.Type,
+		Labels:        block.Labels,
+		path:          d.path,
+		rng:           block.Range,
+		nestedSymbols: nestedSymbols,
+	}
+}
+
+func sortSymbols(symbols []Symbol) {
 	sort.SliceStable(symbols, func(i, j int) bool {
 		return symbols[i].Range().Start.Byte < symbols[j].Range().Start.Byte
 	})
-
-	return symbols
 }
 
 func symbolExprKind(expr hcl.Expression) lang.SymbolExprKind {
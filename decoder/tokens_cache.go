@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package decoder
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+)
+
+type tokensCacheKey struct {
+	path     lang.Path
+	filename string
+}
+
+type tokensCacheEntry struct {
+	version uint64
+	tokens  []lang.SemanticToken
+}
+
+// cachedTokens returns semantic tokens previously computed
+// for the given file, if these were computed for the same
+// (non-zero) version of the path context.
+func (d *Decoder) cachedTokens(path lang.Path, filename string, version uint64) ([]lang.SemanticToken, bool) {
+	if version == 0 {
+		return nil, false
+	}
+
+	d.tokensCacheMu.Lock()
+	defer d.tokensCacheMu.Unlock()
+
+	entry, ok := d.tokensCache[tokensCacheKey{path, filename}]
+	if !ok || entry.version != version {
+		return nil, false
+	}
+
+	return copyTokens(entry.tokens), true
+}
+
+func (d *Decoder) cacheTokens(path lang.Path, filename string, version uint64, tokens []lang.SemanticToken) {
+	if version == 0 {
+		return
+	}
+
+	d.tokensCacheMu.Lock()
+	defer d.tokensCacheMu.Unlock()
+
+	if d.tokensCache == nil {
+		d.tokensCache = make(map[tokensCacheKey]tokensCacheEntry)
+	}
+	d.tokensCache[tokensCacheKey{path, filename}] = tokensCacheEntry{
+		version: version,
+		tokens:  copyTokens(tokens),
+	}
+}
+
+func (d *Decoder) resetTokensCache() {
+	d.tokensCacheMu.Lock()
+	defer d.tokensCacheMu.Unlock()
+
+	d.tokensCache = nil
+}
+
+func copyTokens(tokens []lang.SemanticToken) []lang.SemanticToken {
+	if tokens == nil {
+		return nil
+	}
+	cp := make([]lang.SemanticToken, len(tokens))
+	for i, token := range tokens {
+		cp[i] = lang.SemanticToken{
+			Type:      token.Type,
+			Modifiers: token.Modifiers.Copy(),
+			Range:     token.Range,
+		}
+	}
+	return cp
+}
@@ -6,9 +6,12 @@ package decoder
 import (
 	"context"
 
+	"github.com/hashicorp/hcl-lang/decoder/internal/schemahelper"
 	"github.com/hashicorp/hcl-lang/decoder/internal/walker"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
 	"github.com/hashicorp/hcl-lang/validator"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
@@ -21,21 +24,30 @@ func (d *PathDecoder) Validate(ctx context.Context) (lang.DiagnosticsMap, error)
 		return diags, &NoSchemaError{}
 	}
 
-	if len(d.pathCtx.Validators) == 0 {
+	validators := d.validators()
+	if len(validators) == 0 {
 		return diags, nil
 	}
 
+	ctx = reference.WithTargets(ctx, d.pathCtx.ReferenceTargets)
+	ctx = reference.WithOrigins(ctx, d.pathCtx.ReferenceOrigins)
+	ctx = schema.WithFunctionSignatures(ctx, d.functionSignatures())
+
 	// Validate module files per schema
 	for filename, f := range d.pathCtx.Files {
+		if hasNilBody(f) {
+			continue
+		}
 		body, ok := f.Body.(*hclsyntax.Body)
 		if !ok {
 			// TODO! error
 			continue
 		}
 
-		diags[filename] = walker.Walk(ctx, body, d.pathCtx.Schema, validationWalker{
-			validators: d.pathCtx.Validators,
-		})
+		fileCtx := schemacontext.WithFileBytes(ctx, f.Bytes)
+		diags[filename] = uniqueDiagnostics(walker.Walk(fileCtx, body, d.pathCtx.Schema, validationWalker{
+			validators: validators,
+		}))
 	}
 
 	return diags, nil
@@ -47,7 +59,8 @@ func (d *PathDecoder) ValidateFile(ctx context.Context, filename string) (hcl.Di
 		return hcl.Diagnostics{}, &NoSchemaError{}
 	}
 
-	if len(d.pathCtx.Validators) == 0 {
+	validators := d.validators()
+	if len(validators) == 0 {
 		return hcl.Diagnostics{}, nil
 	}
 
@@ -61,9 +74,136 @@ func (d *PathDecoder) ValidateFile(ctx context.Context, filename string) (hcl.Di
 		return hcl.Diagnostics{}, &UnknownFileFormatError{Filename: filename}
 	}
 
-	return walker.Walk(ctx, body, d.pathCtx.Schema, validationWalker{
-		validators: d.pathCtx.Validators,
-	}), nil
+	ctx = reference.WithTargets(ctx, d.pathCtx.ReferenceTargets)
+	ctx = reference.WithOrigins(ctx, d.pathCtx.ReferenceOrigins)
+	ctx = schema.WithFunctionSignatures(ctx, d.functionSignatures())
+	ctx = schemacontext.WithFileBytes(ctx, f.Bytes)
+
+	return uniqueDiagnostics(walker.Walk(ctx, body, d.pathCtx.Schema, validationWalker{
+		validators: validators,
+	})), nil
+}
+
+// ValidateAttributeAtPos validates the attribute at the given position
+// in a file within the given path and returns a list of Diagnostics
+// for that attribute only.
+//
+// This is intended for validation of values as they are being typed,
+// where validating the whole file would be unnecessarily expensive.
+func (d *Decoder) ValidateAttributeAtPos(ctx context.Context, path lang.Path, filename string, pos hcl.Pos) (hcl.Diagnostics, error) {
+	pd, err := d.Path(path)
+	if err != nil {
+		return hcl.Diagnostics{}, err
+	}
+
+	return pd.ValidateAttributeAtPos(d.pathContext(ctx, pd), filename, pos)
+}
+
+// ValidateAttributeAtPos validates the attribute at the given position
+// and returns a list of Diagnostics for that attribute.
+//
+// Empty Diagnostics are returned if the position is outside of any attribute.
+func (d *PathDecoder) ValidateAttributeAtPos(ctx context.Context, filename string, pos hcl.Pos) (hcl.Diagnostics, error) {
+	if d.pathCtx.Schema == nil {
+		return hcl.Diagnostics{}, &NoSchemaError{}
+	}
+
+	validators := d.validators()
+	if len(validators) == 0 {
+		return hcl.Diagnostics{}, nil
+	}
+
+	f, err := d.fileByName(filename)
+	if err != nil {
+		return hcl.Diagnostics{}, err
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return hcl.Diagnostics{}, &UnknownFileFormatError{Filename: filename}
+	}
+
+	ctx = reference.WithTargets(ctx, d.pathCtx.ReferenceTargets)
+	ctx = reference.WithOrigins(ctx, d.pathCtx.ReferenceOrigins)
+	ctx = schema.WithFunctionSignatures(ctx, d.functionSignatures())
+	ctx = schemacontext.WithFileBytes(ctx, f.Bytes)
+	ctx = schemacontext.WithBlockNestingLevel(ctx, 0)
+
+	vw := validationWalker{
+		validators: validators,
+	}
+	return uniqueDiagnostics(vw.validateAttributeAtPos(ctx, body, d.pathCtx.Schema, pos)), nil
+}
+
+// validateAttributeAtPos descends into blocks containing the given position
+// and validates the attribute containing it, if any.
+//
+// The context is enriched the same way as by walker.Walk, so that
+// validators observe the attribute as they would during a walk of the file.
+func (vw validationWalker) validateAttributeAtPos(ctx context.Context, body *hclsyntax.Body, bodySchema *schema.BodySchema, pos hcl.Pos) hcl.Diagnostics {
+	schemaPath, _ := schemacontext.FromContext(ctx)
+	if bodySchema == nil {
+		ctx = schemacontext.WithUnknownSchema(ctx)
+	}
+
+	for _, attr := range body.Attributes {
+		if !attr.Range().ContainsPos(pos) {
+			continue
+		}
+
+		var attrSchema schema.Schema = nil
+		if bodySchema != nil {
+			if aSchema, ok := schemahelper.AttributeSchema(bodySchema, attr.Name); ok {
+				attrSchema = aSchema
+			}
+		}
+
+		attrCtx := schemacontext.WithSchemaPath(ctx, schemaPath.WithAttributeName(attr.Name))
+		_, diags := vw.Visit(attrCtx, attr, attrSchema)
+		if diags == nil {
+			return hcl.Diagnostics{}
+		}
+		return diags
+	}
+
+	for _, block := range body.Blocks {
+		if !block.Body.Range().ContainsPos(pos) {
+			continue
+		}
+
+		blockCtx := schemacontext.WithSchemaPath(ctx, schemaPath.WithBlockType(block.Type))
+
+		var blockBodySchema *schema.BodySchema
+		if bodySchema != nil {
+			bSchema, ok := schemahelper.BlockSchema(bodySchema, block.Type)
+			if ok && bSchema.Body != nil {
+				mergedSchema, result := schemahelper.MergeBlockBodySchemas(block.AsHCLBlock(), bSchema)
+				if result == schemahelper.LookupFailed || result == schemahelper.LookupPartiallySuccessful {
+					blockCtx = schemacontext.WithUnknownSchema(blockCtx)
+				}
+				blockBodySchema = mergedSchema
+			}
+		}
+
+		nestingLvl, _ := schemacontext.BlockNestingLevel(ctx)
+		blockCtx = schemacontext.WithBlockNestingLevel(blockCtx, nestingLvl+1)
+
+		return vw.validateAttributeAtPos(blockCtx, block.Body, blockBodySchema, pos)
+	}
+
+	return hcl.Diagnostics{}
+}
+
+// validators returns validators of the path
+// along with any enabled via DecoderContext
+func (d *PathDecoder) validators() []validator.Validator {
+	if !d.decoderCtx.ReportUnknownFunctions {
+		return d.pathCtx.Validators
+	}
+
+	validators := make([]validator.Validator, 0, len(d.pathCtx.Validators)+1)
+	validators = append(validators, d.pathCtx.Validators...)
+	return append(validators, validator.UnknownFunction{})
 }
 
 type validationWalker struct {
@@ -73,6 +213,11 @@ type validationWalker struct {
 func (vw validationWalker) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
 	var diags, vDiags hcl.Diagnostics
 
+	if aSchema, ok := nodeSchema.(*schema.AttributeSchema); ok && aSchema.IsCompletionOnly {
+		// completion-only attributes are never validated
+		return ctx, diags
+	}
+
 	for _, v := range vw.validators {
 		ctx, vDiags = v.Visit(ctx, node, nodeSchema)
 		diags = append(diags, vDiags...)
@@ -80,3 +225,44 @@ func (vw validationWalker) Visit(ctx context.Context, node hclsyntax.Node, nodeS
 
 	return ctx, diags
 }
+
+// uniqueDiagnostics returns the given diagnostics without duplicates,
+// so that overlapping validators do not report the same problem twice,
+// regardless of whether they report it when visiting the same node
+// or different nodes (e.g. a body and an attribute within it).
+func uniqueDiagnostics(diags hcl.Diagnostics) hcl.Diagnostics {
+	if len(diags) == 0 {
+		return diags
+	}
+	return appendUniqueDiagnostics(make(hcl.Diagnostics, 0, len(diags)), diags...)
+}
+
+// appendUniqueDiagnostics appends the given diagnostics to diags,
+// skipping any which are already present.
+func appendUniqueDiagnostics(diags hcl.Diagnostics, newDiags ...*hcl.Diagnostic) hcl.Diagnostics {
+	for _, newDiag := range newDiags {
+		isDuplicate := false
+		for _, diag := range diags {
+			if diagnosticsEqual(diag, newDiag) {
+				isDuplicate = true
+				break
+			}
+		}
+		if !isDuplicate {
+			diags = append(diags, newDiag)
+		}
+	}
+	return diags
+}
+
+// diagnosticsEqual reports whether the two diagnostics describe
+// the same problem, i.e. have the same severity, summary and subject.
+func diagnosticsEqual(a, b *hcl.Diagnostic) bool {
+	if a.Severity != b.Severity || a.Summary != b.Summary {
+		return false
+	}
+	if a.Subject == nil || b.Subject == nil {
+		return a.Subject == b.Subject
+	}
+	return *a.Subject == *b.Subject
+}
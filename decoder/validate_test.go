@@ -11,11 +11,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl-lang/validator"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
 )
 
 func TestValidate_schema(t *testing.T) {
@@ -144,6 +146,61 @@ wakka = 2
 				},
 			},
 		},
+		{
+			"deprecated attribute with replacement",
+			&schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"wakka": {
+						Constraint:   schema.LiteralType{Type: cty.Number},
+						IsOptional:   true,
+						IsDeprecated: true,
+						ReplacedBy:   "wakka_wakka",
+						Description:  lang.PlainText("No longer supported"),
+					},
+					"wakka_wakka": {
+						Constraint: schema.LiteralType{Type: cty.Number},
+						IsOptional: true,
+					},
+				},
+			},
+			`wakka = 2
+`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagWarning,
+						Summary:  "\"wakka\" is deprecated",
+						Detail:   "Reason: \"No longer supported\". Use \"wakka_wakka\" instead",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						},
+					},
+				},
+			},
+		},
+		{
+			"completion-only attributes",
+			&schema.BodySchema{
+				Attributes: map[string]*schema.AttributeSchema{
+					"required": {
+						Constraint:       schema.LiteralType{Type: cty.Number},
+						IsRequired:       true,
+						IsCompletionOnly: true,
+					},
+					"deprecated": {
+						Constraint:       schema.LiteralType{Type: cty.Number},
+						IsOptional:       true,
+						IsDeprecated:     true,
+						IsCompletionOnly: true,
+					},
+				},
+			},
+			`deprecated = 2
+`,
+			map[string]hcl.Diagnostics{},
+		},
 		// blocks
 		{
 			"missing required attribute",
@@ -245,6 +302,35 @@ wakka = 2
 				},
 			},
 		},
+		{
+			"deprecated block with replacement",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"foo": {
+						IsDeprecated: true,
+						ReplacedBy:   "bar",
+						Description:  lang.PlainText("No longer supported"),
+					},
+					"bar": {},
+				},
+			},
+			`foo {
+}`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagWarning,
+						Summary:  "\"foo\" is deprecated",
+						Detail:   "Reason: \"No longer supported\". Use \"bar\" instead",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+							End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+						},
+					},
+				},
+			},
+		},
 		{
 			"extra block labels",
 			&schema.BodySchema{
@@ -369,6 +455,75 @@ wakka = 2
 				},
 			},
 		},
+		{
+			"block alias",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"foo": {
+						Body: &schema.BodySchema{
+							Blocks: map[string]*schema.BlockSchema{
+								"bar": {
+									Aliases: []string{"baz"},
+								},
+							},
+							Attributes: map[string]*schema.AttributeSchema{
+								"test": {
+									Constraint: schema.LiteralType{Type: cty.Number},
+									IsRequired: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			`foo {
+				baz {}
+				test = 1
+			}`,
+			map[string]hcl.Diagnostics{},
+		},
+		{
+			"too many blocks with alias",
+			&schema.BodySchema{
+				Blocks: map[string]*schema.BlockSchema{
+					"foo": {
+						Body: &schema.BodySchema{
+							Blocks: map[string]*schema.BlockSchema{
+								"bar": {
+									Aliases:  []string{"baz"},
+									MaxItems: 1,
+								},
+							},
+							Attributes: map[string]*schema.AttributeSchema{
+								"test": {
+									Constraint: schema.LiteralType{Type: cty.Number},
+									IsRequired: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			`foo {
+				bar {}
+				baz {}
+				test = 1
+			}`,
+			map[string]hcl.Diagnostics{
+				"test.tf": {
+					&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Too many blocks specified for \"bar\"",
+						Detail:   "Only 1 block(s) are expected for \"bar\"",
+						Subject: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 1, Column: 5, Byte: 4},
+							End:      hcl.Pos{Line: 5, Column: 5, Byte: 45},
+						},
+					},
+				},
+			},
+		},
 		// either min or max is in schema but no blocks specified
 		{
 			"too few blocks",
@@ -1046,20 +1201,2027 @@ func TestValidate_schema_SingleFile(t *testing.T) {
 	}
 }
 
-func sortDiagnostics(diags hcl.Diagnostics) {
-	sort.Slice(diags, func(i, j int) bool {
-		return diags[i].Subject.Start.Byte < diags[j].Subject.Start.Byte ||
-			diags[i].Summary < diags[j].Summary
-	})
+func TestValidate_referenceRequiredPath(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{
+					OfScopeId: lang.ScopeId("module"),
+					RequiredPath: lang.Address{
+						lang.AttrStep{Name: "some_output"},
+					},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "module"},
+				lang.AttrStep{Name: "foo"},
+			},
+			ScopeId: lang.ScopeId("module"),
+			NestedTargets: reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "module"},
+						lang.AttrStep{Name: "foo"},
+						lang.AttrStep{Name: "some_output"},
+					},
+					ScopeId: lang.ScopeId("module"),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"reference with required path",
+			`attr = module.foo.some_output`,
+			nil,
+		},
+		{
+			"unresolvable reference",
+			`attr = module.bar`,
+			nil,
+		},
+		{
+			"reference without required path",
+			`attr = module.foo`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Missing required reference path",
+					Detail:   `Reference "module.foo" is expected to end with ".some_output"`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 18, Byte: 17},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+				Validators: []validator.Validator{
+					validator.ReferenceRequiredPath{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
 }
 
-var testValidators = []validator.Validator{
-	validator.BlockLabelsLength{},
-	validator.DeprecatedAttribute{},
-	validator.DeprecatedBlock{},
-	validator.MaxBlocks{},
-	validator.MinBlocks{},
-	validator.MissingRequiredAttribute{},
-	validator.UnexpectedAttribute{},
-	validator.UnexpectedBlock{},
+func TestValidate_referenceTypeMismatch(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{OfType: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "str"},
+			},
+			Type: cty.String,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "list"},
+			},
+			Type: cty.List(cty.Bool),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "any"},
+			},
+			Type: cty.DynamicPseudoType,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "obj"},
+			},
+			Type: cty.Object(map[string]cty.Type{
+				"num": cty.Number,
+			}),
+			NestedTargets: reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "obj"},
+						lang.AttrStep{Name: "num"},
+					},
+					Type: cty.Number,
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"matching type",
+			`attr = var.str`,
+			nil,
+		},
+		{
+			"convertible nested type",
+			`attr = var.obj.num`,
+			nil,
+		},
+		{
+			"dynamic type",
+			`attr = var.any`,
+			nil,
+		},
+		{
+			"unresolvable reference",
+			`attr = var.unknown`,
+			nil,
+		},
+		{
+			"mismatching type",
+			`attr = var.list`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid reference type",
+					Detail:   `Reference "var.list" is of type list of bool, expected string`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+				Validators: []validator.Validator{
+					validator.ReferenceTypeMismatch{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_undeclaredMapKey(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"region": {
+				Constraint: schema.MapKeyOf{
+					Address: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "regions"},
+					},
+				},
+				IsOptional: true,
+			},
+			"zone": {
+				Constraint: schema.MapKeyOf{
+					Address: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "zones"},
+					},
+				},
+				IsOptional: true,
+			},
+			"setting": {
+				Constraint: schema.MapKeyOf{
+					Address: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "settings"},
+					},
+				},
+				IsOptional: true,
+			},
+		},
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "regions"},
+			},
+			Type: cty.Map(cty.String),
+			NestedTargets: reference.Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "regions"},
+						lang.IndexStep{Key: cty.StringVal("us")},
+					},
+					Type: cty.String,
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "regions"},
+						lang.IndexStep{Key: cty.StringVal("eu")},
+					},
+					Type: cty.String,
+				},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "zones"},
+			},
+			Type: cty.Map(cty.String),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "settings"},
+			},
+			Type: cty.EmptyObject,
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"declared key",
+			`region = "eu"`,
+			nil,
+		},
+		{
+			"undeclared key",
+			`region = "ap"`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Undeclared map key",
+					Detail:   `"ap" is not a key of var.regions, expected one of "eu", "us"`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 9},
+						End:      hcl.Pos{Line: 1, Column: 14, Byte: 13},
+					},
+				},
+			},
+		},
+		{
+			"non-static value",
+			`region = var.region`,
+			nil,
+		},
+		{
+			"map with unknown keys",
+			`zone = "a"`,
+			nil,
+		},
+		{
+			"object without attributes",
+			`setting = "a"`,
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+				Validators: []validator.Validator{
+					validator.UndeclaredMapKey{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_nullValue(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"nullable": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+				IsNullable: true,
+			},
+			"non_nullable": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"null on nullable attribute",
+			`nullable = null`,
+			nil,
+		},
+		{
+			"value on non-nullable attribute",
+			`non_nullable = "foo"`,
+			nil,
+		},
+		{
+			"null on non-nullable attribute",
+			`non_nullable = null`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid null value",
+					Detail:   `Attribute "non_nullable" cannot be null`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+						End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.NonNullableAttribute{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_duplicateDiagnostics(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"non_nullable": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte(`non_nullable = null`), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		Validators: []validator.Validator{
+			validator.NonNullableAttribute{},
+			testNullValueValidator{},
+		},
+	})
+
+	ctx := context.Background()
+	diags, err := d.ValidateFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDiagnostics := hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid null value",
+			Detail:   `Attribute "non_nullable" cannot be null`,
+			Subject: &hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+				End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDiagnostics, diags); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+// testNullValueValidator flags any null attribute value
+// in the same way as validator.NonNullableAttribute,
+// but with a different detail
+type testNullValueValidator struct{}
+
+func (v testNullValueValidator) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok || !isNullExpression(attr.Expr) {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid null value",
+		Detail:   "Null is not allowed here",
+		Subject:  attr.Expr.Range().Ptr(),
+	})
+	return ctx, diags
+}
+
+func TestValidate_duplicateDiagnosticsAcrossNodes(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"non_nullable": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte(`non_nullable = null`), "test.tf", hcl.InitialPos)
+	d := testPathDecoder(t, &PathContext{
+		Schema: bodySchema,
+		Files: map[string]*hcl.File{
+			"test.tf": f,
+		},
+		Validators: []validator.Validator{
+			testBodyNullValueValidator{},
+			validator.NonNullableAttribute{},
+		},
+	})
+
+	expectedDiagnostics := hcl.Diagnostics{
+		{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid null value",
+			Detail:   `Attribute "non_nullable" cannot be null`,
+			Subject: &hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+				End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	diags, err := d.ValidateFile(ctx, "test.tf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expectedDiagnostics, diags); diff != "" {
+		t.Fatalf("unexpected file diagnostics: %s", diff)
+	}
+
+	diagsMap, err := d.Validate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(expectedDiagnostics, diagsMap["test.tf"]); diff != "" {
+		t.Fatalf("unexpected path diagnostics: %s", diff)
+	}
+}
+
+// testBodyNullValueValidator flags null attribute values
+// when visiting the body, rather than the attribute itself
+type testBodyNullValueValidator struct{}
+
+func (v testBodyNullValueValidator) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	body, ok := node.(*hclsyntax.Body)
+	if !ok {
+		return ctx, diags
+	}
+
+	for _, attr := range body.Attributes {
+		if !isNullExpression(attr.Expr) {
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid null value",
+			Detail:   "Null is not allowed in this body",
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+	}
+	return ctx, diags
+}
+
+func TestValidate_emptyValue(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"description": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			"name": {
+				Constraint:    schema.AnyExpression{OfType: cty.String},
+				IsOptional:    true,
+				DisallowEmpty: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"empty string on attribute allowing it",
+			`description = ""`,
+			nil,
+		},
+		{
+			"non-empty string",
+			`name = "foo"`,
+			nil,
+		},
+		{
+			"interpolated string",
+			`name = "${var.foo}"`,
+			nil,
+		},
+		{
+			"reference",
+			`name = var.foo`,
+			nil,
+		},
+		{
+			"empty string",
+			`name = ""`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid empty value",
+					Detail:   `Attribute "name" cannot be empty`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 10, Byte: 9},
+					},
+				},
+			},
+		},
+		{
+			"empty heredoc",
+			`name = <<EOT
+EOT
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid empty value",
+					Detail:   `Attribute "name" cannot be empty`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 2, Column: 4, Byte: 16},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.NonEmptyAttribute{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_undefinedReference(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{OfType: cty.String},
+				IsOptional: true,
+			},
+			"optional_ref": {
+				Constraint: schema.Reference{
+					OfType:           cty.String,
+					IsTargetOptional: true,
+				},
+				IsOptional: true,
+			},
+		},
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "str"},
+			},
+			Type: cty.String,
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "any"},
+			},
+			Type: cty.DynamicPseudoType,
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"resolved reference",
+			`attr = var.str`,
+			nil,
+		},
+		{
+			"loose match of dynamic target",
+			`attr = var.any.foo`,
+			nil,
+		},
+		{
+			"optional target",
+			`optional_ref = var.unknown`,
+			nil,
+		},
+		{
+			"undefined reference",
+			`attr = var.unknown`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Undefined reference",
+					Detail:   `No declaration found for "var.unknown"`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			pathCtx := &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+				Validators: []validator.Validator{
+					validator.UndefinedReference{},
+				},
+			}
+			d := testPathDecoder(t, pathCtx)
+
+			origins, err := d.CollectReferenceOrigins()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceOrigins = origins
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_undefinedRequiredReference(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"required_ref": {
+				Constraint: schema.Reference{OfType: cty.String},
+				IsRequired: true,
+			},
+			"optional_ref": {
+				Constraint: schema.Reference{OfType: cty.String},
+				IsOptional: true,
+			},
+		},
+	}
+	targets := reference.Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "str"},
+			},
+			Type: cty.String,
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"resolvable required reference",
+			`required_ref = var.str`,
+			nil,
+		},
+		{
+			"unresolvable required reference",
+			`required_ref = var.unknown`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Undefined required reference",
+					Detail:   `Required attribute "required_ref" references "var.unknown", which is not declared`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+						End:      hcl.Pos{Line: 1, Column: 27, Byte: 26},
+					},
+				},
+			},
+		},
+		{
+			"unresolvable optional reference",
+			`required_ref = var.str
+optional_ref = var.unknown`,
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			pathCtx := &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				ReferenceTargets: targets,
+				Validators: []validator.Validator{
+					validator.UndefinedRequiredReference{},
+				},
+			}
+			d := testPathDecoder(t, pathCtx)
+
+			origins, err := d.CollectReferenceOrigins()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pathCtx.ReferenceOrigins = origins
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_functionCall(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.AnyExpression{OfType: cty.DynamicPseudoType},
+				IsOptional: true,
+			},
+		},
+	}
+	functions := map[string]schema.FunctionSignature{
+		"upper": {
+			Params: []function.Parameter{
+				{
+					Name: "str",
+					Type: cty.String,
+				},
+			},
+			ReturnType: cty.String,
+		},
+		"join": {
+			Params: []function.Parameter{
+				{
+					Name: "separator",
+					Type: cty.String,
+				},
+			},
+			VarParam: &function.Parameter{
+				Name: "lists",
+				Type: cty.List(cty.String),
+			},
+			ReturnType: cty.String,
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"valid call",
+			`attr = upper("foo")`,
+			nil,
+		},
+		{
+			"convertible argument",
+			`attr = upper(42)`,
+			nil,
+		},
+		{
+			"reference argument",
+			`attr = upper(var.foo)`,
+			nil,
+		},
+		{
+			"unknown function",
+			`attr = lower(["foo"], 42)`,
+			nil,
+		},
+		{
+			"variadic arguments",
+			`attr = join(",", ["a"], ["b", "c"])`,
+			nil,
+		},
+		{
+			"mismatching argument type",
+			`attr = upper(["foo"])`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid function argument",
+					Detail:   `Invalid value for "str" parameter: string required.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						End:      hcl.Pos{Line: 1, Column: 21, Byte: 20},
+					},
+				},
+			},
+		},
+		{
+			"mismatching variadic argument type",
+			`attr = join(",", ["a"], "b")`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid function argument",
+					Detail:   `Invalid value for "lists" parameter: list of string required.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+						End:      hcl.Pos{Line: 1, Column: 28, Byte: 27},
+					},
+				},
+			},
+		},
+		{
+			"not enough arguments",
+			`attr = upper()`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Not enough function arguments",
+					Detail:   `Function "upper" expects 1 argument(s), 0 given`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+					},
+				},
+			},
+		},
+		{
+			"too many arguments",
+			`attr = upper("foo", "bar")`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Too many function arguments",
+					Detail:   `Function "upper" expects 1 argument(s), 2 given`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 21, Byte: 20},
+						End:      hcl.Pos{Line: 1, Column: 26, Byte: 25},
+					},
+				},
+			},
+		},
+		{
+			"nested call",
+			`attr = upper(upper([]))`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid function argument",
+					Detail:   `Invalid value for "str" parameter: string required.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 20, Byte: 19},
+						End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Functions: functions,
+				Validators: []validator.Validator{
+					validator.FunctionCall{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_unknownFunction(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.AnyExpression{OfType: cty.DynamicPseudoType},
+				IsOptional: true,
+			},
+		},
+	}
+	functions := map[string]schema.FunctionSignature{
+		"upper": {
+			Params: []function.Parameter{
+				{
+					Name: "str",
+					Type: cty.String,
+				},
+			},
+			ReturnType: cty.String,
+		},
+		"jsonencode": {
+			Params: []function.Parameter{
+				{
+					Name: "val",
+					Type: cty.DynamicPseudoType,
+				},
+			},
+			ReturnType: cty.String,
+		},
+	}
+
+	testCases := []struct {
+		testName               string
+		cfg                    string
+		functions              map[string]schema.FunctionSignature
+		reportUnknownFunctions bool
+		expectedDiagnostics    hcl.Diagnostics
+	}{
+		{
+			"known function",
+			`attr = upper("foo")`,
+			functions,
+			true,
+			nil,
+		},
+		{
+			"unknown function with option disabled",
+			`attr = lower("foo")`,
+			functions,
+			false,
+			hcl.Diagnostics{},
+		},
+		{
+			"unknown function without known functions",
+			`attr = lower("foo")`,
+			nil,
+			true,
+			nil,
+		},
+		{
+			"unknown function with suggestion",
+			`attr = jsonencod({})`,
+			functions,
+			true,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown function",
+					Detail:   `There is no function named "jsonencod". Did you mean "jsonencode"?`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+				},
+			},
+		},
+		{
+			"nested unknown function without suggestion",
+			`attr = upper(foo())`,
+			functions,
+			true,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unknown function",
+					Detail:   `There is no function named "foo".`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						End:      hcl.Pos{Line: 1, Column: 17, Byte: 16},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			dirPath := t.TempDir()
+			d := NewDecoder(&testPathReader{
+				paths: map[string]*PathContext{
+					dirPath: {
+						Schema: bodySchema,
+						Files: map[string]*hcl.File{
+							"test.tf": f,
+						},
+						Functions: tc.functions,
+					},
+				},
+			})
+			decoderCtx := NewDecoderContext()
+			decoderCtx.ReportUnknownFunctions = tc.reportUnknownFunctions
+			d.SetContext(decoderCtx)
+
+			pathDecoder, err := d.Path(lang.Path{Path: dirPath})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			diags, err := pathDecoder.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_invalidStringEscape(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.AnyExpression{OfType: cty.DynamicPseudoType},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"valid escapes",
+			`attr = "foo\n\r\t\"\\é\U0001F600"`,
+			nil,
+		},
+		{
+			"escaped backslash",
+			`attr = "\\q"`,
+			nil,
+		},
+		{
+			"invalid escape",
+			`attr = "\q"`,
+			hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid escape sequence",
+					Detail:   `The symbol "q" is not a valid escape sequence selector.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						End:      hcl.Pos{Line: 1, Column: 11, Byte: 10},
+					},
+				},
+			},
+		},
+		{
+			"invalid escape after interpolation",
+			`attr = "${var.foo}\q"`,
+			hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid escape sequence",
+					Detail:   `The symbol "q" is not a valid escape sequence selector.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						End:      hcl.Pos{Line: 1, Column: 21, Byte: 20},
+					},
+				},
+			},
+		},
+		{
+			"invalid escape after multi-byte character",
+			`attr = "é\q"`,
+			hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid escape sequence",
+					Detail:   `The symbol "q" is not a valid escape sequence selector.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 10, Byte: 10},
+						End:      hcl.Pos{Line: 1, Column: 12, Byte: 12},
+					},
+				},
+			},
+		},
+		{
+			"incomplete unicode escape",
+			`attr = "\u12"`,
+			hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid escape sequence",
+					Detail:   `The \u escape sequence must be followed by four hexadecimal digits.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 9, Byte: 8},
+						End:      hcl.Pos{Line: 1, Column: 13, Byte: 12},
+					},
+				},
+			},
+		},
+		{
+			"invalid escape in list",
+			`attr = ["foo", "\q"]`,
+			hcl.Diagnostics{
+				&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid escape sequence",
+					Detail:   `The symbol "q" is not a valid escape sequence selector.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+					},
+				},
+			},
+		},
+		{
+			"heredoc",
+			`attr = <<EOT
+foo \q
+EOT
+`,
+			nil,
+		},
+		{
+			"unknown attribute",
+			`foo = "\q"`,
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.InvalidStringEscape{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_referenceBlockType(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"attr": {
+				Constraint: schema.Reference{
+					OfType:      cty.String,
+					OfBlockType: "aws_instance",
+				},
+				IsOptional: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"reference to required block type",
+			`attr = aws_instance.foo.id`,
+			nil,
+		},
+		{
+			"non-reference expression",
+			`attr = "foo"`,
+			nil,
+		},
+		{
+			"reference to other block type",
+			`attr = aws_vpc.foo.id`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid reference block type",
+					Detail:   `Reference "aws_vpc.foo.id" is expected to refer to "aws_instance"`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+						End:      hcl.Pos{Line: 1, Column: 22, Byte: 21},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.ReferenceBlockType{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_invalidLabelIdentifier(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Labels: []*schema.LabelSchema{
+					{Name: "type"},
+					{Name: "name", IsIdentifier: true},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"valid identifier",
+			`resource "aws_instance" "app-1" {}`,
+			nil,
+		},
+		{
+			"leading digit",
+			`resource "aws_instance" "123abc" {}`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid label identifier",
+					Detail: `Label "123abc" of "resource" is not a valid identifier. An identifier must start with ` +
+						`a letter or underscore and may contain only letters, digits, underscores, and dashes.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+						End:      hcl.Pos{Line: 1, Column: 33, Byte: 32},
+					},
+				},
+			},
+		},
+		{
+			"whitespace",
+			`resource "aws_instance" "my app" {}`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid label identifier",
+					Detail: `Label "my app" of "resource" is not a valid identifier. An identifier must start with ` +
+						`a letter or underscore and may contain only letters, digits, underscores, and dashes.`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 25, Byte: 24},
+						End:      hcl.Pos{Line: 1, Column: 33, Byte: 32},
+					},
+				},
+			},
+		},
+		{
+			"non-identifier label",
+			`resource "123 type" "app" {}`,
+			nil,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.InvalidLabelIdentifier{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_collectionElementType(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"names": {
+				Constraint: schema.List{Elem: schema.LiteralType{Type: cty.String}},
+				IsOptional: true,
+			},
+			"ports": {
+				Constraint: schema.Set{Elem: schema.LiteralType{Type: cty.Number}},
+				IsOptional: true,
+			},
+			"pair": {
+				Constraint: schema.Tuple{Elems: []schema.Constraint{
+					schema.LiteralType{Type: cty.String},
+					schema.LiteralType{Type: cty.Bool},
+				}},
+				IsOptional: true,
+			},
+			"refs": {
+				Constraint: schema.List{Elem: schema.Reference{OfType: cty.String}},
+				IsOptional: true,
+			},
+			"zones": {
+				Constraint: schema.ListOrSingle{Elem: schema.LiteralType{Type: cty.String}},
+				IsOptional: true,
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"resource": {
+				Body: &schema.BodySchema{
+					Extensions: &schema.BodyExtensions{
+						DependsOn: true,
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"matching list elements",
+			`names = ["foo", "bar"]`,
+			nil,
+		},
+		{
+			"references and function calls in list",
+			`names = [var.foo, upper("foo"), "${var.bar}"]`,
+			nil,
+		},
+		{
+			"mismatching list element",
+			`names = ["foo", 42]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "names" is of type number, expected string`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+					},
+				},
+			},
+		},
+		{
+			"mismatching set element",
+			`ports = [80, "http"]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "ports" is of type string, expected number`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 14, Byte: 13},
+						End:      hcl.Pos{Line: 1, Column: 20, Byte: 19},
+					},
+				},
+			},
+		},
+		{
+			"duplicate set element",
+			`ports = [80, 443, 80]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagWarning,
+					Summary:  "Duplicate set element",
+					Detail:   `Element 2 of "ports" duplicates element 0`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 19, Byte: 18},
+						End:      hcl.Pos{Line: 1, Column: 21, Byte: 20},
+					},
+				},
+			},
+		},
+		{
+			"matching tuple elements",
+			`pair = ["foo", true]`,
+			nil,
+		},
+		{
+			"mismatching tuple element",
+			`pair = ["foo", "bar"]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "pair" is of type string, expected bool`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 16, Byte: 15},
+						End:      hcl.Pos{Line: 1, Column: 21, Byte: 20},
+					},
+				},
+			},
+		},
+		{
+			"references in reference list",
+			`refs = [var.foo, local.bar]`,
+			nil,
+		},
+		{
+			"literal in reference list",
+			`refs = [var.foo, "bar"]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "refs" is a literal value, expected a reference`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 18, Byte: 17},
+						End:      hcl.Pos{Line: 1, Column: 23, Byte: 22},
+					},
+				},
+			},
+		},
+		{
+			"literal in depends_on",
+			`resource {
+  depends_on = [aws_instance.foo, "module.bar"]
+}`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "depends_on" is a literal value, expected a reference`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 35, Byte: 45},
+						End:      hcl.Pos{Line: 2, Column: 47, Byte: 57},
+					},
+				},
+			},
+		},
+		{
+			"single value of list or single",
+			`zones = "a"`,
+			nil,
+		},
+		{
+			"mismatching element of list or single",
+			`zones = ["a", 1]`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "zones" is of type number, expected string`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 15, Byte: 14},
+						End:      hcl.Pos{Line: 1, Column: 16, Byte: 15},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.CollectionElementType{ReportSetDuplicates: true},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_attributeRequiredWithConflictsWith(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"username": {
+				Constraint:   schema.LiteralType{Type: cty.String},
+				IsOptional:   true,
+				RequiredWith: []string{"password"},
+			},
+			"password": {
+				Constraint: schema.LiteralType{Type: cty.String},
+				IsOptional: true,
+			},
+			"token": {
+				Constraint:    schema.LiteralType{Type: cty.String},
+				IsOptional:    true,
+				ConflictsWith: []string{"password"},
+			},
+			"a": {
+				Constraint:   schema.LiteralType{Type: cty.Number},
+				IsOptional:   true,
+				RequiredWith: []string{"b"},
+			},
+			"b": {
+				Constraint:   schema.LiteralType{Type: cty.Number},
+				IsOptional:   true,
+				RequiredWith: []string{"c"},
+			},
+			"c": {
+				Constraint:   schema.LiteralType{Type: cty.Number},
+				IsOptional:   true,
+				RequiredWith: []string{"a"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		testName            string
+		cfg                 string
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"required companion specified",
+			`username = "u"
+password = "p"
+`,
+			nil,
+		},
+		{
+			"required companion missing",
+			`username = "u"
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  `Missing attribute "password"`,
+					Detail:   `Attribute "username" requires "password" to be specified as well`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 15, Byte: 14},
+					},
+				},
+			},
+		},
+		{
+			"companion without dependent attribute",
+			`password = "p"
+`,
+			nil,
+		},
+		{
+			"conflicting attributes",
+			`token = "t"
+password = "p"
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Conflicting attributes",
+					Detail:   `Attribute "token" cannot be specified together with "password"`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+					},
+				},
+			},
+		},
+		{
+			"conflicting attribute alone",
+			`token = "t"
+`,
+			nil,
+		},
+		{
+			"complete chain",
+			`a = 1
+b = 1
+c = 1
+`,
+			nil,
+		},
+		{
+			"incomplete chain",
+			`a = 1
+b = 1
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  `Missing attribute "c"`,
+					Detail:   `Attribute "b" requires "c" to be specified as well`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 6},
+						End:      hcl.Pos{Line: 2, Column: 6, Byte: 11},
+					},
+				},
+			},
+		},
+		{
+			"chain with single attribute",
+			`c = 1
+`,
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  `Missing attribute "a"`,
+					Detail:   `Attribute "c" requires "a" to be specified as well`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+						End:      hcl.Pos{Line: 1, Column: 6, Byte: 5},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			f, _ := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			d := testPathDecoder(t, &PathContext{
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.AttributeRequiredWith{},
+					validator.AttributeConflictsWith{},
+				},
+			})
+
+			ctx := context.Background()
+			diags, err := d.ValidateFile(ctx, "test.tf")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
+}
+
+func sortDiagnostics(diags hcl.Diagnostics) {
+	sort.Slice(diags, func(i, j int) bool {
+		return diags[i].Subject.Start.Byte < diags[j].Subject.Start.Byte ||
+			diags[i].Summary < diags[j].Summary
+	})
+}
+
+var testValidators = []validator.Validator{
+	validator.BlockLabelsLength{},
+	validator.DeprecatedAttribute{},
+	validator.DeprecatedBlock{},
+	validator.MaxBlocks{},
+	validator.MinBlocks{},
+	validator.MissingRequiredAttribute{},
+	validator.UnexpectedAttribute{},
+	validator.UnexpectedBlock{},
+}
+
+func TestValidate_attributeAtPos(t *testing.T) {
+	bodySchema := &schema.BodySchema{
+		Attributes: map[string]*schema.AttributeSchema{
+			"names": {
+				Constraint: schema.List{Elem: schema.LiteralType{Type: cty.String}},
+				IsOptional: true,
+			},
+			"ports": {
+				Constraint: schema.Set{Elem: schema.LiteralType{Type: cty.Number}},
+				IsOptional: true,
+			},
+		},
+		Blocks: map[string]*schema.BlockSchema{
+			"nested": {
+				Body: &schema.BodySchema{
+					Attributes: map[string]*schema.AttributeSchema{
+						"names": {
+							Constraint: schema.List{Elem: schema.LiteralType{Type: cty.String}},
+							IsOptional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+	cfg := `names = ["foo", 42]
+ports = [80, 443]
+
+nested {
+  names = ["bar", true]
+  foo   = "bar"
+}
+
+unknown {
+  foo = "bar"
+}
+`
+
+	testCases := []struct {
+		testName            string
+		pos                 hcl.Pos
+		expectedDiagnostics hcl.Diagnostics
+	}{
+		{
+			"invalid attribute",
+			hcl.Pos{Line: 1, Column: 3, Byte: 2},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "names" is of type number, expected string`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 1, Column: 17, Byte: 16},
+						End:      hcl.Pos{Line: 1, Column: 19, Byte: 18},
+					},
+				},
+			},
+		},
+		{
+			"valid attribute",
+			hcl.Pos{Line: 2, Column: 3, Byte: 22},
+			hcl.Diagnostics{},
+		},
+		{
+			"invalid attribute in block",
+			hcl.Pos{Line: 5, Column: 5, Byte: 52},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid element type",
+					Detail:   `Element 1 of "names" is of type bool, expected string`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 5, Column: 19, Byte: 66},
+						End:      hcl.Pos{Line: 5, Column: 23, Byte: 70},
+					},
+				},
+			},
+		},
+		{
+			"unexpected attribute in block",
+			hcl.Pos{Line: 6, Column: 4, Byte: 75},
+			hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "Unexpected attribute",
+					Detail:   `An attribute named "foo" is not expected here`,
+					Subject: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3, Byte: 74},
+						End:      hcl.Pos{Line: 6, Column: 16, Byte: 87},
+					},
+				},
+			},
+		},
+		{
+			"attribute in unknown block",
+			hcl.Pos{Line: 10, Column: 4, Byte: 104},
+			hcl.Diagnostics{},
+		},
+		{
+			"outside of attribute",
+			hcl.Pos{Line: 4, Column: 3, Byte: 41},
+			hcl.Diagnostics{},
+		},
+	}
+
+	f, _ := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	dirPath := t.TempDir()
+	d := NewDecoder(&testPathReader{
+		paths: map[string]*PathContext{
+			dirPath: {
+				Schema: bodySchema,
+				Files: map[string]*hcl.File{
+					"test.tf": f,
+				},
+				Validators: []validator.Validator{
+					validator.CollectionElementType{},
+					validator.UnexpectedAttribute{},
+				},
+			},
+		},
+	})
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d-%s", i, tc.testName), func(t *testing.T) {
+			ctx := context.Background()
+			diags, err := d.ValidateAttributeAtPos(ctx, lang.Path{Path: dirPath}, "test.tf", tc.pos)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expectedDiagnostics, diags); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+		})
+	}
 }
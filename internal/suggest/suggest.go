@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package suggest
+
+import (
+	"sort"
+)
+
+// ClosestName returns the name from the given names closest
+// to the given name, as long as it is reasonably close.
+func ClosestName(name string, names []string) (string, bool) {
+	sortedNames := make([]string, len(names))
+	copy(sortedNames, names)
+	// ensure deterministic suggestion in case of equal distance
+	sort.Strings(sortedNames)
+
+	closestName := ""
+	closestDistance := -1
+	for _, n := range sortedNames {
+		distance := LevenshteinDistance(name, n)
+		if closestDistance < 0 || distance < closestDistance {
+			closestName = n
+			closestDistance = distance
+		}
+	}
+
+	// avoid suggesting names which are entirely different
+	maxDistance := len(name) / 2
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	if closestDistance < 0 || closestDistance > maxDistance {
+		return "", false
+	}
+
+	return closestName, true
+}
+
+// LevenshteinDistance returns the number of single-character
+// edits required to change a into b.
+func LevenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
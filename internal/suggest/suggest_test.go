@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package suggest
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClosestName(t *testing.T) {
+	testCases := []struct {
+		name         string
+		names        []string
+		expectedName string
+		expectedOk   bool
+	}{
+		{
+			"foo",
+			[]string{},
+			"",
+			false,
+		},
+		{
+			"lenght",
+			[]string{"upper", "length", "lower"},
+			"length",
+			true,
+		},
+		{
+			"bar",
+			[]string{"foo", "baz", "bat"},
+			"bat",
+			true,
+		},
+		{
+			"toolongname",
+			[]string{"foo", "bar"},
+			"",
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			name, ok := ClosestName(tc.name, tc.names)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if name != tc.expectedName {
+				t.Fatalf("expected name: %q, given: %q", tc.expectedName, name)
+			}
+		})
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	testCases := []struct {
+		a, b             string
+		expectedDistance int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"héllo", "hello", 1},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s-%s", i, tc.a, tc.b), func(t *testing.T) {
+			distance := LevenshteinDistance(tc.a, tc.b)
+			if distance != tc.expectedDistance {
+				t.Fatalf("expected distance: %d, given: %d", tc.expectedDistance, distance)
+			}
+		})
+	}
+}
@@ -31,6 +31,32 @@ func (a Address) Equals(addr Address) bool {
 	return true
 }
 
+// HasSuffix returns true if the address ends with the given steps.
+// Empty suffix is considered a suffix of any address.
+func (a Address) HasSuffix(suffix Address) bool {
+	if len(suffix) > len(a) {
+		return false
+	}
+	offset := len(a) - len(suffix)
+	for i, step := range suffix {
+		if step.String() != a[offset+i].String() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsRootedIn returns true if the address starts
+// with a root step of the given name.
+func (a Address) IsRootedIn(name string) bool {
+	if len(a) == 0 {
+		return false
+	}
+	rootStep, ok := a[0].(RootStep)
+	return ok && rootStep.Name == name
+}
+
 func (a Address) FirstSteps(steps uint) Address {
 	return a[0:steps]
 }
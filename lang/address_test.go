@@ -37,6 +37,47 @@ func TestAddress_Equals_basic(t *testing.T) {
 	}
 }
 
+func TestAddress_HasSuffix(t *testing.T) {
+	addr := Address{
+		RootStep{Name: "module"},
+		AttrStep{Name: "foo"},
+		AttrStep{Name: "bar"},
+	}
+
+	if !addr.HasSuffix(Address{AttrStep{Name: "bar"}}) {
+		t.Fatalf("expected %q to end with .bar", addr)
+	}
+	if !addr.HasSuffix(Address{AttrStep{Name: "foo"}, AttrStep{Name: "bar"}}) {
+		t.Fatalf("expected %q to end with .foo.bar", addr)
+	}
+	if !addr.HasSuffix(Address{}) {
+		t.Fatalf("expected %q to end with empty address", addr)
+	}
+	if addr.HasSuffix(Address{AttrStep{Name: "foo"}}) {
+		t.Fatalf("expected %q not to end with .foo", addr)
+	}
+	if addr.HasSuffix(append(Address{RootStep{Name: "x"}}, addr...)) {
+		t.Fatalf("expected %q not to end with longer address", addr)
+	}
+}
+
+func TestAddress_IsRootedIn(t *testing.T) {
+	addr := Address{
+		RootStep{Name: "aws_instance"},
+		AttrStep{Name: "foo"},
+	}
+
+	if !addr.IsRootedIn("aws_instance") {
+		t.Fatalf("expected %q to be rooted in aws_instance", addr)
+	}
+	if addr.IsRootedIn("foo") {
+		t.Fatalf("expected %q not to be rooted in foo", addr)
+	}
+	if (Address{}).IsRootedIn("aws_instance") {
+		t.Fatal("expected empty address not to be rooted in aws_instance")
+	}
+}
+
 func TestAddress_Equals_numericIndexStep(t *testing.T) {
 	originalAddr := Address{
 		RootStep{Name: "aws_alb"},
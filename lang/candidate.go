@@ -52,8 +52,25 @@ type Candidate struct {
 	ResolveHook *ResolveHook
 
 	// SortText is an optional string that will be used when comparing this
-	// candidate with other candidates
+	// candidate with other candidates, independently of the label
+	// (e.g. to rank required attributes first), such as LSP's sortText.
+	// Candidates without SortText are compared by their label.
 	SortText string
+
+	// FilterText is an optional string that will be used when filtering
+	// candidates against the typed prefix, such as LSP's filterText.
+	// It is useful where the label is decorated (e.g. list(…))
+	// but should match the bare identifier. Empty means match on label.
+	FilterText string
+
+	// CommitCharacters is an optional set of characters which,
+	// when typed while the candidate is selected, accept the candidate
+	// and then insert the typed character.
+	//
+	// The decoder sets the following defaults per kind:
+	//  - ReferenceCandidateKind commits on "." (to continue with next step)
+	//  - BlockCandidateKind commits on " " (to continue with labels or body)
+	CommitCharacters []string
 }
 
 // TextEdit represents a change (edit) of an HCL config file
@@ -84,8 +101,16 @@ func (ca Candidates) Len() int {
 }
 
 func (ca Candidates) Less(i, j int) bool {
-	// TODO: sort by more metadata, such as IsRequired or IsDeprecated
-	return ca.List[i].Label < ca.List[j].Label
+	return ca.List[i].sortKey() < ca.List[j].sortKey()
+}
+
+// sortKey returns SortText of the candidate if set,
+// falling back to the label otherwise.
+func (c Candidate) sortKey() string {
+	if c.SortText != "" {
+		return c.SortText
+	}
+	return c.Label
 }
 
 func (ca Candidates) Swap(i, j int) {
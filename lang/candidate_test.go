@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCandidates_Sort(t *testing.T) {
+	candidates := CompleteCandidates([]Candidate{
+		{Label: "foo"},
+		{Label: "zoo", SortText: "0zoo"},
+		{Label: "bar"},
+		{Label: "baz", SortText: "2baz"},
+	})
+
+	sort.Sort(candidates)
+
+	expectedLabels := []string{"zoo", "baz", "bar", "foo"}
+	labels := make([]string, len(candidates.List))
+	for i, c := range candidates.List {
+		labels[i] = c.Label
+	}
+	if diff := cmp.Diff(expectedLabels, labels); diff != "" {
+		t.Fatalf("unexpected order: %s", diff)
+	}
+}
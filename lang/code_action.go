@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+// CodeActionKind represents the kind of a code action,
+// as understood by the language server protocol.
+type CodeActionKind string
+
+const (
+	// QuickFixCodeActionKind represents a code action
+	// which fixes a reported problem, such as use of
+	// a deprecated attribute.
+	QuickFixCodeActionKind CodeActionKind = "quickfix"
+
+	// RefactorRewriteCodeActionKind represents a code action
+	// which rewrites configuration without changing its meaning.
+	RefactorRewriteCodeActionKind CodeActionKind = "refactor.rewrite"
+
+	// RefactorCodeActionKind represents a code action
+	// which rewrites configuration in a way which may change
+	// its meaning (e.g. values of heredoc strings) and so
+	// should only ever be applied explicitly by the user.
+	RefactorCodeActionKind CodeActionKind = "refactor"
+)
+
+// CodeAction represents a change to the configuration
+// which the user can choose to apply, e.g. to normalize formatting
+// or to fix a reported problem.
+type CodeAction struct {
+	Title string
+	Kind  CodeActionKind
+	Edits []TextEdit
+}
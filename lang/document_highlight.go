@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+const (
+	NilHighlightKind DocumentHighlightKind = iota
+	// ReadHighlightKind represents read-access of a symbol,
+	// such as a reference origin
+	ReadHighlightKind
+	// WriteHighlightKind represents write-access of a symbol,
+	// such as the declaration of a reference target
+	WriteHighlightKind
+)
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=DocumentHighlightKind -output=document_highlight_kind_string.go
+type DocumentHighlightKind uint
+
+// DocumentHighlight represents an occurrence of a symbol
+// within a single document (file)
+type DocumentHighlight struct {
+	Range hcl.Range
+	Kind  DocumentHighlightKind
+}
@@ -7,7 +7,30 @@ import (
 	"github.com/hashicorp/hcl/v2"
 )
 
+const (
+	NilHoverKind HoverKind = iota
+	// AttributeHoverKind represents hover over an attribute name
+	AttributeHoverKind
+	// BlockHoverKind represents hover over a block type or label
+	BlockHoverKind
+	// ReferenceHoverKind represents hover over a reference
+	// (traversal), such as var.foo
+	ReferenceHoverKind
+	// FunctionHoverKind represents hover over a function call
+	FunctionHoverKind
+	// ValueHoverKind represents hover over any other expression,
+	// such as a literal value, keyword or type declaration
+	ValueHoverKind
+)
+
+//go:generate go run golang.org/x/tools/cmd/stringer -type=HoverKind -output=hover_kind_string.go
+type HoverKind uint
+
 type HoverData struct {
 	Content MarkupContent
 	Range   hcl.Range
+
+	// Kind describes what is being hovered over, which allows
+	// clients to style the hover or apply kind-specific UI.
+	Kind HoverKind
 }
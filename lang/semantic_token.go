@@ -34,6 +34,7 @@ const (
 	TokenTypeComplex   SemanticTokenType = "hcl-typeComplex"
 	TokenTypePrimitive SemanticTokenType = "hcl-typePrimitive"
 	TokenFunctionName  SemanticTokenType = "hcl-functionName"
+	TokenNull          SemanticTokenType = "hcl-null"
 )
 
 var SupportedSemanticTokenTypes = SemanticTokenTypes{
@@ -50,6 +51,7 @@ var SupportedSemanticTokenTypes = SemanticTokenTypes{
 	TokenTypeComplex,
 	TokenTypePrimitive,
 	TokenFunctionName,
+	TokenNull,
 }
 
 type SemanticTokenModifier string
@@ -68,4 +70,14 @@ func (stm SemanticTokenModifiers) Copy() SemanticTokenModifiers {
 
 const (
 	TokenModifierDependent = SemanticTokenModifier("hcl-dependent")
+
+	// TokenModifierSynthetic represents tokens of constructs provided
+	// by body extensions (such as count, for_each or dynamic blocks)
+	// rather than declared in the schema directly.
+	TokenModifierSynthetic = SemanticTokenModifier("hcl-synthetic")
 )
+
+var SupportedSemanticTokenModifiers = SemanticTokenModifiers{
+	TokenModifierDependent,
+	TokenModifierSynthetic,
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// DiffSemanticTokens returns a concise human-readable diff
+// between the expected (a) and the given (b) tokens,
+// or an empty string if the tokens are equal.
+//
+// Tokens are paired by range. Each line of the diff describes
+// a token missing in b (prefixed with "-"), an unexpected token
+// in b (prefixed with "+"), or a token whose type or modifiers
+// differ (prefixed with "~"), followed by its range.
+//
+// Modifiers are compared regardless of order and nil modifiers
+// are considered equal to empty ones.
+func DiffSemanticTokens(a, b []SemanticToken) string {
+	expected := tokensByRange(a)
+	given := tokensByRange(b)
+
+	keys := make([]tokenRangeKey, 0, len(expected)+len(given))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range given {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].less(keys[j])
+	})
+
+	lines := make([]string, 0)
+	for _, key := range keys {
+		aTokens, bTokens := expected[key], given[key]
+		for i := 0; i < len(aTokens) || i < len(bTokens); i++ {
+			switch {
+			case i >= len(bTokens):
+				lines = append(lines, fmt.Sprintf("- %s", formatToken(aTokens[i])))
+			case i >= len(aTokens):
+				lines = append(lines, fmt.Sprintf("+ %s", formatToken(bTokens[i])))
+			default:
+				if change := tokenChange(aTokens[i], bTokens[i]); change != "" {
+					lines = append(lines, fmt.Sprintf("~ %s %s", aTokens[i].Range, change))
+				}
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type tokenRangeKey struct {
+	filename  string
+	startByte int
+	endByte   int
+}
+
+func (k tokenRangeKey) less(other tokenRangeKey) bool {
+	if k.filename != other.filename {
+		return k.filename < other.filename
+	}
+	if k.startByte != other.startByte {
+		return k.startByte < other.startByte
+	}
+	return k.endByte < other.endByte
+}
+
+func tokensByRange(tokens []SemanticToken) map[tokenRangeKey][]SemanticToken {
+	m := make(map[tokenRangeKey][]SemanticToken, len(tokens))
+	for _, token := range tokens {
+		key := rangeKey(token.Range)
+		m[key] = append(m[key], token)
+	}
+	return m
+}
+
+func rangeKey(rng hcl.Range) tokenRangeKey {
+	return tokenRangeKey{
+		filename:  rng.Filename,
+		startByte: rng.Start.Byte,
+		endByte:   rng.End.Byte,
+	}
+}
+
+func formatToken(token SemanticToken) string {
+	return fmt.Sprintf("%s %s %s", token.Range, token.Type, formatModifiers(token.Modifiers))
+}
+
+func formatModifiers(modifiers SemanticTokenModifiers) string {
+	names := make([]string, len(modifiers))
+	for i, modifier := range modifiers {
+		names[i] = string(modifier)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("[%s]", strings.Join(names, " "))
+}
+
+func tokenChange(a, b SemanticToken) string {
+	changes := make([]string, 0)
+	if a.Type != b.Type {
+		changes = append(changes, fmt.Sprintf("type: %s -> %s", a.Type, b.Type))
+	}
+	aModifiers, bModifiers := formatModifiers(a.Modifiers), formatModifiers(b.Modifiers)
+	if aModifiers != bModifiers {
+		changes = append(changes, fmt.Sprintf("modifiers: %s -> %s", aModifiers, bModifiers))
+	}
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestDiffSemanticTokens(t *testing.T) {
+	rng := func(line, startCol, endCol, startByte int) hcl.Range {
+		return hcl.Range{
+			Filename: "test.tf",
+			Start:    hcl.Pos{Line: line, Column: startCol, Byte: startByte},
+			End:      hcl.Pos{Line: line, Column: endCol, Byte: startByte + endCol - startCol},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		a            []SemanticToken
+		b            []SemanticToken
+		expectedDiff string
+	}{
+		{
+			"no tokens",
+			[]SemanticToken{},
+			nil,
+			"",
+		},
+		{
+			"equal tokens",
+			[]SemanticToken{
+				{Type: TokenAttrName, Modifiers: SemanticTokenModifiers{}, Range: rng(1, 1, 5, 0)},
+				{Type: TokenString, Range: rng(1, 8, 13, 7)},
+			},
+			[]SemanticToken{
+				{Type: TokenString, Modifiers: SemanticTokenModifiers{}, Range: rng(1, 8, 13, 7)},
+				{Type: TokenAttrName, Range: rng(1, 1, 5, 0)},
+			},
+			"",
+		},
+		{
+			"missing and unexpected tokens",
+			[]SemanticToken{
+				{Type: TokenAttrName, Range: rng(1, 1, 5, 0)},
+				{Type: TokenBlockType, Range: rng(2, 1, 9, 14)},
+			},
+			[]SemanticToken{
+				{Type: TokenBlockType, Range: rng(2, 1, 9, 14)},
+				{Type: TokenBlockLabel, Modifiers: SemanticTokenModifiers{TokenModifierDependent}, Range: rng(2, 10, 15, 23)},
+			},
+			`- test.tf:1,1-5 hcl-attrName []
++ test.tf:2,10-15 hcl-blockLabel [hcl-dependent]`,
+		},
+		{
+			"changed type and modifiers",
+			[]SemanticToken{
+				{Type: TokenString, Range: rng(1, 8, 13, 7)},
+				{Type: TokenBlockType, Modifiers: SemanticTokenModifiers{TokenModifierDependent}, Range: rng(2, 1, 9, 14)},
+			},
+			[]SemanticToken{
+				{Type: TokenNumber, Range: rng(1, 8, 13, 7)},
+				{Type: TokenBlockType, Range: rng(2, 1, 9, 14)},
+			},
+			`~ test.tf:1,8-13 type: hcl-string -> hcl-number
+~ test.tf:2,1-9 modifiers: [hcl-dependent] -> []`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			diff := DiffSemanticTokens(tc.a, tc.b)
+			if d := cmp.Diff(tc.expectedDiff, diff); d != "" {
+				t.Fatalf("unexpected diff: %s", d)
+			}
+		})
+	}
+}
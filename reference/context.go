@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package reference
+
+import (
+	"context"
+)
+
+type targetsCtxKey struct{}
+
+// WithTargets attaches reference targets of the path being
+// processed, e.g. to make them available to validators.
+func WithTargets(ctx context.Context, targets Targets) context.Context {
+	return context.WithValue(ctx, targetsCtxKey{}, targets)
+}
+
+func TargetsFromContext(ctx context.Context) (Targets, bool) {
+	targets, ok := ctx.Value(targetsCtxKey{}).(Targets)
+	return targets, ok
+}
+
+type originsCtxKey struct{}
+
+// WithOrigins attaches reference origins of the path being
+// processed, e.g. to make them available to validators.
+func WithOrigins(ctx context.Context, origins Origins) context.Context {
+	return context.WithValue(ctx, originsCtxKey{}, origins)
+}
+
+func OriginsFromContext(ctx context.Context) (Origins, bool) {
+	origins, ok := ctx.Value(originsCtxKey{}).(Origins)
+	return origins, ok
+}
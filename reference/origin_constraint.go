@@ -11,6 +11,11 @@ import (
 type OriginConstraint struct {
 	OfScopeId lang.ScopeId
 	OfType    cty.Type
+
+	// Optional indicates that the origin may legitimately point
+	// to a target outside of the analyzed scope, and so it should
+	// not be reported as undefined if no target is found.
+	Optional bool
 }
 
 type OriginConstraints []OriginConstraint
@@ -4,6 +4,8 @@
 package reference
 
 import (
+	"sort"
+
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl/v2"
 )
@@ -23,6 +25,28 @@ func (ro Origins) Copy() Origins {
 	return newOrigins
 }
 
+// Sort sorts origins in place by filename, start byte
+// and address, to provide deterministic ordering.
+func (ro Origins) Sort() {
+	sort.SliceStable(ro, func(i, j int) bool {
+		iRng, jRng := ro[i].OriginRange(), ro[j].OriginRange()
+		if iRng.Filename != jRng.Filename {
+			return iRng.Filename < jRng.Filename
+		}
+		if iRng.Start.Byte != jRng.Start.Byte {
+			return iRng.Start.Byte < jRng.Start.Byte
+		}
+		return originAddress(ro[i]) < originAddress(ro[j])
+	})
+}
+
+func originAddress(origin Origin) string {
+	if mo, ok := origin.(MatchableOrigin); ok {
+		return mo.Address().String()
+	}
+	return ""
+}
+
 func (ro Origins) AtPos(file string, pos hcl.Pos) (Origins, bool) {
 	matchingOrigins := make(Origins, 0)
 	for _, origin := range ro {
@@ -34,6 +58,33 @@ func (ro Origins) AtPos(file string, pos hcl.Pos) (Origins, bool) {
 	return matchingOrigins, len(matchingOrigins) > 0
 }
 
+// ForFile returns origins whose range is in the given file,
+// preserving their order.
+func (ro Origins) ForFile(file string) Origins {
+	origins := make(Origins, 0)
+	for _, origin := range ro {
+		if origin.OriginRange().Filename == file {
+			origins = append(origins, origin)
+		}
+	}
+
+	return origins
+}
+
+// InRange returns origins starting within the given range,
+// preserving their order.
+func (ro Origins) InRange(rng hcl.Range) Origins {
+	origins := make(Origins, 0)
+	for _, origin := range ro {
+		originRng := origin.OriginRange()
+		if originRng.Filename == rng.Filename && rng.ContainsPos(originRng.Start) {
+			origins = append(origins, origin)
+		}
+	}
+
+	return origins
+}
+
 func (ro Origins) Match(localPath lang.Path, target Target, targetPath lang.Path) Origins {
 	origins := make(Origins, 0)
 
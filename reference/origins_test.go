@@ -153,6 +153,242 @@ func TestOrigins_AtPos(t *testing.T) {
 	}
 }
 
+func TestOrigins_ForFile(t *testing.T) {
+	origins := Origins{
+		LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "foo"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			},
+		},
+		LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+			},
+			Range: hcl.Range{
+				Filename: "differentfile.tf",
+				Start:    hcl.Pos{Line: 2, Column: 8, Byte: 14},
+				End:      hcl.Pos{Line: 2, Column: 12, Byte: 18},
+			},
+		},
+		LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "bar"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 8, Byte: 14},
+				End:      hcl.Pos{Line: 2, Column: 12, Byte: 18},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		origins         Origins
+		filename        string
+		expectedOrigins Origins
+	}{
+		{
+			"no origins",
+			Origins{},
+			"test.tf",
+			Origins{},
+		},
+		{
+			"mismatching filename",
+			origins,
+			"unknown.tf",
+			Origins{},
+		},
+		{
+			"multiple matches in original order",
+			origins,
+			"test.tf",
+			Origins{
+				origins[0],
+				origins[2],
+			},
+		},
+		{
+			"single match",
+			origins,
+			"differentfile.tf",
+			Origins{
+				origins[1],
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			origins := tc.origins.ForFile(tc.filename)
+
+			if diff := cmp.Diff(tc.expectedOrigins, origins, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("mismatched origins: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOrigins_InRange(t *testing.T) {
+	origins := Origins{
+		LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "foo"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			},
+		},
+		LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+			},
+			Range: hcl.Range{
+				Filename: "differentfile.tf",
+				Start:    hcl.Pos{Line: 1, Column: 8, Byte: 7},
+				End:      hcl.Pos{Line: 1, Column: 12, Byte: 11},
+			},
+		},
+		LocalOrigin{
+			Addr: lang.Address{
+				lang.RootStep{Name: "bar"},
+			},
+			Range: hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 8, Byte: 20},
+				End:      hcl.Pos{Line: 2, Column: 12, Byte: 24},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		origins         Origins
+		rng             hcl.Range
+		expectedOrigins Origins
+	}{
+		{
+			"no origins",
+			Origins{},
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.InitialPos,
+				End:      hcl.Pos{Line: 3, Column: 1, Byte: 30},
+			},
+			Origins{},
+		},
+		{
+			"mismatching filename",
+			origins,
+			hcl.Range{
+				Filename: "unknown.tf",
+				Start:    hcl.InitialPos,
+				End:      hcl.Pos{Line: 3, Column: 1, Byte: 30},
+			},
+			Origins{},
+		},
+		{
+			"multiple matches in original order",
+			origins,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.InitialPos,
+				End:      hcl.Pos{Line: 3, Column: 1, Byte: 30},
+			},
+			Origins{
+				origins[0],
+				origins[2],
+			},
+		},
+		{
+			"single match",
+			origins,
+			hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 13},
+				End:      hcl.Pos{Line: 2, Column: 12, Byte: 24},
+			},
+			Origins{
+				origins[2],
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			origins := tc.origins.InRange(tc.rng)
+
+			if diff := cmp.Diff(tc.expectedOrigins, origins, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("mismatched origins: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOrigins_Sort(t *testing.T) {
+	rng := func(filename string, startByte int) hcl.Range {
+		return hcl.Range{
+			Filename: filename,
+			Start:    hcl.Pos{Line: 1, Column: startByte + 1, Byte: startByte},
+			End:      hcl.Pos{Line: 1, Column: startByte + 4, Byte: startByte + 3},
+		}
+	}
+	origins := Origins{
+		LocalOrigin{
+			Addr:  lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			Range: rng("b.tf", 0),
+		},
+		PathOrigin{
+			TargetAddr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "bar"}},
+			TargetPath: lang.Path{Path: "./module"},
+			Range:      rng("a.tf", 10),
+		},
+		LocalOrigin{
+			Addr:  lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			Range: rng("a.tf", 10),
+		},
+		DirectOrigin{
+			Range:       rng("a.tf", 2),
+			TargetPath:  lang.Path{Path: "./module"},
+			TargetRange: rng("main.tf", 0),
+		},
+	}
+
+	origins.Sort()
+
+	expectedOrigins := Origins{
+		DirectOrigin{
+			Range:       rng("a.tf", 2),
+			TargetPath:  lang.Path{Path: "./module"},
+			TargetRange: rng("main.tf", 0),
+		},
+		PathOrigin{
+			TargetAddr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "bar"}},
+			TargetPath: lang.Path{Path: "./module"},
+			Range:      rng("a.tf", 10),
+		},
+		LocalOrigin{
+			Addr:  lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			Range: rng("a.tf", 10),
+		},
+		LocalOrigin{
+			Addr:  lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			Range: rng("b.tf", 0),
+		},
+	}
+	if diff := cmp.Diff(expectedOrigins, origins, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected origins: %s", diff)
+	}
+}
+
 func TestOrigins_Match(t *testing.T) {
 	alphaPath := lang.Path{Path: t.TempDir()}
 	betaPath := lang.Path{Path: t.TempDir()}
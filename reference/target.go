@@ -51,13 +51,39 @@ type Target struct {
 	// the editor near the middle of this range.
 	DefRangePtr *hcl.Range
 
-	Type        cty.Type
+	// TypeDefRangePtr represents a range where the type of the target
+	// is declared, e.g. type = string in a variable block,
+	// or nil if the type is not explicitly declared.
+	TypeDefRangePtr *hcl.Range
+
+	Type cty.Type
+
+	// Value represents the value of the target if it is statically
+	// known (e.g. declared as a literal), or cty.NilVal otherwise.
+	Value cty.Value
+
 	Name        string
 	Description lang.MarkupContent
 
+	// Metadata represents any additional information about the target
+	// which is not otherwise understood by the decoder.
+	//
+	// Only keys documented as MetadataKey* are rendered in hover,
+	// any other keys are retained but otherwise ignored.
+	Metadata map[string]string
+
 	NestedTargets Targets
 }
 
+const (
+	// MetadataKeySource describes where the target comes from,
+	// e.g. a plugin name and version.
+	MetadataKeySource = "source"
+
+	// MetadataKeyDocsURL is a URL pointing to documentation of the target.
+	MetadataKeyDocsURL = "docs_url"
+)
+
 // rangeOverlaps is a copy of hcl.Range.Overlaps
 // https://github.com/hashicorp/hcl/blob/v2.14.1/pos.go#L195-L212
 // which accounts for empty ranges that are common in the context of LS
@@ -86,13 +112,137 @@ func (ref Target) Copy() Target {
 		ScopeId:                ref.ScopeId,
 		RangePtr:               copyHclRangePtr(ref.RangePtr),
 		DefRangePtr:            copyHclRangePtr(ref.DefRangePtr),
-		Type:                   ref.Type, // cty.Type is immutable by design
+		TypeDefRangePtr:        copyHclRangePtr(ref.TypeDefRangePtr),
+		Type:                   ref.Type,  // cty.Type is immutable by design
+		Value:                  ref.Value, // cty.Value is immutable by design
 		Name:                   ref.Name,
 		Description:            ref.Description,
+		Metadata:               copyMetadata(ref.Metadata),
 		NestedTargets:          ref.NestedTargets.Copy(),
 	}
 }
 
+// Equal returns true if the target is equal to the other target,
+// i.e. its addresses, scope, type, ranges and other details match,
+// including any nested targets.
+func (ref Target) Equal(other Target) bool {
+	if !addressesEqual(ref.Addr, other.Addr) || !addressesEqual(ref.LocalAddr, other.LocalAddr) {
+		return false
+	}
+	if ref.ScopeId != other.ScopeId {
+		return false
+	}
+	if !typesEqual(ref.Type, other.Type) || !valuesEqual(ref.Value, other.Value) {
+		return false
+	}
+	if !rangePtrsEqual(ref.RangePtr, other.RangePtr) ||
+		!rangePtrsEqual(ref.DefRangePtr, other.DefRangePtr) ||
+		!rangePtrsEqual(ref.TypeDefRangePtr, other.TypeDefRangePtr) ||
+		!rangePtrsEqual(ref.TargetableFromRangePtr, other.TargetableFromRangePtr) {
+		return false
+	}
+	if ref.Name != other.Name || ref.Description != other.Description {
+		return false
+	}
+	if len(ref.Metadata) != len(other.Metadata) {
+		return false
+	}
+	for key, value := range ref.Metadata {
+		if otherValue, ok := other.Metadata[key]; !ok || otherValue != value {
+			return false
+		}
+	}
+
+	if len(ref.NestedTargets) != len(other.NestedTargets) {
+		return false
+	}
+	for i, nestedTarget := range ref.NestedTargets {
+		if !nestedTarget.Equal(other.NestedTargets[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SameDeclaration returns true if the target declares the same
+// thing as the other target, i.e. its addresses, scope, type
+// and the range it is targetable from match, including any nested targets.
+//
+// Unlike Equal it disregards declaration ranges and other details, such that
+// the same target declared in two different files is considered the same,
+// while local targets (e.g. count.index) of different blocks are not.
+func (ref Target) SameDeclaration(other Target) bool {
+	if !addressesEqual(ref.Addr, other.Addr) || !addressesEqual(ref.LocalAddr, other.LocalAddr) {
+		return false
+	}
+	if !rangePtrsEqual(ref.TargetableFromRangePtr, other.TargetableFromRangePtr) {
+		return false
+	}
+	if ref.ScopeId != other.ScopeId || !typesEqual(ref.Type, other.Type) {
+		return false
+	}
+
+	if len(ref.NestedTargets) != len(other.NestedTargets) {
+		return false
+	}
+	for i, nestedTarget := range ref.NestedTargets {
+		if !nestedTarget.SameDeclaration(other.NestedTargets[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addressesEqual compares addresses step by step and unlike
+// lang.Address.Equals treats two empty addresses as equal.
+func addressesEqual(a, b lang.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, step := range a {
+		if step.String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+// typesEqual compares types disregarding any optional attribute markers,
+// which do not change what the target represents.
+func typesEqual(a, b cty.Type) bool {
+	if a == cty.NilType || b == cty.NilType {
+		return a == b
+	}
+	return a.WithoutOptionalAttributesDeep().Equals(b.WithoutOptionalAttributesDeep())
+}
+
+func valuesEqual(a, b cty.Value) bool {
+	if a == cty.NilVal || b == cty.NilVal {
+		return a == b
+	}
+	return a.RawEquals(b)
+}
+
+func rangePtrsEqual(a, b *hcl.Range) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func copyMetadata(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+	newMetadata := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		newMetadata[key] = value
+	}
+	return newMetadata
+}
+
 func copyHclRangePtr(rng *hcl.Range) *hcl.Range {
 	if rng == nil {
 		return nil
@@ -142,7 +292,31 @@ func (r Target) TargetRange() (hcl.Range, bool) {
 }
 
 func (target Target) MatchesConstraint(ref schema.Reference) bool {
-	return target.MatchesScopeId(ref.OfScopeId) && target.IsConvertibleToType(ref.OfType)
+	return target.MatchesScopeId(ref.OfScopeId) &&
+		target.IsConvertibleToType(ref.OfType) &&
+		target.MatchesRequiredPath(ref.RequiredPath) &&
+		target.MatchesBlockType(ref.OfBlockType)
+}
+
+// MatchesBlockType returns true if any of the target's
+// addresses is rooted in the given block type
+func (ref Target) MatchesBlockType(blockType string) bool {
+	if blockType == "" {
+		return true
+	}
+
+	return ref.Addr.IsRootedIn(blockType) || ref.LocalAddr.IsRootedIn(blockType)
+}
+
+// MatchesRequiredPath returns true if any of the target's
+// addresses ends with the given path
+func (ref Target) MatchesRequiredPath(path lang.Address) bool {
+	if len(path) == 0 {
+		return true
+	}
+
+	return (len(ref.Addr) > 0 && ref.Addr.HasSuffix(path)) ||
+		(len(ref.LocalAddr) > 0 && ref.LocalAddr.HasSuffix(path))
 }
 
 func (ref Target) MatchesScopeId(scopeId lang.ScopeId) bool {
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestTarget_Address(t *testing.T) {
@@ -161,3 +162,270 @@ func TestTarget_Address(t *testing.T) {
 		})
 	}
 }
+
+func TestTarget_Equal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		target        Target
+		other         Target
+		expectedEqual bool
+	}{
+		{
+			"empty targets",
+			Target{},
+			Target{},
+			true,
+		},
+		{
+			"same address, scope, type and range",
+			Target{
+				Addr:     lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				ScopeId:  lang.ScopeId("variable"),
+				Type:     cty.String,
+				RangePtr: &hcl.Range{Filename: "a.tf", Start: hcl.InitialPos, End: hcl.Pos{Line: 1, Column: 5, Byte: 4}},
+			},
+			Target{
+				Addr:     lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				ScopeId:  lang.ScopeId("variable"),
+				Type:     cty.String,
+				RangePtr: &hcl.Range{Filename: "a.tf", Start: hcl.InitialPos, End: hcl.Pos{Line: 1, Column: 5, Byte: 4}},
+			},
+			true,
+		},
+		{
+			"different address",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "bar"}},
+			},
+			false,
+		},
+		{
+			"different scope",
+			Target{
+				Addr:    lang.Address{lang.RootStep{Name: "foo"}},
+				ScopeId: lang.ScopeId("one"),
+			},
+			Target{
+				Addr:    lang.Address{lang.RootStep{Name: "foo"}},
+				ScopeId: lang.ScopeId("two"),
+			},
+			false,
+		},
+		{
+			"different type",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				Type: cty.String,
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				Type: cty.Number,
+			},
+			false,
+		},
+		{
+			"type vs no type",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				Type: cty.String,
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+			},
+			false,
+		},
+		{
+			"different value",
+			Target{
+				Addr:  lang.Address{lang.RootStep{Name: "foo"}},
+				Type:  cty.String,
+				Value: cty.StringVal("one"),
+			},
+			Target{
+				Addr:  lang.Address{lang.RootStep{Name: "foo"}},
+				Type:  cty.String,
+				Value: cty.StringVal("two"),
+			},
+			false,
+		},
+		{
+			"range in different file",
+			Target{
+				Addr:     lang.Address{lang.RootStep{Name: "foo"}},
+				RangePtr: &hcl.Range{Filename: "a.tf", Start: hcl.InitialPos, End: hcl.InitialPos},
+			},
+			Target{
+				Addr:     lang.Address{lang.RootStep{Name: "foo"}},
+				RangePtr: &hcl.Range{Filename: "b.tf", Start: hcl.InitialPos, End: hcl.InitialPos},
+			},
+			false,
+		},
+		{
+			"range vs no range",
+			Target{
+				Addr:     lang.Address{lang.RootStep{Name: "foo"}},
+				RangePtr: &hcl.Range{Filename: "a.tf", Start: hcl.InitialPos, End: hcl.InitialPos},
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+			},
+			false,
+		},
+		{
+			"equal nested targets",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "foo"}, lang.AttrStep{Name: "bar"}}},
+				},
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "foo"}, lang.AttrStep{Name: "bar"}}},
+				},
+			},
+			true,
+		},
+		{
+			"different nested targets",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "foo"}, lang.AttrStep{Name: "bar"}}},
+				},
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "foo"}, lang.AttrStep{Name: "baz"}}},
+				},
+			},
+			false,
+		},
+		{
+			"missing nested target",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "foo"}, lang.AttrStep{Name: "bar"}}},
+				},
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "foo"}},
+			},
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			equal := tc.target.Equal(tc.other)
+			if equal != tc.expectedEqual {
+				t.Fatalf("expected equal: %t, given: %t", tc.expectedEqual, equal)
+			}
+			reverseEqual := tc.other.Equal(tc.target)
+			if reverseEqual != tc.expectedEqual {
+				t.Fatalf("expected reverse equal: %t, given: %t", tc.expectedEqual, reverseEqual)
+			}
+		})
+	}
+}
+
+func TestTarget_SameDeclaration(t *testing.T) {
+	rng := func(filename string) *hcl.Range {
+		return &hcl.Range{
+			Filename: filename,
+			Start:    hcl.Pos{Line: 1, Column: 1, Byte: 0},
+			End:      hcl.Pos{Line: 1, Column: 4, Byte: 3},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		target       Target
+		other        Target
+		expectedSame bool
+	}{
+		{
+			"declared in different files",
+			Target{
+				Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				Type:        cty.String,
+				DefRangePtr: rng("a.tf"),
+			},
+			Target{
+				Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				Type:        cty.String,
+				DefRangePtr: rng("b.tf"),
+			},
+			true,
+		},
+		{
+			"different type",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				Type: cty.String,
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				Type: cty.Number,
+			},
+			false,
+		},
+		{
+			"different scope",
+			Target{
+				Addr:    lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				ScopeId: lang.ScopeId("variable"),
+			},
+			Target{
+				Addr:    lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				ScopeId: lang.ScopeId("local"),
+			},
+			false,
+		},
+		{
+			"local targets of different blocks",
+			Target{
+				LocalAddr:              lang.Address{lang.RootStep{Name: "count"}, lang.AttrStep{Name: "index"}},
+				Type:                   cty.Number,
+				TargetableFromRangePtr: rng("a.tf"),
+			},
+			Target{
+				LocalAddr:              lang.Address{lang.RootStep{Name: "count"}, lang.AttrStep{Name: "index"}},
+				Type:                   cty.Number,
+				TargetableFromRangePtr: rng("b.tf"),
+			},
+			false,
+		},
+		{
+			"different nested targets",
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "bar"}}},
+				},
+			},
+			Target{
+				Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+				NestedTargets: Targets{
+					{Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "baz"}}},
+				},
+			},
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			same := tc.target.SameDeclaration(tc.other)
+			if same != tc.expectedSame {
+				t.Fatalf("expected same: %t, given: %t", tc.expectedSame, same)
+			}
+		})
+	}
+}
@@ -6,10 +6,13 @@ package reference
 import (
 	"context"
 	"errors"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/hashicorp/hcl-lang/schema"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type Targets []Target
@@ -32,14 +35,82 @@ func (r Targets) Len() int {
 }
 
 func (r Targets) Less(i, j int) bool {
-	return r[i].LocalAddr.String() < r[j].LocalAddr.String() ||
-		r[i].Addr.String() < r[j].Addr.String()
+	return targetLess(r[i], r[j])
 }
 
 func (r Targets) Swap(i, j int) {
 	r[i], r[j] = r[j], r[i]
 }
 
+// Sort sorts targets (including any nested targets) in place
+// by address, local address and definition range,
+// to provide deterministic ordering.
+func (refs Targets) Sort() {
+	sort.Stable(refs)
+	for _, ref := range refs {
+		ref.NestedTargets.Sort()
+	}
+}
+
+// Deduplicate returns targets without any duplicates, i.e. targets
+// which declare the same address, scope and type within the same
+// targetable range (see Target.SameDeclaration),
+// regardless of which file they were declared in.
+//
+// Of any duplicates, the target declared first (by filename
+// and position, as in Sort) is kept, so that the result does not
+// depend on the order in which files were processed.
+func (refs Targets) Deduplicate() Targets {
+	uniqueRefs := make(Targets, 0, len(refs))
+	seen := make(map[string][]int, len(refs))
+
+	for _, ref := range refs {
+		key := ref.Addr.String()
+		isDuplicate := false
+		for _, idx := range seen[key] {
+			if uniqueRefs[idx].SameDeclaration(ref) {
+				if targetLess(ref, uniqueRefs[idx]) {
+					uniqueRefs[idx] = ref
+				}
+				isDuplicate = true
+				break
+			}
+		}
+		if isDuplicate {
+			continue
+		}
+
+		seen[key] = append(seen[key], len(uniqueRefs))
+		uniqueRefs = append(uniqueRefs, ref)
+	}
+
+	return uniqueRefs
+}
+
+func targetLess(a, b Target) bool {
+	if aAddr, bAddr := a.Addr.String(), b.Addr.String(); aAddr != bAddr {
+		return aAddr < bAddr
+	}
+	if aAddr, bAddr := a.LocalAddr.String(), b.LocalAddr.String(); aAddr != bAddr {
+		return aAddr < bAddr
+	}
+
+	aRng, bRng := a.DefRangePtr, b.DefRangePtr
+	if aRng == nil {
+		aRng = a.RangePtr
+	}
+	if bRng == nil {
+		bRng = b.RangePtr
+	}
+	if aRng == nil || bRng == nil {
+		return aRng == nil && bRng != nil
+	}
+	if aRng.Filename != bRng.Filename {
+		return aRng.Filename < bRng.Filename
+	}
+	return aRng.Start.Byte < bRng.Start.Byte
+}
+
 type TargetWalkFunc func(Target) error
 
 var stopWalking error = errors.New("stop walking")
@@ -188,7 +259,112 @@ func (refs Targets) Match(origin MatchableOrigin) (Targets, bool) {
 	return matchingReferences, len(matchingReferences) > 0
 }
 
+// ResolveAddress returns the target (including nested ones)
+// whose address or local address equals the given address.
+//
+// If loose is true and there is no exact match, the innermost target
+// of unknown (dynamic) type whose address is a prefix of the given
+// address is returned instead, e.g. var.foo (of any type) for var.foo.bar.
+func (refs Targets) ResolveAddress(addr lang.Address, loose bool) (*Target, bool) {
+	if len(addr) == 0 {
+		return nil, false
+	}
+
+	if target, ok := refs.resolveAddress(addr, false); ok {
+		return target, true
+	}
+	if loose {
+		return refs.resolveAddress(addr, true)
+	}
+
+	return nil, false
+}
+
+func (refs Targets) resolveAddress(addr lang.Address, loose bool) (*Target, bool) {
+	for i := range refs {
+		target := &refs[i]
+
+		if nestedTarget, ok := target.NestedTargets.resolveAddress(addr, loose); ok {
+			return nestedTarget, true
+		}
+
+		if target.Addr.Equals(addr) || target.LocalAddr.Equals(addr) {
+			return target, true
+		}
+		if loose && target.Type == cty.DynamicPseudoType &&
+			(isAddressPrefix(target.Addr, addr) || isAddressPrefix(target.LocalAddr, addr)) {
+			return target, true
+		}
+	}
+
+	return nil, false
+}
+
+// MapKeysOf returns sorted keys of the map (or object) target
+// at the given address, as declared via its nested targets,
+// e.g. "eu" and "us" for var.regions["eu"] and var.regions["us"].
+//
+// It returns false if there is no such target or if no keys
+// are statically known.
+func (refs Targets) MapKeysOf(addr lang.Address) ([]string, bool) {
+	target, ok := refs.ResolveAddress(addr, false)
+	if !ok {
+		return nil, false
+	}
+
+	keys := make([]string, 0, len(target.NestedTargets))
+	for _, nestedTarget := range target.NestedTargets {
+		nestedAddr := nestedTarget.Addr
+		if len(nestedAddr) == 0 {
+			nestedAddr = nestedTarget.LocalAddr
+		}
+		if len(nestedAddr) == 0 {
+			continue
+		}
+
+		switch step := nestedAddr[len(nestedAddr)-1].(type) {
+		case lang.AttrStep:
+			keys = append(keys, step.Name)
+		case lang.IndexStep:
+			if step.Key.IsKnown() && !step.Key.IsNull() && step.Key.Type() == cty.String {
+				keys = append(keys, step.Key.AsString())
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		// attributes of objects are known even without nested targets
+		if target.Type == cty.NilType || !target.Type.IsObjectType() {
+			return nil, false
+		}
+		for name := range target.Type.AttributeTypes() {
+			keys = append(keys, name)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, false
+	}
+
+	sort.Strings(keys)
+	return keys, true
+}
+
+// isAddressPrefix returns true if prefix is a non-empty
+// strict prefix of the given address
+func isAddressPrefix(prefix, addr lang.Address) bool {
+	if len(prefix) == 0 || len(prefix) >= len(addr) {
+		return false
+	}
+	return addr.FirstSteps(uint(len(prefix))).Equals(prefix)
+}
+
 func (refs Targets) OutermostInFile(file string) Targets {
+	return refs.ForFile(file)
+}
+
+// ForFile returns targets whose range is in the given file,
+// preserving their order. Targets without a range are skipped.
+func (refs Targets) ForFile(file string) Targets {
 	targets := make(Targets, 0)
 
 	for _, target := range refs {
@@ -5,6 +5,7 @@ package reference
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -496,6 +497,240 @@ func TestTargets_Match_localRefs(t *testing.T) {
 	}
 }
 
+func TestTargets_ResolveAddress(t *testing.T) {
+	targets := Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "foo"},
+			},
+			Type: cty.Object(map[string]cty.Type{
+				"bar": cty.String,
+			}),
+			NestedTargets: Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "foo"},
+						lang.AttrStep{Name: "bar"},
+					},
+					Type: cty.String,
+				},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "dyn"},
+			},
+			Type: cty.DynamicPseudoType,
+		},
+		{
+			LocalAddr: lang.Address{
+				lang.RootStep{Name: "self"},
+				lang.AttrStep{Name: "attr"},
+			},
+			Type: cty.String,
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		addr         lang.Address
+		loose        bool
+		expectedAddr string
+		expectedOk   bool
+	}{
+		{
+			"empty address",
+			lang.Address{},
+			true,
+			"",
+			false,
+		},
+		{
+			"top-level target",
+			lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			false,
+			"var.foo",
+			true,
+		},
+		{
+			"nested target",
+			lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "bar"}},
+			false,
+			"var.foo.bar",
+			true,
+		},
+		{
+			"local address",
+			lang.Address{lang.RootStep{Name: "self"}, lang.AttrStep{Name: "attr"}},
+			false,
+			"self.attr",
+			true,
+		},
+		{
+			"unknown attribute of dynamic target",
+			lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "dyn"}, lang.AttrStep{Name: "any"}},
+			false,
+			"",
+			false,
+		},
+		{
+			"unknown attribute of dynamic target loosely",
+			lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "dyn"}, lang.AttrStep{Name: "any"}},
+			true,
+			"var.dyn",
+			true,
+		},
+		{
+			"unknown attribute of typed target loosely",
+			lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "any"}},
+			true,
+			"",
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			target, ok := targets.ResolveAddress(tc.addr, tc.loose)
+			if ok != tc.expectedOk {
+				t.Fatalf("expected ok: %t, given: %t", tc.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			addr := target.Addr
+			if len(addr) == 0 {
+				addr = target.LocalAddr
+			}
+			if addr.String() != tc.expectedAddr {
+				t.Fatalf("expected target %q, given: %q", tc.expectedAddr, addr.String())
+			}
+		})
+	}
+}
+
+func TestTargets_MapKeysOf(t *testing.T) {
+	targets := Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "map"},
+			},
+			Type: cty.Map(cty.String),
+			NestedTargets: Targets{
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "map"},
+						lang.IndexStep{Key: cty.StringVal("us")},
+					},
+					Type: cty.String,
+				},
+				{
+					Addr: lang.Address{
+						lang.RootStep{Name: "var"},
+						lang.AttrStep{Name: "map"},
+						lang.IndexStep{Key: cty.StringVal("eu")},
+					},
+					Type: cty.String,
+				},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "obj"},
+			},
+			Type: cty.Object(map[string]cty.Type{
+				"foo": cty.String,
+				"bar": cty.Number,
+			}),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "unknown"},
+			},
+			Type: cty.Map(cty.String),
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "empty_obj"},
+			},
+			Type: cty.EmptyObject,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		addr          lang.Address
+		expectedKeys  []string
+		expectedFound bool
+	}{
+		{
+			"map with nested targets",
+			lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "map"},
+			},
+			[]string{"eu", "us"},
+			true,
+		},
+		{
+			"object without nested targets",
+			lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "obj"},
+			},
+			[]string{"bar", "foo"},
+			true,
+		},
+		{
+			"map without nested targets",
+			lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "unknown"},
+			},
+			nil,
+			false,
+		},
+		{
+			"object without attributes",
+			lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "empty_obj"},
+			},
+			nil,
+			false,
+		},
+		{
+			"undeclared target",
+			lang.Address{
+				lang.RootStep{Name: "var"},
+				lang.AttrStep{Name: "missing"},
+			},
+			nil,
+			false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			keys, found := targets.MapKeysOf(tc.addr)
+			if found != tc.expectedFound {
+				t.Fatalf("expected found: %t, given: %t", tc.expectedFound, found)
+			}
+			if diff := cmp.Diff(tc.expectedKeys, keys); diff != "" {
+				t.Fatalf("unexpected keys: %s", diff)
+			}
+		})
+	}
+}
+
 func TestTargets_OutermostInFile(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -612,6 +847,92 @@ func TestTargets_OutermostInFile(t *testing.T) {
 	}
 }
 
+func TestTargets_ForFile(t *testing.T) {
+	targets := Targets{
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "foo"},
+			},
+			RangePtr: &hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.InitialPos,
+				End:      hcl.Pos{Line: 2, Column: 1, Byte: 10},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "bar"},
+			},
+			RangePtr: &hcl.Range{
+				Filename: "differentfile.tf",
+				Start:    hcl.InitialPos,
+				End:      hcl.Pos{Line: 2, Column: 1, Byte: 10},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "unaddressable"},
+			},
+		},
+		{
+			Addr: lang.Address{
+				lang.RootStep{Name: "baz"},
+			},
+			RangePtr: &hcl.Range{
+				Filename: "test.tf",
+				Start:    hcl.Pos{Line: 3, Column: 1, Byte: 11},
+				End:      hcl.Pos{Line: 4, Column: 1, Byte: 20},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		targets         Targets
+		filename        string
+		expectedTargets Targets
+	}{
+		{
+			"no targets",
+			Targets{},
+			"test.tf",
+			Targets{},
+		},
+		{
+			"mismatching filename",
+			targets,
+			"unknown.tf",
+			Targets{},
+		},
+		{
+			"multiple matches in original order",
+			targets,
+			"test.tf",
+			Targets{
+				targets[0],
+				targets[3],
+			},
+		},
+		{
+			"single match",
+			targets,
+			"differentfile.tf",
+			Targets{
+				targets[1],
+			},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d-%s", i, tc.name), func(t *testing.T) {
+			targets := tc.targets.ForFile(tc.filename)
+
+			if diff := cmp.Diff(tc.expectedTargets, targets, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("mismatch of targets: %s", diff)
+			}
+		})
+	}
+}
+
 func TestTargets_InnermostAtPos(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -843,3 +1164,70 @@ func TestTargets_InnermostAtPos(t *testing.T) {
 		})
 	}
 }
+
+func TestTargets_Sort(t *testing.T) {
+	rng := func(filename string, startByte int) *hcl.Range {
+		return &hcl.Range{
+			Filename: filename,
+			Start:    hcl.Pos{Line: 1, Column: startByte + 1, Byte: startByte},
+			End:      hcl.Pos{Line: 1, Column: startByte + 4, Byte: startByte + 3},
+		}
+	}
+	targets := Targets{
+		{
+			Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			DefRangePtr: rng("b.tf", 0),
+			NestedTargets: Targets{
+				{
+					Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "zed"}},
+				},
+				{
+					Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "bar"}},
+				},
+			},
+		},
+		{
+			Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			DefRangePtr: rng("a.tf", 0),
+		},
+		{
+			Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "bar"}},
+			DefRangePtr: rng("c.tf", 0),
+		},
+	}
+
+	targets.Sort()
+
+	expectedTargets := Targets{
+		{
+			Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "bar"}},
+			DefRangePtr: rng("c.tf", 0),
+		},
+		{
+			Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			DefRangePtr: rng("a.tf", 0),
+		},
+		{
+			Addr:        lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}},
+			DefRangePtr: rng("b.tf", 0),
+			NestedTargets: Targets{
+				{
+					Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "bar"}},
+				},
+				{
+					Addr: lang.Address{lang.RootStep{Name: "var"}, lang.AttrStep{Name: "foo"}, lang.AttrStep{Name: "zed"}},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedTargets, targets, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected targets: %s", diff)
+	}
+
+	// sort.Sort (via Less) is expected to agree with Sort
+	reversedTargets := Targets{expectedTargets[2], expectedTargets[1], expectedTargets[0]}
+	sort.Sort(reversedTargets)
+	if diff := cmp.Diff(expectedTargets, reversedTargets, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected targets sorted via Less: %s", diff)
+	}
+}
@@ -31,6 +31,9 @@ func (addr Address) AttributeValidate() error {
 		if _, ok := step.(AttrValueStep); ok {
 			return fmt.Errorf("Address[%d]: AttrValueStep is not implemented for attribute", i)
 		}
+		if _, ok := step.(NameStep); ok {
+			return fmt.Errorf("Address[%d]: NameStep is not valid for attribute", i)
+		}
 	}
 
 	return nil
@@ -49,3 +52,12 @@ func (addr Address) BlockValidate() error {
 
 	return nil
 }
+
+func (addr Address) hasNameStep() bool {
+	for _, step := range addr {
+		if _, ok := step.(NameStep); ok {
+			return true
+		}
+	}
+	return false
+}
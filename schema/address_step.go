@@ -31,6 +31,15 @@ func (AttrNameStep) isAddrStepImpl() addrStepImplSigil {
 	return addrStepImplSigil{}
 }
 
+// NameStep represents the name of the block, i.e. the value of the label
+// (LabelSchema.IsName) or the attribute (AttributeSchema.IsName)
+// marked as the name.
+type NameStep struct{}
+
+func (NameStep) isAddrStepImpl() addrStepImplSigil {
+	return addrStepImplSigil{}
+}
+
 type AttrValueStep struct {
 	Name       string
 	IsOptional bool
@@ -19,6 +19,31 @@ type AttributeSchema struct {
 	IsComputed   bool
 	IsSensitive  bool
 
+	// ReplacedBy (if not empty) represents name of the attribute
+	// which replaces this (deprecated) attribute.
+	ReplacedBy string
+
+	// IsCompletionOnly indicates that the attribute is offered
+	// in completion, but skipped during validation, i.e. it does not
+	// trigger any diagnostics, such as a missing required attribute.
+	IsCompletionOnly bool
+
+	// IsNullable indicates that the attribute accepts null as a value,
+	// i.e. attr = null is considered valid.
+	IsNullable bool
+
+	// DisallowEmpty indicates that the attribute does not accept
+	// an empty string as a value, i.e. attr = "" is considered invalid.
+	DisallowEmpty bool
+
+	// RequiredWith represents names of sibling attributes
+	// which must be specified whenever this attribute is specified.
+	RequiredWith []string
+
+	// ConflictsWith represents names of sibling attributes
+	// which cannot be specified together with this attribute.
+	ConflictsWith []string
+
 	// Constraint represents expression constraint e.g. what types of
 	// expressions are expected for the attribute
 	Constraint Constraint
@@ -32,6 +57,12 @@ type AttributeSchema struct {
 	// as key when looking up dependent schema
 	IsDepKey bool
 
+	// IsName describes whether the (string) value of this attribute
+	// represents the name of the enclosing block, which is used
+	// to resolve NameStep within the block's address.
+	// Only one attribute of a block can be marked as IsName.
+	IsName bool
+
 	// Address describes whether and how the attribute itself is targetable
 	Address *AttributeAddrSchema
 
@@ -133,16 +164,34 @@ func (as *AttributeSchema) Copy() *AttributeSchema {
 		IsRequired:             as.IsRequired,
 		IsOptional:             as.IsOptional,
 		IsDeprecated:           as.IsDeprecated,
+		ReplacedBy:             as.ReplacedBy,
 		IsComputed:             as.IsComputed,
 		IsSensitive:            as.IsSensitive,
+		IsCompletionOnly:       as.IsCompletionOnly,
+		IsNullable:             as.IsNullable,
+		DisallowEmpty:          as.DisallowEmpty,
 		IsDepKey:               as.IsDepKey,
+		IsName:                 as.IsName,
 		DefaultValue:           as.DefaultValue,
 		Description:            as.Description,
 		Address:                as.Address.Copy(),
 		OriginForTarget:        as.OriginForTarget.Copy(),
 		SemanticTokenModifiers: as.SemanticTokenModifiers.Copy(),
 		CompletionHooks:        as.CompletionHooks.Copy(),
-		Constraint:             as.Constraint.Copy(),
+	}
+
+	if as.Constraint != nil {
+		newAs.Constraint = as.Constraint.Copy()
+	}
+
+	if as.RequiredWith != nil {
+		newAs.RequiredWith = make([]string, len(as.RequiredWith))
+		copy(newAs.RequiredWith, as.RequiredWith)
+	}
+
+	if as.ConflictsWith != nil {
+		newAs.ConflictsWith = make([]string, len(as.ConflictsWith))
+		copy(newAs.ConflictsWith, as.ConflictsWith)
 	}
 
 	return newAs
@@ -17,6 +17,15 @@ type BlockSchema struct {
 	Labels []*LabelSchema
 	Type   BlockType
 
+	// Aliases represents alternative block types under which
+	// the block may also be declared, such that a block declared
+	// under an alias is decoded using this schema.
+	//
+	// Completion only offers the canonical block type, i.e. the key
+	// under which the block is declared in BodySchema.Blocks,
+	// and hover displays the canonical block type as well.
+	Aliases []string
+
 	// SemanticTokenModifiers represents the semantic token modifiers
 	// to report for the block's type and labels
 	// (in addition to any modifiers of any parent blocks)
@@ -35,6 +44,19 @@ type BlockSchema struct {
 	MinItems     uint64
 	MaxItems     uint64
 
+	// LabelsUnique describes whether the name label of the block,
+	// i.e. the label marked as IsName or the last label otherwise,
+	// is expected to be unique among blocks of the same type
+	// which share all preceding labels.
+	//
+	// Completion of the name label then avoids names already in use
+	// and offers a generated unique name.
+	LabelsUnique bool
+
+	// ReplacedBy (if not empty) represents type of the block
+	// which replaces this (deprecated) block.
+	ReplacedBy string
+
 	Address *BlockAddrSchema
 }
 
@@ -169,11 +191,28 @@ func (*BlockSchema) isSchemaImpl() schemaImplSigil {
 func (bSchema *BlockSchema) Validate() error {
 	var errs *multierror.Error
 
+	nameLabels := 0
+	for _, label := range bSchema.Labels {
+		if label.IsName {
+			nameLabels++
+		}
+	}
+	if nameLabels > 1 {
+		errs = multierror.Append(errs, errors.New("Labels: only one label can be marked as IsName"))
+	}
+	if bSchema.Body.nameAttributeCount() > 1 {
+		errs = multierror.Append(errs, errors.New("Body: only one attribute can be marked as IsName"))
+	}
+
 	if bSchema.Address != nil {
 		err := bSchema.Address.Validate()
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("Address: %w", err))
 		}
+
+		if bSchema.Address.Steps.hasNameStep() && nameLabels == 0 && !bSchema.Body.hasNameAttribute() {
+			errs = multierror.Append(errs, errors.New("Address: NameStep requires a label or attribute marked as IsName"))
+		}
 	}
 
 	if bSchema.Body != nil {
@@ -199,13 +238,20 @@ func (bs *BlockSchema) Copy() *BlockSchema {
 		Type:                   bs.Type,
 		SemanticTokenModifiers: bs.SemanticTokenModifiers.Copy(),
 		IsDeprecated:           bs.IsDeprecated,
+		ReplacedBy:             bs.ReplacedBy,
 		MinItems:               bs.MinItems,
 		MaxItems:               bs.MaxItems,
+		LabelsUnique:           bs.LabelsUnique,
 		Description:            bs.Description,
 		Body:                   bs.Body.Copy(),
 		Address:                bs.Address.Copy(),
 	}
 
+	if bs.Aliases != nil {
+		newBs.Aliases = make([]string, len(bs.Aliases))
+		copy(newBs.Aliases, bs.Aliases)
+	}
+
 	if bs.Labels != nil {
 		newBs.Labels = make([]*LabelSchema, len(bs.Labels))
 		for i, label := range bs.Labels {
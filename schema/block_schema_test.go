@@ -7,6 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestBlockSchema_Validate(t *testing.T) {
@@ -63,6 +67,109 @@ func TestBlockSchema_Validate(t *testing.T) {
 			},
 			errors.New("Address: InferDependentBody requires DependentBodyAsData"),
 		},
+		{
+			&BlockSchema{
+				Body: &BodySchema{
+					Blocks: map[string]*BlockSchema{
+						"foo": {
+							Aliases: []string{"bar"},
+						},
+						"bar": {},
+					},
+				},
+			},
+			errors.New("Body: 1 error occurred:\n\t* foo: alias \"bar\" conflicts with block type\n\n"),
+		},
+		{
+			&BlockSchema{
+				Body: &BodySchema{
+					Blocks: map[string]*BlockSchema{
+						"bar": {
+							Aliases: []string{"baz"},
+						},
+						"foo": {
+							Aliases: []string{"baz"},
+						},
+					},
+				},
+			},
+			errors.New("Body: 1 error occurred:\n\t* foo: alias \"baz\" already used by bar\n\n"),
+		},
+		{
+			&BlockSchema{
+				Labels: []*LabelSchema{
+					{Name: "name", IsName: true},
+				},
+				Address: &BlockAddrSchema{
+					Steps: []AddrStep{
+						StaticStep{Name: "foo"},
+						NameStep{},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			&BlockSchema{
+				Address: &BlockAddrSchema{
+					Steps: []AddrStep{
+						StaticStep{Name: "foo"},
+						NameStep{},
+					},
+				},
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"name": {
+							IsRequired: true,
+							IsName:     true,
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			&BlockSchema{
+				Labels: []*LabelSchema{
+					{Name: "name"},
+				},
+				Address: &BlockAddrSchema{
+					Steps: []AddrStep{
+						StaticStep{Name: "foo"},
+						NameStep{},
+					},
+				},
+			},
+			errors.New("Address: NameStep requires a label or attribute marked as IsName"),
+		},
+		{
+			&BlockSchema{
+				Labels: []*LabelSchema{
+					{Name: "type", IsName: true},
+					{Name: "name", IsName: true},
+				},
+			},
+			errors.New("Labels: only one label can be marked as IsName"),
+		},
+		{
+			&BlockSchema{
+				Body: &BodySchema{
+					Attributes: map[string]*AttributeSchema{
+						"name": {
+							Constraint: LiteralType{Type: cty.String},
+							IsRequired: true,
+							IsName:     true,
+						},
+						"alias": {
+							Constraint: LiteralType{Type: cty.String},
+							IsOptional: true,
+							IsName:     true,
+						},
+					},
+				},
+			},
+			errors.New("Body: only one attribute can be marked as IsName"),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -80,3 +187,91 @@ func TestBlockSchema_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockSchema_Copy(t *testing.T) {
+	original := &BlockSchema{
+		Aliases: []string{"alias"},
+		Labels: []*LabelSchema{
+			{Name: "name", IsName: true},
+		},
+		LabelsUnique: true,
+		Body: &BodySchema{
+			Attributes: map[string]*AttributeSchema{
+				"obj": {
+					Constraint: Object{
+						Attributes: ObjectAttributes{
+							"list": {
+								Constraint: List{
+									Elem: OneOf{
+										LiteralType{Type: cty.String},
+										Keyword{Keyword: "foo"},
+									},
+								},
+							},
+						},
+					},
+				},
+				"no_constraint": {IsOptional: true},
+			},
+		},
+		DependentBody: map[SchemaKey]*BodySchema{
+			"key": {
+				Attributes: map[string]*AttributeSchema{
+					"dep": {Constraint: LiteralType{Type: cty.Bool}},
+				},
+			},
+		},
+	}
+	expected := &BlockSchema{
+		Aliases: []string{"alias"},
+		Labels: []*LabelSchema{
+			{Name: "name", IsName: true},
+		},
+		LabelsUnique: true,
+		Body: &BodySchema{
+			Attributes: map[string]*AttributeSchema{
+				"obj": {
+					Constraint: Object{
+						Attributes: ObjectAttributes{
+							"list": {
+								Constraint: List{
+									Elem: OneOf{
+										LiteralType{Type: cty.String},
+										Keyword{Keyword: "foo"},
+									},
+								},
+							},
+						},
+					},
+				},
+				"no_constraint": {IsOptional: true},
+			},
+		},
+		DependentBody: map[SchemaKey]*BodySchema{
+			"key": {
+				Attributes: map[string]*AttributeSchema{
+					"dep": {Constraint: LiteralType{Type: cty.Bool}},
+				},
+			},
+		},
+	}
+
+	copied := original.Copy()
+	if diff := cmp.Diff(expected, copied, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("unexpected copy: %s", diff)
+	}
+
+	// mutate the copy at all levels
+	copied.Aliases[0] = "changed"
+	copied.Labels[0].Name = "changed"
+	obj := copied.Body.Attributes["obj"].Constraint.(Object)
+	list := obj.Attributes["list"].Constraint.(List)
+	list.Elem.(OneOf)[1] = Keyword{Keyword: "bar"}
+	obj.Attributes["list"].IsRequired = true
+	obj.Attributes["new"] = &AttributeSchema{IsOptional: true}
+	copied.DependentBody["key"].Attributes["dep"].IsRequired = true
+
+	if diff := cmp.Diff(expected, original, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("original schema modified via copy: %s", diff)
+	}
+}
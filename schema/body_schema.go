@@ -53,13 +53,35 @@ type BodySchema struct {
 	Extensions *BodyExtensions
 }
 
+// BodyExtensions represents HCL extensions supported in a body.
+//
+// An attribute declared explicitly in BodySchema.Attributes takes
+// precedence over the count, for_each, provider or depends_on attribute implied
+// by an extension of the same name. References implied by the extension
+// (such as count.index) remain available regardless.
 type BodyExtensions struct {
 	Count         bool // count attribute + count.index refs
 	ForEach       bool // for_each attribute + each.* refs
 	DynamicBlocks bool // dynamic "block-name" w/ content & for_each inside
 	SelfRefs      bool // self.* refs
+	Provider      bool // provider attribute + refs to targets of ProviderScopeId
+	DependsOn     bool // depends_on attribute + refs to targets of ResourceScopeId or ModuleScopeId
+	Lifecycle     bool // lifecycle block w/ create_before_destroy, prevent_destroy etc. inside
 }
 
+const (
+	// ProviderScopeId represents the scope of provider alias reference targets
+	// (e.g. aws.west), which the provider attribute implied
+	// by BodyExtensions.Provider is expected to reference.
+	ProviderScopeId = lang.ScopeId("provider")
+
+	// ResourceScopeId and ModuleScopeId represent scopes of reference targets
+	// which elements of the depends_on attribute implied
+	// by BodyExtensions.DependsOn are expected to reference.
+	ResourceScopeId = lang.ScopeId("resource")
+	ModuleScopeId   = lang.ScopeId("module")
+)
+
 func (be *BodyExtensions) Copy() *BodyExtensions {
 	if be == nil {
 		return nil
@@ -70,6 +92,9 @@ func (be *BodyExtensions) Copy() *BodyExtensions {
 		ForEach:       be.ForEach,
 		DynamicBlocks: be.DynamicBlocks,
 		SelfRefs:      be.SelfRefs,
+		Provider:      be.Provider,
+		DependsOn:     be.DependsOn,
+		Lifecycle:     be.Lifecycle,
 	}
 }
 
@@ -126,6 +151,12 @@ func (bs *BodySchema) ToHCLSchema() *hcl.BodySchema {
 			Type:       blockType,
 			LabelNames: labelNames,
 		})
+		for _, alias := range block.Aliases {
+			blocks = append(blocks, hcl.BlockHeaderSchema{
+				Type:       alias,
+				LabelNames: labelNames,
+			})
+		}
 	}
 
 	return &hcl.BodySchema{
@@ -173,6 +204,21 @@ func (bs *BodySchema) Validate() error {
 		}
 	}
 
+	aliasedTypes := make(map[string]string, 0)
+	for _, bType := range bs.BlockTypes() {
+		for _, alias := range bs.Blocks[bType].Aliases {
+			if _, ok := bs.Blocks[alias]; ok {
+				result = multierror.Append(result, fmt.Errorf("%s: alias %q conflicts with block type", bType, alias))
+				continue
+			}
+			if otherType, ok := aliasedTypes[alias]; ok {
+				result = multierror.Append(result, fmt.Errorf("%s: alias %q already used by %s", bType, alias, otherType))
+				continue
+			}
+			aliasedTypes[alias] = bType
+		}
+	}
+
 	for bType, block := range bs.Blocks {
 		err := block.Validate()
 		if err != nil {
@@ -189,6 +235,23 @@ func (bs *BodySchema) Validate() error {
 	return result.ErrorOrNil()
 }
 
+func (bs *BodySchema) hasNameAttribute() bool {
+	return bs.nameAttributeCount() > 0
+}
+
+func (bs *BodySchema) nameAttributeCount() int {
+	if bs == nil {
+		return 0
+	}
+	count := 0
+	for _, attr := range bs.Attributes {
+		if attr.IsName {
+			count++
+		}
+	}
+	return count
+}
+
 func (bs *BodySchema) Copy() *BodySchema {
 	if bs == nil {
 		return nil
@@ -5,6 +5,10 @@ package schema
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/hcl-lang/lang"
 	"github.com/zclconf/go-cty/cty"
@@ -24,6 +28,28 @@ type Constraint interface {
 	EmptyCompletionData(ctx context.Context, nextPlaceholder int, nestingLevel int) CompletionData
 }
 
+// collectionFriendlyName returns a friendly name of a collection
+// with the given element constraint, recursing through any nested
+// collections, e.g. "list of map of string".
+//
+// Names of elements with alternatives (OneOf) are parenthesized
+// to keep the name unambiguous, e.g. "list of (string or number)".
+func collectionFriendlyName(collectionName string, elem Constraint) string {
+	if elem == nil {
+		return collectionName
+	}
+	elemName := elem.FriendlyName()
+	if elemName == "" {
+		return collectionName
+	}
+
+	if _, ok := elem.(OneOf); ok && strings.Contains(elemName, " or ") {
+		elemName = fmt.Sprintf("(%s)", elemName)
+	}
+
+	return fmt.Sprintf("%s of %s", collectionName, elemName)
+}
+
 type ConstraintWithHoverData interface {
 	// EmptyHoverData provides hover data in context where there is
 	// no corresponding configuration, such as when the Constraint
@@ -35,6 +61,67 @@ type ConstraintWithHoverData interface {
 	EmptyHoverData(nestingLevel int) *HoverData
 }
 
+// ConstraintWithDefaultSnippet represents a constraint which provides
+// a custom snippet to be inserted when completing an attribute value
+// constrained by it, in place of the generic (type-based) snippet
+// provided by EmptyCompletionData.
+//
+// Placeholders within the snippet, if any, are expected to start at ${1}.
+type ConstraintWithDefaultSnippet interface {
+	// DefaultSnippet returns the snippet and true, or false
+	// to fall back to the snippet of EmptyCompletionData.
+	DefaultSnippet() (string, bool)
+}
+
+// EmptyCompletionDataOf returns completion data of the given constraint,
+// i.e. its default snippet if it provides any
+// (see ConstraintWithDefaultSnippet), or its EmptyCompletionData otherwise.
+//
+// Placeholders of the default snippet are renumbered to start
+// at nextPlaceholder, such that the snippet can be nested
+// within snippets of other constraints.
+func EmptyCompletionDataOf(ctx context.Context, cons Constraint, nextPlaceholder int, nestingLevel int) CompletionData {
+	c, ok := cons.(ConstraintWithDefaultSnippet)
+	if !ok {
+		return cons.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+	}
+	snippet, ok := c.DefaultSnippet()
+	if !ok {
+		return cons.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+	}
+
+	lastPlaceholder := nextPlaceholder - 1
+	offset := nextPlaceholder - 1
+	snippet = snippetPlaceholderRegexp.ReplaceAllStringFunc(snippet, func(placeholder string) string {
+		m := snippetPlaceholderRegexp.FindStringSubmatch(placeholder)
+		idx, _ := strconv.Atoi(m[2])
+		idx += offset
+		if idx > lastPlaceholder {
+			lastPlaceholder = idx
+		}
+		return m[1] + strconv.Itoa(idx)
+	})
+
+	return CompletionData{
+		NewText:         snippetToText(snippet),
+		Snippet:         snippet,
+		NextPlaceholder: lastPlaceholder + 1,
+	}
+}
+
+var (
+	snippetPlaceholderRegexp = regexp.MustCompile(`(\$\{?)([0-9]+)`)
+	snippetDefaultRegexp     = regexp.MustCompile(`\$\{[0-9]+:([^}]*)\}`)
+	snippetTabstopRegexp     = regexp.MustCompile(`\$\{[0-9]+\}|\$[0-9]+`)
+)
+
+// snippetToText returns the text inserted by the given snippet
+// when all placeholders are left with their default values.
+func snippetToText(snippet string) string {
+	text := snippetDefaultRegexp.ReplaceAllString(snippet, "$1")
+	return snippetTabstopRegexp.ReplaceAllString(text, "")
+}
+
 type Validatable interface {
 	Validate() error
 }
@@ -33,10 +33,7 @@ func (List) isConstraintImpl() constraintSigil {
 }
 
 func (l List) FriendlyName() string {
-	if l.Elem != nil && l.Elem.FriendlyName() != "" {
-		return fmt.Sprintf("list of %s", l.Elem.FriendlyName())
-	}
-	return "list"
+	return collectionFriendlyName("list", l.Elem)
 }
 
 func (l List) Copy() Constraint {
@@ -61,7 +58,7 @@ func (l List) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nest
 		}
 	}
 
-	elemData := l.Elem.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+	elemData := EmptyCompletionDataOf(ctx, l.Elem, nextPlaceholder, nestingLevel)
 	if elemData.NewText == "" || elemData.Snippet == "" {
 		return CompletionData{
 			NewText:         "[ ]",
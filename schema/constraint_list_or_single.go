@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ListOrSingle represents either a single value or a list
+// of such values, e.g. x = "a" or x = ["a", "b"].
+//
+// It is a shorthand for OneOf{Elem, List{Elem: Elem}}
+// and behaves identically to it.
+type ListOrSingle struct {
+	// Elem defines constraint to apply to the single value
+	// and to each item of the list
+	Elem Constraint
+
+	// Description defines description of the list (affects hover)
+	Description lang.MarkupContent
+}
+
+func (ListOrSingle) isConstraintImpl() constraintSigil {
+	return constraintSigil{}
+}
+
+// OneOf returns the equivalent OneOf constraint
+func (ls ListOrSingle) OneOf() OneOf {
+	if ls.Elem == nil {
+		return OneOf{
+			List{Description: ls.Description},
+		}
+	}
+	return OneOf{
+		ls.Elem,
+		List{
+			Elem:        ls.Elem,
+			Description: ls.Description,
+		},
+	}
+}
+
+func (ls ListOrSingle) FriendlyName() string {
+	return ls.OneOf().FriendlyName()
+}
+
+func (ls ListOrSingle) Copy() Constraint {
+	var elem Constraint
+	if ls.Elem != nil {
+		elem = ls.Elem.Copy()
+	}
+	return ListOrSingle{
+		Elem:        elem,
+		Description: ls.Description,
+	}
+}
+
+func (ls ListOrSingle) Validate() error {
+	if ls.Elem == nil {
+		return errors.New("expected Elem not to be nil")
+	}
+	if c, ok := ls.Elem.(Validatable); ok {
+		return c.Validate()
+	}
+	return nil
+}
+
+func (ls ListOrSingle) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nestingLevel int) CompletionData {
+	return ls.OneOf().EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+}
+
+func (ls ListOrSingle) ConstraintType() (cty.Type, bool) {
+	return ls.OneOf().ConstraintType()
+}
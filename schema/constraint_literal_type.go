@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -24,11 +25,23 @@ import (
 // e.g. LiteralType{Type: cty.List(...)}.
 type LiteralType struct {
 	Type cty.Type
-	// TODO: object defaults
+
+	// Defaults (if not nil) represents default values of optional
+	// object attributes within Type, such as those declared
+	// via optional(string, "default") and obtained
+	// from typeexpr.TypeConstraintWithDefaults.
+	Defaults *typeexpr.Defaults
 
 	// SkipComplexTypes avoids descending into complex literal types, such as {} and [].
 	// It might be required when LiteralType is used in OneOf to avoid duplicates.
 	SkipComplexTypes bool
+
+	// Snippet (if not empty) represents a custom snippet to be inserted
+	// when completing a value, in place of the generic type-based one,
+	// e.g. "ami-${1:00000000}" for an AMI ID.
+	//
+	// Placeholders within the snippet are expected to start at ${1}.
+	Snippet string
 }
 
 func (LiteralType) isConstraintImpl() constraintSigil {
@@ -42,10 +55,16 @@ func (lt LiteralType) FriendlyName() string {
 func (lt LiteralType) Copy() Constraint {
 	return LiteralType{
 		Type:             lt.Type,
+		Defaults:         lt.Defaults,
 		SkipComplexTypes: lt.SkipComplexTypes,
+		Snippet:          lt.Snippet,
 	}
 }
 
+func (lt LiteralType) DefaultSnippet() (string, bool) {
+	return lt.Snippet, lt.Snippet != ""
+}
+
 func (lt LiteralType) Validate() error {
 	if lt.Type == cty.NilType {
 		return errors.New("expected Type not to be nil")
@@ -117,24 +136,8 @@ func (lt LiteralType) EmptyCompletionData(ctx context.Context, nextPlaceholder i
 		return tupleCons.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
 	}
 	if lt.Type.IsObjectType() {
-		attrTypes := lt.Type.AttributeTypes()
-		attrs := make(ObjectAttributes, 0)
-		for name, attrType := range attrTypes {
-			aSchema := &AttributeSchema{
-				Constraint: LiteralType{
-					Type: attrType,
-				},
-			}
-			if lt.Type.AttributeOptional(name) {
-				aSchema.IsOptional = true
-			} else {
-				aSchema.IsRequired = true
-			}
-
-			attrs[name] = aSchema
-		}
 		cons := Object{
-			Attributes: attrs,
+			Attributes: ObjectAttributesForType(lt.Type, lt.Defaults),
 		}
 		return cons.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
 	}
@@ -144,6 +147,47 @@ func (lt LiteralType) EmptyCompletionData(ctx context.Context, nextPlaceholder i
 	}
 }
 
+// ObjectAttributesForType returns attributes of the given object type
+// as ObjectAttributes, where attributes declared as optional
+// are marked as optional and any other attributes as required.
+//
+// Defaults (if not nil) are used to populate default values
+// of optional attributes and are passed down to nested attributes.
+func ObjectAttributesForType(objType cty.Type, defaults *typeexpr.Defaults) ObjectAttributes {
+	attrTypes := objType.AttributeTypes()
+	attrs := make(ObjectAttributes, len(attrTypes))
+
+	for name, attrType := range attrTypes {
+		aSchema := &AttributeSchema{
+			Constraint: LiteralType{
+				Type:     attrType,
+				Defaults: childDefaults(defaults, name),
+			},
+		}
+		if objType.AttributeOptional(name) {
+			aSchema.IsOptional = true
+			if defaults != nil {
+				if val, ok := defaults.DefaultValues[name]; ok {
+					aSchema.DefaultValue = DefaultValue{Value: val}
+				}
+			}
+		} else {
+			aSchema.IsRequired = true
+		}
+
+		attrs[name] = aSchema
+	}
+
+	return attrs
+}
+
+func childDefaults(defaults *typeexpr.Defaults, key string) *typeexpr.Defaults {
+	if defaults == nil {
+		return nil
+	}
+	return defaults.Children[key]
+}
+
 func (lt LiteralType) EmptyHoverData(nestingLevel int) *HoverData {
 	if lt.Type.IsPrimitiveType() {
 		return &HoverData{
@@ -188,24 +232,8 @@ func (lt LiteralType) EmptyHoverData(nestingLevel int) *HoverData {
 		return cons.EmptyHoverData(nestingLevel)
 	}
 	if lt.Type.IsObjectType() {
-		attrTypes := lt.Type.AttributeTypes()
-		attrs := make(ObjectAttributes, 0)
-		for name, attrType := range attrTypes {
-			aSchema := &AttributeSchema{
-				Constraint: LiteralType{
-					Type: attrType,
-				},
-			}
-			if lt.Type.AttributeOptional(name) {
-				aSchema.IsOptional = true
-			} else {
-				aSchema.IsRequired = true
-			}
-
-			attrs[name] = aSchema
-		}
 		cons := Object{
-			Attributes: attrs,
+			Attributes: ObjectAttributesForType(lt.Type, lt.Defaults),
 		}
 		return cons.EmptyHoverData(nestingLevel)
 	}
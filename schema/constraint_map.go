@@ -42,10 +42,7 @@ func (Map) isConstraintImpl() constraintSigil {
 
 func (m Map) FriendlyName() string {
 	if m.Name == "" {
-		if m.Elem != nil && m.Elem.FriendlyName() != "" {
-			return fmt.Sprintf("map of %s", m.Elem.FriendlyName())
-		}
-		return "map"
+		return collectionFriendlyName("map", m.Elem)
 	}
 	return m.Name
 }
@@ -77,7 +74,7 @@ func (m Map) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nesti
 		}
 	}
 
-	elemData := m.Elem.EmptyCompletionData(ctx, nextPlaceholder+1, nestingLevel+1)
+	elemData := EmptyCompletionDataOf(ctx, m.Elem, nextPlaceholder+1, nestingLevel+1)
 	if elemData.NewText == "" || elemData.Snippet == "" {
 		return CompletionData{
 			NewText:         fmt.Sprintf("{\n%s\n%s}", insideNesting, rootNesting),
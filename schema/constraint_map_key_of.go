@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MapKeyOf represents a string which must be one of the keys
+// of a map (or object) reference target, e.g. a key of var.regions.
+//
+// Keys are read from nested targets of the target at Address
+// and are only known if the target's value is statically declared.
+type MapKeyOf struct {
+	// Address represents the address of the map target
+	Address lang.Address
+
+	// Description defines description of the value
+	Description lang.MarkupContent
+}
+
+func (MapKeyOf) isConstraintImpl() constraintSigil {
+	return constraintSigil{}
+}
+
+func (mk MapKeyOf) FriendlyName() string {
+	return fmt.Sprintf("key of %s", mk.Address.String())
+}
+
+func (mk MapKeyOf) Copy() Constraint {
+	return MapKeyOf{
+		Address:     mk.Address.Copy(),
+		Description: mk.Description,
+	}
+}
+
+func (mk MapKeyOf) Validate() error {
+	if len(mk.Address) == 0 {
+		return errors.New("Address: expected non-empty address")
+	}
+	return nil
+}
+
+func (mk MapKeyOf) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nestingLevel int) CompletionData {
+	return CompletionData{
+		TriggerSuggest:  true,
+		NextPlaceholder: nextPlaceholder,
+	}
+}
+
+func (mk MapKeyOf) ConstraintType() (cty.Type, bool) {
+	return cty.String, true
+}
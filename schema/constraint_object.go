@@ -108,7 +108,7 @@ func (o Object) attributesCompletionData(ctx context.Context, placeholder, nesti
 
 	for _, name := range attrNames {
 		attr := o.Attributes[name]
-		attrData := attr.Constraint.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel+1)
+		attrData := EmptyCompletionDataOf(ctx, attr.Constraint, nextPlaceholder, nestingLevel+1)
 		if attrData.NewText == "" || attrData.Snippet == "" {
 			return CompletionData{}, false
 		}
@@ -169,6 +169,9 @@ func (o Object) EmptyHoverData(nestingLevel int) *HoverData {
 		attrFlags := []string{}
 		if attr.IsOptional {
 			attrFlags = append(attrFlags, "optional")
+			if defaultData := defaultValueHoverData(attr.DefaultValue); defaultData != "" {
+				attrFlags = append(attrFlags, fmt.Sprintf("default: %s", defaultData))
+			}
 		}
 		if attr.IsSensitive {
 			attrFlags = append(attrFlags, "sensitive")
@@ -192,6 +195,21 @@ func (o Object) EmptyHoverData(nestingLevel int) *HoverData {
 	}
 }
 
+// defaultValueHoverData returns single-line representation
+// of the given default value of a primitive type, if any
+func defaultValueHoverData(def Default) string {
+	dv, ok := def.(DefaultValue)
+	if !ok || dv.Value.Type() == cty.NilType || dv.Value.IsNull() || !dv.Value.IsKnown() || !dv.Value.Type().IsPrimitiveType() {
+		return ""
+	}
+
+	hoverData := LiteralValue{Value: dv.Value}.EmptyHoverData(1)
+	if hoverData == nil {
+		return ""
+	}
+	return hoverData.Content.Value
+}
+
 func sortedObjectExprAttrNames(attributes ObjectAttributes) []string {
 	if len(attributes) == 0 {
 		return []string{}
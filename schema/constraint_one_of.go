@@ -85,7 +85,7 @@ func (o OneOf) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nes
 		}
 	}
 
-	cData := o[0].EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+	cData := EmptyCompletionDataOf(ctx, o[0], nextPlaceholder, nestingLevel)
 
 	return CompletionData{
 		NewText:         cData.NewText,
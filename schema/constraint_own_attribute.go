@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+)
+
+// OwnAttribute represents a reference to an attribute of the enclosing
+// block, addressed by its name without any prefix and optionally
+// followed by steps into its value, e.g. tags or tags["Name"]
+// in ignore_changes = [tags["Name"]].
+type OwnAttribute struct {
+	// Attributes defines attributes which can be referenced
+	Attributes map[string]*AttributeSchema
+
+	// Name overrides friendly name of the constraint
+	Name string
+}
+
+func (OwnAttribute) isConstraintImpl() constraintSigil {
+	return constraintSigil{}
+}
+
+func (oa OwnAttribute) FriendlyName() string {
+	if oa.Name == "" {
+		return "attribute reference"
+	}
+	return oa.Name
+}
+
+func (oa OwnAttribute) Copy() Constraint {
+	var attrs map[string]*AttributeSchema
+	if oa.Attributes != nil {
+		attrs = make(map[string]*AttributeSchema, len(oa.Attributes))
+		for name, attr := range oa.Attributes {
+			attrs[name] = attr.Copy()
+		}
+	}
+	return OwnAttribute{
+		Attributes: attrs,
+		Name:       oa.Name,
+	}
+}
+
+func (oa OwnAttribute) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nestingLevel int) CompletionData {
+	return CompletionData{
+		TriggerSuggest:  true,
+		NextPlaceholder: nextPlaceholder,
+	}
+}
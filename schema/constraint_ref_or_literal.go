@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"context"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// RefOrLiteral represents a reference to a target of the given type
+// or a literal value of that type.
+//
+// It is a shorthand for OneOf{Reference{OfType: Type}, LiteralType{Type: Type}}
+// and behaves identically to it.
+type RefOrLiteral struct {
+	Type cty.Type
+}
+
+func (RefOrLiteral) isConstraintImpl() constraintSigil {
+	return constraintSigil{}
+}
+
+// OneOf returns the equivalent OneOf constraint
+func (rl RefOrLiteral) OneOf() OneOf {
+	return OneOf{
+		Reference{OfType: rl.Type},
+		LiteralType{Type: rl.Type},
+	}
+}
+
+func (rl RefOrLiteral) FriendlyName() string {
+	return rl.OneOf().FriendlyName()
+}
+
+func (rl RefOrLiteral) Copy() Constraint {
+	return RefOrLiteral{
+		Type: rl.Type,
+	}
+}
+
+func (rl RefOrLiteral) Validate() error {
+	return rl.OneOf().Validate()
+}
+
+func (rl RefOrLiteral) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nestingLevel int) CompletionData {
+	return rl.OneOf().EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+}
+
+func (rl RefOrLiteral) ConstraintType() (cty.Type, bool) {
+	return rl.OneOf().ConstraintType()
+}
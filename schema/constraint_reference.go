@@ -30,6 +30,26 @@ type Reference struct {
 	//
 	// Only one of Address or OfScopeId/OfType can be declared
 	Address *ReferenceAddrSchema
+
+	// RequiredPath (if not empty) requires the reference to end
+	// with the given steps, e.g. an output name in module.foo.output_name.
+	//
+	// Only targets which can satisfy the path are considered
+	// matching during completion.
+	RequiredPath lang.Address
+
+	// OfBlockType (if not empty) requires the reference to point
+	// to a target whose address root matches the given block type,
+	// e.g. aws_instance in aws_instance.foo.id.
+	//
+	// Only targets of the given block type are considered
+	// matching during completion.
+	OfBlockType string
+
+	// IsTargetOptional indicates that the reference may point to
+	// a target outside of the analyzed configuration, and so
+	// it should not be reported as undefined if no target is found.
+	IsTargetOptional bool
 }
 
 type ReferenceAddrSchema struct {
@@ -62,10 +82,13 @@ func (ref Reference) FriendlyName() string {
 
 func (ref Reference) Copy() Constraint {
 	return Reference{
-		OfScopeId: ref.OfScopeId,
-		OfType:    ref.OfType,
-		Name:      ref.Name,
-		Address:   ref.Address.Copy(),
+		OfScopeId:        ref.OfScopeId,
+		OfType:           ref.OfType,
+		Name:             ref.Name,
+		Address:          ref.Address.Copy(),
+		RequiredPath:     ref.RequiredPath.Copy(),
+		OfBlockType:      ref.OfBlockType,
+		IsTargetOptional: ref.IsTargetOptional,
 	}
 }
 
@@ -84,6 +107,12 @@ func (ref Reference) Validate() error {
 	if ref.Address != nil && ref.Address.ScopeId == "" {
 		return errors.New("Address requires non-empty ScopeId")
 	}
+	if ref.Address != nil && len(ref.RequiredPath) > 0 {
+		return errors.New("cannot have both Address and RequiredPath set")
+	}
+	if ref.Address != nil && ref.OfBlockType != "" {
+		return errors.New("cannot have both Address and OfBlockType set")
+	}
 	if ref.OfType == cty.NilType && ref.OfScopeId == "" && ref.Address == nil {
 		return errors.New("one of OfType, OfScopeId and Address is required")
 	}
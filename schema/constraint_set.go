@@ -33,10 +33,7 @@ func (Set) isConstraintImpl() constraintSigil {
 }
 
 func (s Set) FriendlyName() string {
-	if s.Elem != nil && s.Elem.FriendlyName() != "" {
-		return fmt.Sprintf("set of %s", s.Elem.FriendlyName())
-	}
-	return "set"
+	return collectionFriendlyName("set", s.Elem)
 }
 
 func (s Set) Copy() Constraint {
@@ -61,7 +58,7 @@ func (s Set) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nesti
 		}
 	}
 
-	elemData := s.Elem.EmptyCompletionData(ctx, nextPlaceholder, nestingLevel)
+	elemData := EmptyCompletionDataOf(ctx, s.Elem, nextPlaceholder, nestingLevel)
 	if elemData.NewText == "" || elemData.Snippet == "" {
 		return CompletionData{
 			NewText:         "[ ]",
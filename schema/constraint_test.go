@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/zclconf/go-cty/cty"
 )
 
@@ -17,10 +18,12 @@ var (
 	_ Constraint = AnyExpression{}
 	_ Constraint = Keyword{}
 	_ Constraint = List{}
+	_ Constraint = ListOrSingle{}
 	_ Constraint = LiteralType{}
 	_ Constraint = LiteralValue{}
 	_ Constraint = Map{}
 	_ Constraint = Object{}
+	_ Constraint = OwnAttribute{}
 	_ Constraint = Set{}
 	_ Constraint = Reference{}
 	_ Constraint = Tuple{}
@@ -36,6 +39,7 @@ var (
 
 	_ TypeAwareConstraint = AnyExpression{}
 	_ TypeAwareConstraint = List{}
+	_ TypeAwareConstraint = ListOrSingle{}
 	_ TypeAwareConstraint = LiteralType{}
 	_ TypeAwareConstraint = LiteralValue{}
 	_ TypeAwareConstraint = Map{}
@@ -315,6 +319,23 @@ tomap({
 			},
 			nil,
 		},
+		{
+			LiteralType{
+				Type: cty.ObjectWithOptionalAttrs(map[string]cty.Type{
+					"name": cty.String,
+					"port": cty.Number,
+					"tags": cty.List(cty.String),
+				}, []string{"port", "tags"}),
+				Defaults: &typeexpr.Defaults{
+					DefaultValues: map[string]cty.Value{
+						"port": cty.NumberIntVal(8080),
+					},
+				},
+			},
+			&HoverData{
+				Content: lang.Markdown("```\n{\n  name = string\n  port = number # optional, default: 8080\n  tags = list(string) # optional\n}\n```\n"),
+			},
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
@@ -869,3 +890,98 @@ STRING
 		})
 	}
 }
+
+func TestEmptyCompletionDataOf(t *testing.T) {
+	testCases := []struct {
+		cons             Constraint
+		nextPlaceholder  int
+		expectedCompData CompletionData
+	}{
+		{
+			LiteralType{Type: cty.String},
+			1,
+			CompletionData{
+				NewText:         `"value"`,
+				Snippet:         `"${1:value}"`,
+				NextPlaceholder: 2,
+			},
+		},
+		{
+			LiteralType{
+				Type:    cty.String,
+				Snippet: `"ami-${1:00000000}"`,
+			},
+			1,
+			CompletionData{
+				NewText:         `"ami-00000000"`,
+				Snippet:         `"ami-${1:00000000}"`,
+				NextPlaceholder: 2,
+			},
+		},
+		{
+			LiteralType{
+				Type:    cty.String,
+				Snippet: `"${1:eu}-${2:west}-$3"`,
+			},
+			3,
+			CompletionData{
+				NewText:         `"eu-west-"`,
+				Snippet:         `"${3:eu}-${4:west}-$5"`,
+				NextPlaceholder: 6,
+			},
+		},
+		{
+			Object{
+				Attributes: ObjectAttributes{
+					"ami": &AttributeSchema{
+						IsRequired: true,
+						Constraint: LiteralType{
+							Type:    cty.String,
+							Snippet: `"ami-${1:00000000}"`,
+						},
+					},
+					"name": &AttributeSchema{
+						IsRequired: true,
+						Constraint: LiteralType{Type: cty.String},
+					},
+				},
+			},
+			1,
+			CompletionData{
+				NewText: `{
+  ami = "ami-00000000"
+  name = "value"
+}`,
+				Snippet: `{
+  ami = "ami-${1:00000000}"
+  name = "${2:value}"
+}`,
+				NextPlaceholder: 3,
+			},
+		},
+		{
+			List{
+				Elem: LiteralType{
+					Type:    cty.String,
+					Snippet: `"ami-${1:00000000}"`,
+				},
+			},
+			1,
+			CompletionData{
+				NewText:         `[ "ami-00000000" ]`,
+				Snippet:         `[ "ami-${1:00000000}" ]`,
+				NextPlaceholder: 2,
+			},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%2d", i), func(t *testing.T) {
+			ctx := context.Background()
+			ctx = WithPrefillRequiredFields(ctx, true)
+			data := EmptyCompletionDataOf(ctx, tc.cons, tc.nextPlaceholder, 0)
+			if diff := cmp.Diff(tc.expectedCompData, data); diff != "" {
+				t.Fatalf("unexpected completion data: %s", diff)
+			}
+		})
+	}
+}
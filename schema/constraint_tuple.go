@@ -59,7 +59,7 @@ func (t Tuple) EmptyCompletionData(ctx context.Context, nextPlaceholder int, nes
 	lastPlaceholder := nextPlaceholder
 
 	for i, elem := range t.Elems {
-		cData := elem.EmptyCompletionData(ctx, lastPlaceholder, nestingLevel)
+		cData := EmptyCompletionDataOf(ctx, elem, lastPlaceholder, nestingLevel)
 		if cData.NewText == "" || cData.Snippet == "" {
 			return CompletionData{
 				NewText:         "[ ]",
@@ -16,3 +16,16 @@ func WithActiveSelfRefs(ctx context.Context) context.Context {
 func ActiveSelfRefsFromContext(ctx context.Context) bool {
 	return ctx.Value(bodyActiveSelfRefsCtxKey{}) != nil
 }
+
+type functionSignaturesCtxKey struct{}
+
+// WithFunctionSignatures attaches signatures of functions available
+// in the path being processed, e.g. to make them available to validators.
+func WithFunctionSignatures(ctx context.Context, signatures map[string]FunctionSignature) context.Context {
+	return context.WithValue(ctx, functionSignaturesCtxKey{}, signatures)
+}
+
+func FunctionSignaturesFromContext(ctx context.Context) (map[string]FunctionSignature, bool) {
+	signatures, ok := ctx.Value(functionSignaturesCtxKey{}).(map[string]FunctionSignature)
+	return signatures, ok
+}
@@ -20,10 +20,23 @@ type LabelSchema struct {
 	// when looking up dependent schema
 	IsDepKey bool
 
+	// IsName describes whether the label represents the name
+	// of the block, which is used to resolve NameStep
+	// within the block's address.
+	IsName bool
+
 	// In cases where label's IsDepKey=true any DependentKey label values
 	// within Blocks's DependentBody can be used for completion
 	// This enables such behaviour.
 	Completable bool
+
+	// AllowedValues (if not empty) represents the values allowed
+	// for the label, which are offered in completion.
+	AllowedValues []string
+
+	// IsIdentifier describes whether the label value must be a valid
+	// HCL identifier, e.g. a resource name (see InvalidLabelIdentifier).
+	IsIdentifier bool
 }
 
 func (*LabelSchema) isSchemaImpl() schemaImplSigil {
@@ -35,11 +48,20 @@ func (ls *LabelSchema) Copy() *LabelSchema {
 		return nil
 	}
 
-	return &LabelSchema{
+	newLs := &LabelSchema{
 		Name:                   ls.Name,
 		SemanticTokenModifiers: ls.SemanticTokenModifiers.Copy(),
 		Completable:            ls.Completable,
 		Description:            ls.Description,
 		IsDepKey:               ls.IsDepKey,
+		IsName:                 ls.IsName,
+		IsIdentifier:           ls.IsIdentifier,
 	}
+
+	if ls.AllowedValues != nil {
+		newLs.AllowedValues = make([]string, len(ls.AllowedValues))
+		copy(newLs.AllowedValues, ls.AllowedValues)
+	}
+
+	return newLs
 }
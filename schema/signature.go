@@ -25,3 +25,49 @@ type FunctionSignature struct {
 	// parameter if it is supported.
 	VarParam *function.Parameter
 }
+
+// FunctionSignatureFromCty derives a signature from the given go-cty
+// function, so that functions already implemented via go-cty
+// do not need to be described separately.
+//
+// The description of the function and its parameters is sourced
+// from the function's own spec (Function.Description() and
+// Parameter.Description). Functions which do not carry any
+// descriptions can be given some via function.WithNewDescriptions.
+//
+// ReturnType is derived by calling the function's type callback
+// with the declared types of all fixed parameters and falls back to
+// cty.DynamicPseudoType if the type cannot be determined that way.
+//
+// go-cty has no concept of optional parameters beyond the variadic
+// one (VarParam), which is retained as-is, along with parameters'
+// AllowNull and similar flags.
+func FunctionSignatureFromCty(f function.Function) FunctionSignature {
+	params := f.Params()
+
+	argTypes := make([]cty.Type, len(params))
+	for i, param := range params {
+		argTypes[i] = param.Type
+	}
+	returnType, err := f.ReturnType(argTypes)
+	if err != nil || returnType == cty.NilType {
+		returnType = cty.DynamicPseudoType
+	}
+
+	return FunctionSignature{
+		Description: f.Description(),
+		ReturnType:  returnType,
+		Params:      params,
+		VarParam:    f.VarParam(),
+	}
+}
+
+// FunctionSignaturesFromCty derives signatures of all given go-cty functions
+// via FunctionSignatureFromCty.
+func FunctionSignaturesFromCty(funcs map[string]function.Function) map[string]FunctionSignature {
+	signatures := make(map[string]FunctionSignature, len(funcs))
+	for name, f := range funcs {
+		signatures[name] = FunctionSignatureFromCty(f)
+	}
+	return signatures
+}
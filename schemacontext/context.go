@@ -52,3 +52,68 @@ func BlockNestingLevel(ctx context.Context) (uint64, bool) {
 	lvl, ok := ctx.Value(blockNestingLevelCtxKey{}).(uint64)
 	return lvl, ok
 }
+
+type schemaPathCtxKey struct{}
+
+// SchemaPath represents the location of a node within the schema,
+// i.e. types of the enclosing blocks and the attribute name (if any).
+type SchemaPath struct {
+	// BlockTypes represents types of the enclosing blocks,
+	// from the outermost to the innermost one
+	BlockTypes []string
+
+	// AttributeName represents the name of the attribute,
+	// or is empty if the node is not (within) an attribute
+	AttributeName string
+}
+
+// WithBlockType returns a copy of the path with the given
+// block type appended to BlockTypes.
+func (sp SchemaPath) WithBlockType(blockType string) SchemaPath {
+	blockTypes := make([]string, len(sp.BlockTypes), len(sp.BlockTypes)+1)
+	copy(blockTypes, sp.BlockTypes)
+
+	return SchemaPath{
+		BlockTypes: append(blockTypes, blockType),
+	}
+}
+
+// WithAttributeName returns a copy of the path with the given
+// attribute name.
+func (sp SchemaPath) WithAttributeName(name string) SchemaPath {
+	blockTypes := make([]string, len(sp.BlockTypes))
+	copy(blockTypes, sp.BlockTypes)
+
+	return SchemaPath{
+		BlockTypes:    blockTypes,
+		AttributeName: name,
+	}
+}
+
+// WithSchemaPath attaches the schema path of the node being
+// decoded (validated, completed etc.) to the context.
+func WithSchemaPath(ctx context.Context, path SchemaPath) context.Context {
+	return context.WithValue(ctx, schemaPathCtxKey{}, path)
+}
+
+// FromContext returns the schema path of the node being
+// decoded, such that e.g. validators can tell where in the tree
+// the visited node is.
+func FromContext(ctx context.Context) (SchemaPath, bool) {
+	path, ok := ctx.Value(schemaPathCtxKey{}).(SchemaPath)
+	return path, ok
+}
+
+type fileBytesCtxKey struct{}
+
+// WithFileBytes attaches the source of the file being decoded
+// to the context, such that e.g. validators can inspect raw
+// configuration which is not represented in the AST.
+func WithFileBytes(ctx context.Context, b []byte) context.Context {
+	return context.WithValue(ctx, fileBytesCtxKey{}, b)
+}
+
+func FileBytes(ctx context.Context) ([]byte, bool) {
+	b, ok := ctx.Value(fileBytesCtxKey{}).([]byte)
+	return b, ok
+}
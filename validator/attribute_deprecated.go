@@ -30,10 +30,18 @@ func (v DeprecatedAttribute) Visit(ctx context.Context, node hclsyntax.Node, nod
 		diags = append(diags, &hcl.Diagnostic{
 			Severity: hcl.DiagWarning,
 			Summary:  fmt.Sprintf("%q is deprecated", attr.Name),
-			Detail:   fmt.Sprintf("Reason: %q", attrSchema.Description.Value),
+			Detail:   deprecationDetail(attrSchema.Description.Value, attrSchema.ReplacedBy),
 			Subject:  attr.SrcRange.Ptr(),
 		})
 	}
 
 	return ctx, diags
 }
+
+func deprecationDetail(reason, replacement string) string {
+	detail := fmt.Sprintf("Reason: %q", reason)
+	if replacement != "" {
+		detail += fmt.Sprintf(". Use %q instead", replacement)
+	}
+	return detail
+}
@@ -32,7 +32,7 @@ func (v MissingRequiredAttribute) Visit(ctx context.Context, node hclsyntax.Node
 	}
 
 	for name, attr := range bodySchema.Attributes {
-		if attr.IsRequired {
+		if attr.IsRequired && !attr.IsCompletionOnly {
 			_, ok := body.Attributes[name]
 			if !ok {
 				diags = append(diags, &hcl.Diagnostic{
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// NonEmptyAttribute reports empty string values (including
+// empty heredocs) of attributes which disallow them.
+//
+// Only literal strings are checked, i.e. interpolated strings
+// and references are skipped.
+type NonEmptyAttribute struct{}
+
+func (v NonEmptyAttribute) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if !attrSchema.DisallowEmpty {
+		return ctx, diags
+	}
+
+	expr, ok := attr.Expr.(*hclsyntax.TemplateExpr)
+	if !ok || !expr.IsStringLiteral() {
+		return ctx, diags
+	}
+	litExpr, ok := expr.Parts[0].(*hclsyntax.LiteralValueExpr)
+	if !ok || !litExpr.Val.IsKnown() || litExpr.Val.IsNull() ||
+		litExpr.Val.Type() != cty.String || litExpr.Val.AsString() != "" {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid empty value",
+		Detail:   fmt.Sprintf("Attribute %q cannot be empty", attr.Name),
+		Subject:  expr.SrcRange.Ptr(),
+	})
+
+	return ctx, diags
+}
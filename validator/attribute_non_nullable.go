@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// NonNullableAttribute reports null values
+// of attributes which are not nullable.
+type NonNullableAttribute struct{}
+
+func (v NonNullableAttribute) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if attrSchema.IsNullable {
+		return ctx, diags
+	}
+
+	expr, ok := attr.Expr.(*hclsyntax.LiteralValueExpr)
+	if !ok || !expr.Val.IsKnown() || !expr.Val.IsNull() {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid null value",
+		Detail:   fmt.Sprintf("Attribute %q cannot be null", attr.Name),
+		Subject:  expr.SrcRange.Ptr(),
+	})
+
+	return ctx, diags
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/internal/syntaxhelper"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// AttributeRequiredWith reports specified attributes whose companion
+// attributes (as declared via RequiredWith) are missing.
+type AttributeRequiredWith struct{}
+
+func (v AttributeRequiredWith) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	body, ok := node.(*hclsyntax.Body)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+
+	bodySchema := nodeSchema.(*schema.BodySchema)
+	if bodySchema.Attributes == nil {
+		return ctx, diags
+	}
+
+	for _, attr := range syntaxhelper.SortedBodyAttributes(body) {
+		aSchema, ok := bodySchema.Attributes[attr.Name]
+		if !ok || aSchema.IsCompletionOnly {
+			continue
+		}
+
+		for _, name := range aSchema.RequiredWith {
+			if _, ok := body.Attributes[name]; ok {
+				continue
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Missing attribute %q", name),
+				Detail:   fmt.Sprintf("Attribute %q requires %q to be specified as well", attr.Name, name),
+				Subject:  attr.SrcRange.Ptr(),
+			})
+		}
+	}
+
+	return ctx, diags
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// InvalidLabelIdentifier reports block labels which are expected
+// to be identifiers (LabelSchema.IsIdentifier) but do not match
+// the HCL identifier grammar, e.g. 123abc or names with spaces.
+type InvalidLabelIdentifier struct{}
+
+func (v InvalidLabelIdentifier) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	block, ok := node.(*hclsyntax.Block)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+
+	blockSchema := nodeSchema.(*schema.BlockSchema)
+
+	for i, label := range block.Labels {
+		if i >= len(blockSchema.Labels) {
+			// superfluous labels are reported by BlockLabelsLength
+			break
+		}
+		labelSchema := blockSchema.Labels[i]
+		if labelSchema == nil || !labelSchema.IsIdentifier {
+			continue
+		}
+		if hclsyntax.ValidIdentifier(label) {
+			continue
+		}
+
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid label identifier",
+			Detail: fmt.Sprintf("Label %q of %q is not a valid identifier. An identifier must start with "+
+				"a letter or underscore and may contain only letters, digits, underscores, and dashes.",
+				label, block.Type),
+			Subject: block.LabelRanges[i].Ptr(),
+		})
+	}
+
+	return ctx, diags
+}
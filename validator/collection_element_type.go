@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// CollectionElementType reports elements of List, Set, Tuple
+// and ListOrSingle expressions which do not match the primitive type
+// required by the LiteralType element constraint, or which
+// are literal values where the element constraint requires a reference.
+//
+// Only literal elements are checked, i.e. references, function
+// calls and other elements of unknown value are skipped.
+type CollectionElementType struct {
+	// ReportSetDuplicates enables reporting of duplicate
+	// literal elements of Set expressions as warnings.
+	ReportSetDuplicates bool
+}
+
+func (v CollectionElementType) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+
+	expr, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return ctx, diags
+	}
+
+	isSet := false
+	var elemConstraint func(i int) schema.Constraint
+	switch cons := attrSchema.Constraint.(type) {
+	case schema.List:
+		elemConstraint = func(int) schema.Constraint { return cons.Elem }
+	case schema.ListOrSingle:
+		elemConstraint = func(int) schema.Constraint { return cons.Elem }
+	case schema.Set:
+		isSet = true
+		elemConstraint = func(int) schema.Constraint { return cons.Elem }
+	case schema.Tuple:
+		elemConstraint = func(i int) schema.Constraint {
+			if i < len(cons.Elems) {
+				return cons.Elems[i]
+			}
+			return nil
+		}
+	default:
+		return ctx, diags
+	}
+
+	values := make(map[int]cty.Value, len(expr.Exprs))
+	for i, elemExpr := range expr.Exprs {
+		val, ok := literalElementValue(elemExpr)
+		if !ok {
+			continue
+		}
+		values[i] = val
+
+		if isReferenceConstraint(elemConstraint(i)) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid element type",
+				Detail:   fmt.Sprintf("Element %d of %q is a literal value, expected a reference", i, attr.Name),
+				Subject:  elemExpr.Range().Ptr(),
+			})
+			continue
+		}
+
+		litType, ok := elemConstraint(i).(schema.LiteralType)
+		if !ok || !litType.Type.IsPrimitiveType() {
+			continue
+		}
+		if val.Type().Equals(litType.Type) {
+			continue
+		}
+
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid element type",
+			Detail: fmt.Sprintf("Element %d of %q is of type %s, expected %s",
+				i, attr.Name, val.Type().FriendlyName(), litType.Type.FriendlyNameForConstraint()),
+			Subject: elemExpr.Range().Ptr(),
+		})
+	}
+
+	if isSet && v.ReportSetDuplicates {
+		for i := range expr.Exprs {
+			val, ok := values[i]
+			if !ok {
+				continue
+			}
+			for j := 0; j < i; j++ {
+				prevVal, ok := values[j]
+				if !ok || !prevVal.RawEquals(val) {
+					continue
+				}
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Duplicate set element",
+					Detail:   fmt.Sprintf("Element %d of %q duplicates element %d", i, attr.Name, j),
+					Subject:  expr.Exprs[i].Range().Ptr(),
+				})
+				break
+			}
+		}
+	}
+
+	return ctx, diags
+}
+
+// isReferenceConstraint returns true if the given constraint
+// only permits references, i.e. it is a Reference constraint
+// or OneOf consisting exclusively of Reference constraints.
+func isReferenceConstraint(cons schema.Constraint) bool {
+	switch c := cons.(type) {
+	case schema.Reference:
+		return true
+	case schema.OneOf:
+		if len(c) == 0 {
+			return false
+		}
+		for _, oc := range c {
+			if !isReferenceConstraint(oc) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// literalElementValue returns the value of the given element expression
+// if it is a known non-null literal value.
+func literalElementValue(expr hclsyntax.Expression) (cty.Value, bool) {
+	if len(expr.Variables()) > 0 {
+		return cty.NilVal, false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() {
+		return cty.NilVal, false
+	}
+	return val, true
+}
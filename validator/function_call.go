@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FunctionCall reports calls of known functions with the wrong number
+// of arguments, or with arguments of a type not convertible to the type
+// of the corresponding parameter.
+//
+// Arguments whose type cannot be determined statically (such as references)
+// are not checked. Calls of unknown functions are ignored.
+// It requires function signatures to be available via context.
+type FunctionCall struct{}
+
+func (v FunctionCall) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	signatures, ok := schema.FunctionSignaturesFromContext(ctx)
+	if !ok || len(signatures) == 0 {
+		return ctx, diags
+	}
+
+	hclsyntax.VisitAll(attr.Expr, func(n hclsyntax.Node) hcl.Diagnostics {
+		callExpr, ok := n.(*hclsyntax.FunctionCallExpr)
+		if !ok {
+			return nil
+		}
+		sig, ok := signatures[callExpr.Name]
+		if !ok {
+			return nil
+		}
+
+		diags = append(diags, validateFunctionCall(callExpr, sig)...)
+		return nil
+	})
+
+	return ctx, diags
+}
+
+func validateFunctionCall(callExpr *hclsyntax.FunctionCallExpr, sig schema.FunctionSignature) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	// the final argument being expanded means the number
+	// of arguments is not known statically
+	if !callExpr.ExpandFinal {
+		if len(callExpr.Args) < len(sig.Params) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Not enough function arguments",
+				Detail: fmt.Sprintf("Function %q expects %d argument(s), %d given",
+					callExpr.Name, len(sig.Params), len(callExpr.Args)),
+				Subject: callExpr.Range().Ptr(),
+			})
+		}
+		if sig.VarParam == nil && len(callExpr.Args) > len(sig.Params) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Too many function arguments",
+				Detail: fmt.Sprintf("Function %q expects %d argument(s), %d given",
+					callExpr.Name, len(sig.Params), len(callExpr.Args)),
+				Subject: callExpr.Args[len(sig.Params)].Range().Ptr(),
+			})
+		}
+	}
+
+	for i, arg := range callExpr.Args {
+		var param *function.Parameter
+		if i < len(sig.Params) {
+			param = &sig.Params[i]
+		} else if sig.VarParam != nil {
+			param = sig.VarParam
+		}
+		if param == nil {
+			continue
+		}
+		if callExpr.ExpandFinal && i == len(callExpr.Args)-1 {
+			// expanded argument represents multiple values
+			continue
+		}
+
+		val, valDiags := arg.Value(nil)
+		if valDiags.HasErrors() || !val.IsWhollyKnown() || val.Type() == cty.DynamicPseudoType {
+			// type cannot be determined statically
+			continue
+		}
+		if val.IsNull() {
+			if !param.AllowNull {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid function argument",
+					Detail:   fmt.Sprintf("Invalid value for %q parameter: argument must not be null.", param.Name),
+					Subject:  arg.Range().Ptr(),
+				})
+			}
+			continue
+		}
+
+		if _, err := convert.Convert(val, param.Type); err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid function argument",
+				Detail:   fmt.Sprintf("Invalid value for %q parameter: %s.", param.Name, err),
+				Subject:  arg.Range().Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
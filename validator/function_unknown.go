@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/internal/suggest"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// UnknownFunction reports calls of functions which are not known,
+// along with a suggestion of the closest known function name, if any.
+//
+// It requires function signatures to be available via context
+// and does nothing if there are no known functions.
+type UnknownFunction struct{}
+
+func (v UnknownFunction) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	signatures, ok := schema.FunctionSignaturesFromContext(ctx)
+	if !ok || len(signatures) == 0 {
+		return ctx, diags
+	}
+
+	hclsyntax.VisitAll(attr.Expr, func(n hclsyntax.Node) hcl.Diagnostics {
+		callExpr, ok := n.(*hclsyntax.FunctionCallExpr)
+		if !ok {
+			return nil
+		}
+		if _, ok := signatures[callExpr.Name]; ok {
+			return nil
+		}
+
+		detail := fmt.Sprintf("There is no function named %q.", callExpr.Name)
+		if suggestion, ok := closestFunctionName(callExpr.Name, signatures); ok {
+			detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Unknown function",
+			Detail:   detail,
+			Subject:  callExpr.NameRange.Ptr(),
+		})
+		return nil
+	})
+
+	return ctx, diags
+}
+
+// closestFunctionName returns the known function name closest
+// to the given name, as long as it is reasonably close.
+func closestFunctionName(name string, signatures map[string]schema.FunctionSignature) (string, bool) {
+	names := make([]string, 0, len(signatures))
+	for fName := range signatures {
+		names = append(names, fName)
+	}
+	return suggest.ClosestName(name, names)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// UndeclaredMapKey reports static string values of attributes
+// constrained by MapKeyOf which are not keys of the referenced map.
+//
+// Maps whose keys are not statically known are not reported.
+// It requires reference targets to be available via context.
+type UndeclaredMapKey struct{}
+
+func (v UndeclaredMapKey) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	cons, ok := attrSchema.Constraint.(schema.MapKeyOf)
+	if !ok {
+		return ctx, diags
+	}
+
+	if len(attr.Expr.Variables()) > 0 {
+		// only static values can be checked
+		return ctx, diags
+	}
+	val, vDiags := attr.Expr.Value(nil)
+	if vDiags.HasErrors() || !val.IsWhollyKnown() || val.IsNull() || val.Type() != cty.String {
+		return ctx, diags
+	}
+
+	targets, ok := reference.TargetsFromContext(ctx)
+	if !ok {
+		return ctx, diags
+	}
+	keys, ok := targets.MapKeysOf(cons.Address)
+	if !ok {
+		return ctx, diags
+	}
+
+	key := val.AsString()
+	for _, k := range keys {
+		if k == key {
+			return ctx, diags
+		}
+	}
+
+	quotedKeys := make([]string, len(keys))
+	for i, k := range keys {
+		quotedKeys[i] = fmt.Sprintf("%q", k)
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Undeclared map key",
+		Detail: fmt.Sprintf("%q is not a key of %s, expected one of %s",
+			key, cons.Address.String(), strings.Join(quotedKeys, ", ")),
+		Subject: attr.Expr.Range().Ptr(),
+	})
+
+	return ctx, diags
+}
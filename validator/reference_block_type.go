@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ReferenceBlockType reports references which do not point
+// to the block type required by the Reference constraint.
+type ReferenceBlockType struct{}
+
+func (v ReferenceBlockType) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	cons, ok := attrSchema.Constraint.(schema.Reference)
+	if !ok || cons.OfBlockType == "" {
+		return ctx, diags
+	}
+
+	expr, ok := attr.Expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return ctx, diags
+	}
+	addr, err := lang.TraversalToAddress(expr.Traversal)
+	if err != nil || addr.IsRootedIn(cons.OfBlockType) {
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid reference block type",
+		Detail:   fmt.Sprintf("Reference %q is expected to refer to %q", addr.String(), cons.OfBlockType),
+		Subject:  expr.SrcRange.Ptr(),
+	})
+
+	return ctx, diags
+}
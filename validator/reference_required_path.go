@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// ReferenceRequiredPath reports references which resolve to a target
+// but do not end with the path required by the Reference constraint.
+//
+// It requires reference targets to be available via context.
+type ReferenceRequiredPath struct{}
+
+func (v ReferenceRequiredPath) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	cons, ok := attrSchema.Constraint.(schema.Reference)
+	if !ok || len(cons.RequiredPath) == 0 {
+		return ctx, diags
+	}
+
+	expr, ok := attr.Expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return ctx, diags
+	}
+	addr, err := lang.TraversalToAddress(expr.Traversal)
+	if err != nil || addr.HasSuffix(cons.RequiredPath) {
+		return ctx, diags
+	}
+
+	targets, ok := reference.TargetsFromContext(ctx)
+	if !ok || len(findTargets(targets, addr)) == 0 {
+		// unresolvable references are not a concern of this validator
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Missing required reference path",
+		Detail:   fmt.Sprintf("Reference %q is expected to end with %q", addr.String(), cons.RequiredPath.String()),
+		Subject:  expr.SrcRange.Ptr(),
+	})
+
+	return ctx, diags
+}
+
+// findTargets returns all targets (including nested ones)
+// addressable by the given address
+func findTargets(targets reference.Targets, addr lang.Address) reference.Targets {
+	found := make(reference.Targets, 0)
+	for _, target := range targets {
+		if target.Addr.Equals(addr) || target.LocalAddr.Equals(addr) {
+			found = append(found, target)
+		}
+		found = append(found, findTargets(target.NestedTargets, addr)...)
+	}
+	return found
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// UndefinedRequiredReference reports required attributes constrained
+// by Reference whose value does not resolve to any reference target.
+//
+// Unlike UndefinedReference it only applies to required attributes
+// and reports an error, since the configuration cannot be valid
+// without the target. Missing attributes are reported
+// by MissingRequiredAttribute instead.
+// It requires reference origins and targets to be available via context.
+type UndefinedRequiredReference struct{}
+
+func (v UndefinedRequiredReference) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	if !attrSchema.IsRequired {
+		return ctx, diags
+	}
+	if _, ok := attrSchema.Constraint.(schema.Reference); !ok {
+		return ctx, diags
+	}
+
+	for _, localOrigin := range undefinedLocalOrigins(ctx, attr.Expr.Range()) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Undefined required reference",
+			Detail: fmt.Sprintf("Required attribute %q references %q, which is not declared",
+				attr.Name, localOrigin.Address().String()),
+			Subject: localOrigin.Range.Ptr(),
+		})
+	}
+
+	return ctx, diags
+}
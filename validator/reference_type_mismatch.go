@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ReferenceTypeMismatch reports references which resolve to a target
+// of a type not convertible to the type required by the Reference constraint.
+//
+// Targets of unknown (dynamic) type are considered matching.
+// It requires reference targets to be available via context.
+type ReferenceTypeMismatch struct{}
+
+func (v ReferenceTypeMismatch) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+	attrSchema := nodeSchema.(*schema.AttributeSchema)
+	var cons schema.Reference
+	switch c := attrSchema.Constraint.(type) {
+	case schema.Reference:
+		cons = c
+	case schema.RefOrLiteral:
+		cons = schema.Reference{OfType: c.Type}
+	default:
+		return ctx, diags
+	}
+	if cons.OfType == cty.NilType || cons.OfType == cty.DynamicPseudoType {
+		return ctx, diags
+	}
+
+	expr, ok := attr.Expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return ctx, diags
+	}
+	addr, err := lang.TraversalToAddress(expr.Traversal)
+	if err != nil {
+		return ctx, diags
+	}
+
+	targets, ok := reference.TargetsFromContext(ctx)
+	if !ok {
+		return ctx, diags
+	}
+
+	var mismatchingType cty.Type
+	for _, target := range findTargets(targets, addr) {
+		if target.Type == cty.NilType {
+			// type-less targets are not a concern of this validator
+			return ctx, diags
+		}
+		if target.IsConvertibleToType(cons.OfType) {
+			return ctx, diags
+		}
+		mismatchingType = target.Type
+	}
+	if mismatchingType == cty.NilType {
+		// unresolvable references are not a concern of this validator
+		return ctx, diags
+	}
+
+	diags = append(diags, &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid reference type",
+		Detail: fmt.Sprintf("Reference %q is of type %s, expected %s",
+			addr.String(), mismatchingType.FriendlyName(), cons.OfType.FriendlyNameForConstraint()),
+		Subject: expr.SrcRange.Ptr(),
+	})
+
+	return ctx, diags
+}
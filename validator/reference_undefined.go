@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl-lang/reference"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// UndefinedReference reports references within attribute values
+// which do not match any reference target.
+//
+// Origins with optional constraints, i.e. those which may point
+// outside of the analyzed scope, are not reported.
+// It requires reference origins and targets to be available via context.
+type UndefinedReference struct{}
+
+func (v UndefinedReference) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	for _, localOrigin := range undefinedLocalOrigins(ctx, attr.Expr.Range()) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagWarning,
+			Summary:  "Undefined reference",
+			Detail:   fmt.Sprintf("No declaration found for %q", localOrigin.Address().String()),
+			Subject:  localOrigin.Range.Ptr(),
+		})
+	}
+
+	return ctx, diags
+}
+
+// undefinedLocalOrigins returns local origins within the given range
+// which do not match any reference target, skipping origins
+// with optional constraints.
+func undefinedLocalOrigins(ctx context.Context, rng hcl.Range) []reference.LocalOrigin {
+	undefined := make([]reference.LocalOrigin, 0)
+
+	origins, ok := reference.OriginsFromContext(ctx)
+	if !ok {
+		return undefined
+	}
+	targets, ok := reference.TargetsFromContext(ctx)
+	if !ok {
+		return undefined
+	}
+
+	for _, origin := range origins.InRange(rng) {
+		// origins pointing to other paths cannot be resolved here
+		localOrigin, ok := origin.(reference.LocalOrigin)
+		if !ok {
+			continue
+		}
+
+		if hasOptionalConstraint(localOrigin.OriginConstraints()) {
+			continue
+		}
+
+		if _, ok := targets.Match(localOrigin); ok {
+			continue
+		}
+
+		undefined = append(undefined, localOrigin)
+	}
+
+	return undefined
+}
+
+func hasOptionalConstraint(constraints reference.OriginConstraints) bool {
+	for _, cons := range constraints {
+		if cons.Optional {
+			return true
+		}
+	}
+	return false
+}
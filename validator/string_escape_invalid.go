@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl-lang/schemacontext"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// InvalidStringEscape reports invalid escape sequences
+// in quoted string literals of known attributes, such as "\q".
+//
+// Heredocs are not checked, since they do not support escaping.
+// It requires the file source to be available via context.
+type InvalidStringEscape struct{}
+
+func (v InvalidStringEscape) Visit(ctx context.Context, node hclsyntax.Node, nodeSchema schema.Schema) (context.Context, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	attr, ok := node.(*hclsyntax.Attribute)
+	if !ok {
+		return ctx, diags
+	}
+
+	if nodeSchema == nil {
+		return ctx, diags
+	}
+
+	src, ok := schemacontext.FileBytes(ctx)
+	if !ok {
+		return ctx, diags
+	}
+
+	var heredocRanges []hcl.Range
+	hclsyntax.VisitAll(attr.Expr, func(n hclsyntax.Node) hcl.Diagnostics {
+		tplExpr, ok := n.(*hclsyntax.TemplateExpr)
+		if !ok {
+			return nil
+		}
+		for _, rng := range heredocRanges {
+			if rng.Overlaps(tplExpr.SrcRange) {
+				// templates nested within heredocs,
+				// e.g. within directives, are not escaped either
+				return nil
+			}
+		}
+		if bytes.HasPrefix(tplExpr.SrcRange.SliceBytes(src), []byte("<<")) {
+			heredocRanges = append(heredocRanges, tplExpr.SrcRange)
+			return nil
+		}
+
+		for _, part := range tplExpr.Parts {
+			litExpr, ok := part.(*hclsyntax.LiteralValueExpr)
+			if !ok {
+				continue
+			}
+			diags = append(diags, invalidEscapeDiags(litExpr.SrcRange, src)...)
+		}
+		return nil
+	})
+
+	return ctx, diags
+}
+
+// invalidEscapeDiags returns diagnostics for any invalid escape
+// sequences in the source of the given (single-line) string literal.
+func invalidEscapeDiags(rng hcl.Range, src []byte) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	lit := rng.SliceBytes(src)
+	for i := 0; i < len(lit); i++ {
+		if lit[i] != '\\' {
+			continue
+		}
+
+		length, detail := escapeSequence(lit[i:])
+		if detail != "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid escape sequence",
+				Detail:   detail,
+				Subject:  literalSubRange(rng, lit, i, i+length).Ptr(),
+			})
+		}
+		// skip over the sequence, so that e.g. the second
+		// backslash of an escaped backslash isn't checked again
+		i += length - 1
+	}
+
+	return diags
+}
+
+// escapeSequence returns length of the escape sequence
+// at the beginning of b and detail explaining why it is invalid,
+// or empty detail if it is valid.
+func escapeSequence(b []byte) (int, string) {
+	if len(b) < 2 {
+		return len(b), "Backslash must be followed by an escape sequence selector character."
+	}
+
+	switch b[1] {
+	case 'n', 'r', 't', '"', '\\':
+		return 2, ""
+	case 'u', 'U':
+		digits, digitsName := 4, "four"
+		if b[1] == 'U' {
+			digits, digitsName = 8, "eight"
+		}
+		hexLen := 0
+		for hexLen < digits && 2+hexLen < len(b) && isHexDigit(b[2+hexLen]) {
+			hexLen++
+		}
+		if hexLen != digits {
+			return 2 + hexLen, fmt.Sprintf("The \\%c escape sequence must be followed by %s hexadecimal digits.",
+				b[1], digitsName)
+		}
+		num, err := strconv.ParseUint(string(b[2:2+digits]), 16, 32)
+		if err != nil || !utf8.ValidRune(rune(num)) {
+			return 2 + digits, fmt.Sprintf("Cannot encode character U+%04x in UTF-8.", num)
+		}
+		return 2 + digits, ""
+	}
+
+	_, size := utf8.DecodeRune(b[1:])
+	return 1 + size, fmt.Sprintf("The symbol %q is not a valid escape sequence selector.", b[1:1+size])
+}
+
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+// literalSubRange returns range of the given byte offsets
+// within the (single-line) literal of the given range
+func literalSubRange(rng hcl.Range, lit []byte, start, end int) hcl.Range {
+	startCol := rng.Start.Column + utf8.RuneCount(lit[:start])
+	endCol := startCol + utf8.RuneCount(lit[start:end])
+
+	return hcl.Range{
+		Filename: rng.Filename,
+		Start: hcl.Pos{
+			Line:   rng.Start.Line,
+			Column: startCol,
+			Byte:   rng.Start.Byte + start,
+		},
+		End: hcl.Pos{
+			Line:   rng.Start.Line,
+			Column: endCol,
+			Byte:   rng.Start.Byte + end,
+		},
+	}
+}